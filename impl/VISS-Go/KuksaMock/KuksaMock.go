@@ -0,0 +1,300 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package KuksaMock
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"VISS-Go/VapiKuksa"
+	kuksa "VISS-Go/VapiKuksa/kuksa/val/v2"
+	"VISS-Go/VissMock"
+)
+
+/* KuksaMock is an in-process fake of the KUKSA databroker for tests, it serves the kuksa.val.v2 VAL service on gRPC.
+*  The signals are held by a VissMock server, so an Actuate moves the actuator value towards the set value with the rate of the signal.
+*  Actuate is executed by the fake itself, there is no provider, and a Subscribe stream polls the signal values for changes. */
+type Server struct {
+	signals *VissMock.Server
+	grpcServer *grpc.Server
+	listener net.Listener
+	mutex sync.Mutex
+	token string
+	closeOnce sync.Once
+}
+
+const pollInterval = 50 * time.Millisecond
+
+func NewServer(signals []VissMock.Signal) *Server {
+	var server Server
+	server.signals = VissMock.NewServer(signals)
+	server.grpcServer = grpc.NewServer()
+	kuksa.RegisterVALServer(server.grpcServer, &valServer{server: &server})
+	return &server
+}
+
+// Start starts serving gRPC on address, e.g. "127.0.0.1:0", and returns the address that is listened on.
+func (server *Server) Start(address string) (string, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", err
+	}
+	server.listener = listener
+	go server.grpcServer.Serve(listener)
+	return listener.Addr().String(), nil
+}
+
+// Port returns the port number that is listened on, as used in VapiKuksa.RegisterVehicle.
+func (server *Server) Port() string {
+	_, port, _ := net.SplitHostPort(server.listener.Addr().String())
+	return port
+}
+
+func (server *Server) Close() {
+	server.closeOnce.Do(func() {
+		server.grpcServer.Stop()
+		server.signals.Close()
+	})
+}
+
+// RequireToken makes the server reject requests that do not have the authorization header "Bearer " + token.
+func (server *Server) RequireToken(token string) {
+	server.mutex.Lock()
+	server.token = token
+	server.mutex.Unlock()
+}
+
+// SetValue sets the current value of a signal directly, without actuator dynamics, e.g. to simulate a changed sensor value.
+func (server *Server) SetValue(path string, value string) bool {
+	return server.signals.SetValue(path, value)
+}
+
+func (server *Server) GetValue(path string) (string, bool) {
+	return server.signals.GetValue(path)
+}
+
+// ****************** VAL service ***************
+type valServer struct {
+	kuksa.UnimplementedVALServer
+	server *Server
+}
+
+func (val *valServer) GetValue(ctx context.Context, request *kuksa.GetValueRequest) (*kuksa.GetValueResponse, error) {
+	err := val.server.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signal, err := val.server.getSignal(request.GetSignalId())
+	if err != nil {
+		return nil, err
+	}
+	dataPoint, err := getDatapoint(signal)
+	if err != nil {
+		return nil, err
+	}
+	return &kuksa.GetValueResponse{DataPoint: dataPoint}, nil
+}
+
+func (val *valServer) GetValues(ctx context.Context, request *kuksa.GetValuesRequest) (*kuksa.GetValuesResponse, error) {
+	err := val.server.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var response kuksa.GetValuesResponse
+	for _, signalId := range request.GetSignalIds() {
+		signal, err := val.server.getSignal(signalId)
+		if err != nil {
+			return nil, err
+		}
+		dataPoint, err := getDatapoint(signal)
+		if err != nil {
+			return nil, err
+		}
+		response.DataPoints = append(response.DataPoints, dataPoint)
+	}
+	return &response, nil
+}
+
+func (val *valServer) Subscribe(request *kuksa.SubscribeRequest, stream kuksa.VAL_SubscribeServer) error {
+	err := val.server.authorize(stream.Context())
+	if err != nil {
+		return err
+	}
+	if len(request.GetSignalPaths()) == 0 {
+		return status.Error(codes.InvalidArgument, "No signal paths")
+	}
+	values := make(map[string]string)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		var response kuksa.SubscribeResponse
+		response.Entries = make(map[string]*kuksa.Datapoint)
+		for _, path := range request.GetSignalPaths() {
+			signal, err := val.server.getSignal(&kuksa.SignalID{Signal: &kuksa.SignalID_Path{Path: path}})
+			if err != nil {
+				return err
+			}
+			if value, ok := values[path]; ok && value == signal.Value {
+				continue
+			}
+			values[path] = signal.Value
+			response.Entries[path], err = getDatapoint(signal)
+			if err != nil {
+				return err
+			}
+		}
+		if len(response.Entries) > 0 {
+			err = stream.Send(&response)
+			if err != nil {
+				return err
+			}
+		}
+		select {
+			case <- ticker.C:
+			case <- stream.Context().Done():
+				return nil
+		}
+	}
+}
+
+func (val *valServer) Actuate(ctx context.Context, request *kuksa.ActuateRequest) (*kuksa.ActuateResponse, error) {
+	err := val.server.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signal, err := val.server.getSignal(request.GetSignalId())
+	if err != nil {
+		return nil, err
+	}
+	if signal.Type != "actuator" {
+		return nil, status.Error(codes.InvalidArgument, "Actuate request on a non-actuator: " + signal.Path)
+	}
+	value, err := getValue(signal, request.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	val.server.signals.Actuate(signal.Path, value)
+	return &kuksa.ActuateResponse{}, nil
+}
+
+func (val *valServer) PublishValue(ctx context.Context, request *kuksa.PublishValueRequest) (*kuksa.PublishValueResponse, error) {
+	err := val.server.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signal, err := val.server.getSignal(request.GetSignalId())
+	if err != nil {
+		return nil, err
+	}
+	value, err := getValue(signal, request.GetDataPoint().GetValue())
+	if err != nil {
+		return nil, err
+	}
+	val.server.signals.SetValue(signal.Path, value)
+	return &kuksa.PublishValueResponse{}, nil
+}
+
+func (val *valServer) ListMetadata(ctx context.Context, request *kuksa.ListMetadataRequest) (*kuksa.ListMetadataResponse, error) {
+	err := val.server.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var response kuksa.ListMetadataResponse
+	for i, signal := range val.server.signals.Signals() {
+		if signal.Type == "branch" || !matchRoot(request.GetRoot(), signal.Path) {
+			continue
+		}
+		response.Metadata = append(response.Metadata, &kuksa.Metadata{Path: signal.Path, Id: int32(i), DataType: VapiKuksa.DataTypeOf(signal.Datatype),
+			EntryType: VapiKuksa.EntryTypeOf(signal.Type), Description: signal.Description})
+	}
+	if len(response.Metadata) == 0 {
+		return nil, status.Error(codes.NotFound, "Path not found: " + request.GetRoot())
+	}
+	return &response, nil
+}
+
+func (val *valServer) GetServerInfo(ctx context.Context, request *kuksa.GetServerInfoRequest) (*kuksa.GetServerInfoResponse, error) {
+	return &kuksa.GetServerInfoResponse{Name: "KuksaMock", Version: "0.0.1"}, nil
+}
+
+func (server *Server) authorize(ctx context.Context) error {
+	server.mutex.Lock()
+	token := server.token
+	server.mutex.Unlock()
+	if token == "" {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	authorization := md.Get("authorization")
+	if len(authorization) == 0 {
+		return status.Error(codes.Unauthenticated, "Missing authorization")
+	}
+	if authorization[0] != "Bearer " + token {
+		return status.Error(codes.PermissionDenied, "Invalid token")
+	}
+	return nil
+}
+
+func (server *Server) getSignal(signalId *kuksa.SignalID) (VissMock.Signal, error) {
+	for i, signal := range server.signals.Signals() {
+		if signal.Type == "branch" {
+			continue
+		}
+		if signalId.GetPath() == signal.Path || (signalId.GetPath() == "" && signalId.GetId() == int32(i)) {
+			return signal, nil
+		}
+	}
+	return VissMock.Signal{}, status.Error(codes.NotFound, fmt.Sprintf("Signal not found: %v", signalId))
+}
+
+func getDatapoint(signal VissMock.Signal) (*kuksa.Datapoint, error) {
+	if signal.Value == "" && signal.Datatype != "string" {
+		return &kuksa.Datapoint{}, nil  // no value yet
+	}
+	value, err := VapiKuksa.ParseValue(signal.Value, VapiKuksa.DataTypeOf(signal.Datatype))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &kuksa.Datapoint{Value: value}, nil
+}
+
+func getValue(signal VissMock.Signal, value *kuksa.Value) (string, error) { // the value in the VISS format, the typed value must be the one of the data type of the signal
+	formatted := VapiKuksa.FormatValue(value)
+	expected, err := VapiKuksa.ParseValue(formatted, VapiKuksa.DataTypeOf(signal.Datatype))
+	if err != nil || fmt.Sprintf("%T", expected.GetTypedValue()) != fmt.Sprintf("%T", value.GetTypedValue()) {
+		return "", status.Error(codes.InvalidArgument, "Value does not match the data type of " + signal.Path)
+	}
+	return formatted, nil
+}
+
+func matchRoot(root string, path string) bool { // a root matches itself and the leaves below it, and * matches one path segment
+	if root == "" {
+		return true
+	}
+	rootSegments := strings.Split(root, ".")
+	pathSegments := strings.Split(path, ".")
+	if len(pathSegments) < len(rootSegments) {
+		return false
+	}
+	for i := 0; i < len(rootSegments); i++ {
+		if rootSegments[i] != "*" && rootSegments[i] != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,534 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiKuksa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"VISS-Go/VapiViss"
+	kuksa "VISS-Go/VapiKuksa/kuksa/val/v2"
+)
+
+// ****************** VISS to kuksa.val.v2 translation ***************
+/* The transport executes the VISS requests get, set, subscribe and unsubscribe by the VAL service of the databroker.
+*  - get: the path, and the paths of a paths filter, are expanded to the leaves by ListMetadata, and their values are read by GetValues.
+*    With a metadata filter the response is the VISS metadata tree that is built from the ListMetadata response.
+*  - set: an actuator is set by Actuate, other signals by PublishValue. The value is converted to the data type of the signal.
+*  - subscribe: a timebased filter is executed by a GetValues per period, other subscriptions by a Subscribe stream,
+*    i. e. with an event when a value is changed. An event contains the values of all subscribed leaves.
+*  The short term credentials of a request are sent as the bearer token of the authorization header. */
+const requestTimeout = 10 * time.Second
+const firstEventDelay = 100 * time.Millisecond
+
+type kuksaTransport struct {
+	conn *grpc.ClientConn
+	client kuksa.VALClient
+	serverChan chan []byte
+	closeChan chan struct{}
+	mutex sync.Mutex
+	closed bool
+	handlers sync.WaitGroup
+	subscriptions map[string]context.CancelFunc
+	leaves map[string][]*kuksa.Metadata  // ListMetadata responses by root
+}
+
+var subscriptionCounter uint32
+
+func dialKuksa(socket string) (VapiViss.Transport, error) {
+	conn, err := grpc.NewClient(socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	var transport kuksaTransport
+	transport.conn = conn
+	transport.client = kuksa.NewVALClient(conn)
+	transport.serverChan = make(chan []byte)
+	transport.closeChan = make(chan struct{})
+	transport.subscriptions = make(map[string]context.CancelFunc)
+	transport.leaves = make(map[string][]*kuksa.Metadata)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err = transport.client.GetServerInfo(ctx, &kuksa.GetServerInfoRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &transport, nil
+}
+
+func (transport *kuksaTransport) Send(clientMessage []byte) {
+	var requestMap map[string]interface{}
+	err := json.Unmarshal(clientMessage, &requestMap)
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	if transport.closed {
+		return
+	}
+	transport.handlers.Add(1)
+	go func() {
+		defer transport.handlers.Done()
+		if err != nil {
+			transport.sendServerMessage(map[string]interface{}{"action": "unknown", "error": getErrorMap(400, "bad_request", "Invalid JSON"), "ts": getTimestamp()})
+			return
+		}
+		responseMap, start := transport.processRequest(requestMap)
+		if transport.sendServerMessage(responseMap) && start != nil {
+			start()
+		}
+	}()
+}
+
+func (transport *kuksaTransport) Receive() <-chan []byte {
+	return transport.serverChan
+}
+
+func (transport *kuksaTransport) Close() {
+	transport.mutex.Lock()
+	if transport.closed {
+		transport.mutex.Unlock()
+		return
+	}
+	transport.closed = true
+	for _, cancel := range transport.subscriptions {
+		cancel()
+	}
+	transport.subscriptions = nil
+	transport.mutex.Unlock()
+	close(transport.closeChan)
+	transport.conn.Close()
+	go func() {
+		transport.handlers.Wait()
+		close(transport.serverChan)
+	}()
+}
+
+func (transport *kuksaTransport) sendServerMessage(messageMap map[string]interface{}) bool {
+	if messageMap == nil {
+		return true
+	}
+	message, _ := json.Marshal(messageMap)
+	select {
+		case transport.serverChan <- message:
+			return true
+		case <- transport.closeChan:
+			return false
+	}
+}
+
+func (transport *kuksaTransport) processRequest(requestMap map[string]interface{}) (map[string]interface{}, func()) {  // the response, and the start of a subscription after the response is sent
+	action, _ := requestMap["action"].(string)
+	requestId, _ := requestMap["requestId"].(string)
+	responseMap := map[string]interface{}{"action": action, "requestId": requestId, "ts": getTimestamp()}
+	path, _ := requestMap["path"].(string)
+	ctx, cancel := transport.getContext(requestMap["authorization"])
+	defer cancel()
+	var errorMap map[string]interface{}
+	var start func()
+	switch action {
+		case "get":
+			if isMetadataFilter(requestMap["filter"]) {
+				responseMap["metadata"], errorMap = transport.getMetadata(ctx, path)
+			} else {
+				responseMap["data"], errorMap = transport.getData(ctx, path, requestMap["filter"])
+			}
+		case "set":
+			errorMap = transport.setValue(ctx, path, fmt.Sprint(requestMap["value"]))
+		case "subscribe":
+			responseMap["subscriptionId"], errorMap, start = transport.startSubscription(path, requestMap["filter"], requestMap["authorization"])
+		case "unsubscribe":
+			subscriptionId, _ := requestMap["subscriptionId"].(string)
+			responseMap["subscriptionId"] = subscriptionId
+			errorMap = transport.stopSubscription(subscriptionId)
+		default:
+			errorMap = getErrorMap(400, "bad_request", "Unknown action")
+	}
+	if errorMap != nil {
+		delete(responseMap, "metadata")
+		delete(responseMap, "data")
+		responseMap["error"] = errorMap
+	}
+	return responseMap, start
+}
+
+func (transport *kuksaTransport) getContext(authorization interface{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	return withAuthorization(ctx, authorization), cancel
+}
+
+func withAuthorization(ctx context.Context, authorization interface{}) context.Context {
+	token, _ := authorization.(string)
+	if token == "" {
+		return ctx
+	}
+	if !strings.HasPrefix(token, "Bearer ") {
+		token = "Bearer " + token
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", token)
+}
+
+func (transport *kuksaTransport) getLeaves(ctx context.Context, path string, filter interface{}) ([]*kuksa.Metadata, map[string]interface{}) {
+	roots := []string{path}
+	relativePaths := getPathsParameter(filter)
+	if len(relativePaths) > 0 {
+		roots = make([]string, len(relativePaths))
+		for i := 0; i < len(relativePaths); i++ {
+			roots[i] = path + "." + relativePaths[i]
+		}
+	}
+	var leaves []*kuksa.Metadata
+	for _, root := range roots {
+		transport.mutex.Lock()
+		rootLeaves, ok := transport.leaves[root]
+		transport.mutex.Unlock()
+		if !ok {
+			response, err := transport.client.ListMetadata(ctx, &kuksa.ListMetadataRequest{Root: root})
+			if err != nil {
+				return nil, getStatusErrorMap(err)
+			}
+			rootLeaves = response.GetMetadata()
+			transport.mutex.Lock()
+			transport.leaves[root] = rootLeaves
+			transport.mutex.Unlock()
+		}
+		leaves = append(leaves, rootLeaves...)
+	}
+	if len(leaves) == 0 {
+		return nil, getErrorMap(404, "unavailable_data", "Path not found: " + path)
+	}
+	return leaves, nil
+}
+
+func (transport *kuksaTransport) getData(ctx context.Context, path string, filter interface{}) (interface{}, map[string]interface{}) {
+	if isHistoryFilter(filter) {  // the databroker keeps only the current values
+		return nil, getErrorMap(400, "bad_request", "The history filter is not supported by the databroker")
+	}
+	leaves, errorMap := transport.getLeaves(ctx, path, filter)
+	if errorMap != nil {
+		return nil, errorMap
+	}
+	return transport.readValues(ctx, leaves)
+}
+
+func (transport *kuksaTransport) readValues(ctx context.Context, leaves []*kuksa.Metadata) (interface{}, map[string]interface{}) {
+	request := &kuksa.GetValuesRequest{SignalIds: make([]*kuksa.SignalID, len(leaves))}
+	for i := 0; i < len(leaves); i++ {
+		request.SignalIds[i] = &kuksa.SignalID{Signal: &kuksa.SignalID_Path{Path: leaves[i].GetPath()}}
+	}
+	response, err := transport.client.GetValues(ctx, request)
+	if err != nil {
+		return nil, getStatusErrorMap(err)
+	}
+	dataPoints := make(map[string]*kuksa.Datapoint)
+	for i, dataPoint := range response.GetDataPoints() {
+		if i < len(leaves) {
+			dataPoints[leaves[i].GetPath()] = dataPoint
+		}
+	}
+	return getDataMap(leaves, dataPoints), nil
+}
+
+func getDataMap(leaves []*kuksa.Metadata, dataPoints map[string]*kuksa.Datapoint) interface{} {
+	data := make([]interface{}, len(leaves))
+	for i := 0; i < len(leaves); i++ {
+		dataPoint := dataPoints[leaves[i].GetPath()]
+		ts := getTimestamp()
+		if dataPoint.GetTimestamp() != nil {
+			ts = dataPoint.GetTimestamp().AsTime().UTC().Format(time.RFC3339Nano)
+		}
+		data[i] = map[string]interface{}{"path": leaves[i].GetPath(), "dp": map[string]interface{}{"value": FormatValue(dataPoint.GetValue()), "ts": ts}}
+	}
+	if len(data) == 1 {
+		return data[0]
+	}
+	return data
+}
+
+func (transport *kuksaTransport) getMetadata(ctx context.Context, path string) (interface{}, map[string]interface{}) {
+	leaves, errorMap := transport.getLeaves(ctx, path, nil)
+	if errorMap != nil {
+		return nil, errorMap
+	}
+	root := make(map[string]interface{})
+	for _, leaf := range leaves {
+		segments := strings.Split(leaf.GetPath(), ".")
+		children := root
+		for i := 0; i < len(segments) - 1; i++ {
+			if children[segments[i]] == nil {
+				children[segments[i]] = map[string]interface{}{"type": "branch", "description": segments[i] + " branch.", "children": make(map[string]interface{})}
+			}
+			children = children[segments[i]].(map[string]interface{})["children"].(map[string]interface{})
+		}
+		node := map[string]interface{}{"type": EntryTypeName(leaf.GetEntryType()), "datatype": DatatypeName(leaf.GetDataType()), "description": leaf.GetDescription()}
+		if leaf.GetUnit() != "" {
+			node["unit"] = leaf.GetUnit()
+		}
+		if leaf.GetMin() != nil {
+			node["min"] = FormatValue(leaf.GetMin())
+		}
+		if leaf.GetMax() != nil {
+			node["max"] = FormatValue(leaf.GetMax())
+		}
+		if leaf.GetAllowedValues() != nil {
+			var allowed []string
+			json.Unmarshal([]byte(FormatValue(leaf.GetAllowedValues())), &allowed)
+			node["allowed"] = allowed
+		}
+		children[segments[len(segments)-1]] = node
+	}
+	return root, nil
+}
+
+func (transport *kuksaTransport) setValue(ctx context.Context, path string, value string) map[string]interface{} {
+	leaves, errorMap := transport.getLeaves(ctx, path, nil)
+	if errorMap != nil {
+		return errorMap
+	}
+	if len(leaves) != 1 || leaves[0].GetPath() != path {
+		return getErrorMap(400, "bad_request", "Set request on a branch: " + path)
+	}
+	kuksaValue, err := ParseValue(value, leaves[0].GetDataType())
+	if err != nil {
+		return getErrorMap(400, "bad_request", "Invalid value for " + path + ": " + err.Error())
+	}
+	signalId := &kuksa.SignalID{Signal: &kuksa.SignalID_Path{Path: path}}
+	if leaves[0].GetEntryType() == kuksa.EntryType_ENTRY_TYPE_ACTUATOR {
+		_, err = transport.client.Actuate(ctx, &kuksa.ActuateRequest{SignalId: signalId, Value: kuksaValue})
+	} else {
+		_, err = transport.client.PublishValue(ctx, &kuksa.PublishValueRequest{SignalId: signalId, DataPoint: &kuksa.Datapoint{Value: kuksaValue}})
+	}
+	if err != nil {
+		return getStatusErrorMap(err)
+	}
+	return nil
+}
+
+/* startSubscription returns the subscriptionId, and a function that sends the events. VapiViss registers the subscriptionId
+*  when it has received the subscribe response, so the first event is sent firstEventDelay after the response. */
+func (transport *kuksaTransport) startSubscription(path string, filter interface{}, authorization interface{}) (interface{}, map[string]interface{}, func()) {
+	ctx, cancel := context.WithCancel(withAuthorization(context.Background(), authorization))
+	requestCtx, requestCancel := context.WithTimeout(ctx, requestTimeout)
+	defer requestCancel()
+	leaves, errorMap := transport.getLeaves(requestCtx, path, filter)
+	if errorMap != nil {
+		cancel()
+		return nil, errorMap, nil
+	}
+	var next func() (interface{}, map[string]interface{})
+	stop := func() {}  // releases the resources of next when the handler returns
+	period, isTimebased := getPeriod(filter)
+	if isTimebased {
+		_, errorMap = transport.readValues(requestCtx, leaves)
+		if errorMap != nil {
+			cancel()
+			return nil, errorMap, nil
+		}
+		ticker := time.NewTicker(period)
+		stop = ticker.Stop
+		next = func() (interface{}, map[string]interface{}) {
+			select {
+				case <- ticker.C:
+					readCtx, readCancel := context.WithTimeout(ctx, requestTimeout)
+					defer readCancel()
+					return transport.readValues(readCtx, leaves)
+				case <- ctx.Done():
+					return nil, nil
+			}
+		}
+	} else {
+		request := &kuksa.SubscribeRequest{SignalPaths: make([]string, len(leaves))}
+		for i := 0; i < len(leaves); i++ {
+			request.SignalPaths[i] = leaves[i].GetPath()
+		}
+		stream, err := transport.client.Subscribe(ctx, request)
+		if err != nil {
+			cancel()
+			return nil, getStatusErrorMap(err), nil
+		}
+		dataPoints := make(map[string]*kuksa.Datapoint)
+		response, err := stream.Recv()  // the first response has the current values, and reports an unknown path
+		if err != nil {
+			cancel()
+			return nil, getStatusErrorMap(err), nil
+		}
+		first := true
+		next = func() (interface{}, map[string]interface{}) {
+			if !first {
+				response, err = stream.Recv()
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil, nil
+					}
+					return nil, getStatusErrorMap(err)
+				}
+			}
+			first = false
+			for path, dataPoint := range response.GetEntries() {
+				dataPoints[path] = dataPoint
+			}
+			return getDataMap(leaves, dataPoints), nil
+		}
+	}
+	subscriptionId := strconv.FormatUint(uint64(atomic.AddUint32(&subscriptionCounter, 1)), 10)
+	transport.mutex.Lock()
+	if transport.closed {
+		transport.mutex.Unlock()
+		cancel()
+		stop()
+		return nil, getErrorMap(503, "service_unavailable", "Connection closed"), nil
+	}
+	transport.subscriptions[subscriptionId] = cancel
+	transport.handlers.Add(1)
+	transport.mutex.Unlock()
+	return subscriptionId, nil, func() {
+		defer transport.handlers.Done()
+		defer stop()
+		select {
+			case <- time.After(firstEventDelay):
+			case <- ctx.Done():
+				return
+		}
+		for {
+			data, errorMap := next()
+			if data == nil && errorMap == nil {
+				return
+			}
+			eventMap := map[string]interface{}{"action": "subscription", "subscriptionId": subscriptionId, "ts": getTimestamp()}
+			if errorMap != nil {
+				eventMap["error"] = errorMap
+			} else {
+				eventMap["data"] = data
+			}
+			if !transport.sendServerMessage(eventMap) || errorMap != nil {
+				transport.stopSubscription(subscriptionId)
+				return
+			}
+		}
+	}
+}
+
+func (transport *kuksaTransport) stopSubscription(subscriptionId string) map[string]interface{} {
+	transport.mutex.Lock()
+	cancel := transport.subscriptions[subscriptionId]
+	delete(transport.subscriptions, subscriptionId)
+	transport.mutex.Unlock()
+	if cancel == nil {
+		return getErrorMap(404, "invalid_data", "Unknown subscriptionId")
+	}
+	cancel()
+	return nil
+}
+
+func getStatusErrorMap(err error) map[string]interface{} {
+	if err == io.EOF {
+		return getErrorMap(503, "service_unavailable", "The subscription was terminated by the databroker")
+	}
+	grpcStatus := status.Convert(err)
+	switch grpcStatus.Code() {
+		case codes.NotFound:
+			return getErrorMap(404, "unavailable_data", grpcStatus.Message())
+		case codes.InvalidArgument:
+			return getErrorMap(400, "bad_request", grpcStatus.Message())
+		case codes.Unauthenticated:
+			return getErrorMap(401, "invalid_token", grpcStatus.Message())
+		case codes.PermissionDenied:
+			return getErrorMap(403, "forbidden_request", grpcStatus.Message())
+		case codes.Unavailable:
+			return getErrorMap(503, "service_unavailable", grpcStatus.Message())
+	}
+	return getErrorMap(502, "bad_gateway", grpcStatus.Message())
+}
+
+func isMetadataFilter(filter interface{}) bool {
+	for _, filterMap := range getFilterList(filter) {
+		if filterMap["variant"] == "metadata" {
+			return true
+		}
+	}
+	return false
+}
+
+func isHistoryFilter(filter interface{}) bool {
+	for _, filterMap := range getFilterList(filter) {
+		if filterMap["variant"] == "history" {
+			return true
+		}
+	}
+	return false
+}
+
+func getPathsParameter(filter interface{}) []string {
+	for _, filterMap := range getFilterList(filter) {
+		if filterMap["variant"] == "paths" {
+			switch parameter := filterMap["parameter"].(type) {
+				case string:
+					return []string{parameter}
+				case []interface{}:
+					paths := make([]string, 0, len(parameter))
+					for _, relativePath := range parameter {
+						paths = append(paths, fmt.Sprint(relativePath))
+					}
+					return paths
+			}
+		}
+	}
+	return nil
+}
+
+func getPeriod(filter interface{}) (time.Duration, bool) {
+	for _, filterMap := range getFilterList(filter) {
+		if filterMap["variant"] == "timebased" {
+			if parameter, ok := filterMap["parameter"].(map[string]interface{}); ok {
+				period, err := strconv.Atoi(fmt.Sprint(parameter["period"]))
+				if err == nil && period > 0 {
+					return time.Duration(period) * time.Millisecond, true
+				}
+			}
+			return time.Second, true
+		}
+	}
+	return 0, false
+}
+
+func getFilterList(filter interface{}) []map[string]interface{} {
+	switch vv := filter.(type) {
+		case map[string]interface{}:
+			return []map[string]interface{}{vv}
+		case []interface{}:
+			var filterList []map[string]interface{}
+			for i := 0; i < len(vv); i++ {
+				if filterMap, ok := vv[i].(map[string]interface{}); ok {
+					filterList = append(filterList, filterMap)
+				}
+			}
+			return filterList
+	}
+	return nil
+}
+
+func getErrorMap(number int, reason string, description string) map[string]interface{} {
+	return map[string]interface{}{"number": strconv.Itoa(number), "reason": reason, "description": description}
+}
+
+func getTimestamp() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000000Z")
+}
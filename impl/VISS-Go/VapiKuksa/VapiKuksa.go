@@ -0,0 +1,296 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiKuksa
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"VISS-Go/VapiViss"
+	"VISS-Go/vapi"
+	kuksa "VISS-Go/VapiKuksa/kuksa/val/v2"
+)
+
+/* VapiKuksa is the VAPI backend for vehicles that run the Eclipse KUKSA databroker.
+*  It registers the protocol KUKSA_PROTOCOL as a VapiViss transport, which translates the VISS messages of VapiViss
+*  to calls of the kuksa.val.v2 gRPC API, so the procedures of VapiViss, including the seating services, are executed on the databroker.
+*  The kuksa.val.v1 API is not supported, it is deprecated by the databroker. */
+const KUKSA_PROTOCOL = "KUKSA.val.v2"
+
+func init() {
+	VapiViss.RegisterTransport(KUKSA_PROTOCOL, dialKuksa)
+}
+
+// ****************** VAPI backend ***************
+// Backend implements the vapi interfaces by the VapiViss procedures, the vehicles are connected with the protocol KUKSA_PROTOCOL.
+type Backend struct {
+	VapiViss.Backend
+}
+
+var _ vapi.Vapi = Backend{}
+
+func NewBackend() vapi.Vapi {
+	return Backend{}
+}
+
+// RegisterVehicle makes a vehicle known to GetVehicle that is connected to the databroker on ipAddress:portNo.
+func RegisterVehicle(vehicleGuid string, ipAddress string, portNo string) {
+	VapiViss.RegisterVehicle(vehicleGuid, ipAddress, []VapiViss.ConnectivityData{{PortNo: portNo, Protocol: KUKSA_PROTOCOL}})
+}
+
+// ****************** Value conversion ***************
+var dataTypeNames = map[kuksa.DataType]string{
+	kuksa.DataType_DATA_TYPE_STRING: "string",
+	kuksa.DataType_DATA_TYPE_BOOLEAN: "boolean",
+	kuksa.DataType_DATA_TYPE_INT8: "int8",
+	kuksa.DataType_DATA_TYPE_INT16: "int16",
+	kuksa.DataType_DATA_TYPE_INT32: "int32",
+	kuksa.DataType_DATA_TYPE_INT64: "int64",
+	kuksa.DataType_DATA_TYPE_UINT8: "uint8",
+	kuksa.DataType_DATA_TYPE_UINT16: "uint16",
+	kuksa.DataType_DATA_TYPE_UINT32: "uint32",
+	kuksa.DataType_DATA_TYPE_UINT64: "uint64",
+	kuksa.DataType_DATA_TYPE_FLOAT: "float",
+	kuksa.DataType_DATA_TYPE_DOUBLE: "double",
+	kuksa.DataType_DATA_TYPE_TIMESTAMP: "timestamp",
+	kuksa.DataType_DATA_TYPE_STRING_ARRAY: "string[]",
+	kuksa.DataType_DATA_TYPE_BOOLEAN_ARRAY: "boolean[]",
+	kuksa.DataType_DATA_TYPE_INT8_ARRAY: "int8[]",
+	kuksa.DataType_DATA_TYPE_INT16_ARRAY: "int16[]",
+	kuksa.DataType_DATA_TYPE_INT32_ARRAY: "int32[]",
+	kuksa.DataType_DATA_TYPE_INT64_ARRAY: "int64[]",
+	kuksa.DataType_DATA_TYPE_UINT8_ARRAY: "uint8[]",
+	kuksa.DataType_DATA_TYPE_UINT16_ARRAY: "uint16[]",
+	kuksa.DataType_DATA_TYPE_UINT32_ARRAY: "uint32[]",
+	kuksa.DataType_DATA_TYPE_UINT64_ARRAY: "uint64[]",
+	kuksa.DataType_DATA_TYPE_FLOAT_ARRAY: "float[]",
+	kuksa.DataType_DATA_TYPE_DOUBLE_ARRAY: "double[]",
+	kuksa.DataType_DATA_TYPE_TIMESTAMP_ARRAY: "timestamp[]",
+}
+
+// DatatypeName returns the VSS datatype of a KUKSA data type, e.g. "uint8".
+func DatatypeName(dataType kuksa.DataType) string {
+	return dataTypeNames[dataType]
+}
+
+// DataTypeOf returns the KUKSA data type of a VSS datatype, or DATA_TYPE_UNSPECIFIED if it is unknown.
+func DataTypeOf(datatype string) kuksa.DataType {
+	for dataType, name := range dataTypeNames {
+		if name == datatype {
+			return dataType
+		}
+	}
+	return kuksa.DataType_DATA_TYPE_UNSPECIFIED
+}
+
+var entryTypeNames = map[kuksa.EntryType]string{
+	kuksa.EntryType_ENTRY_TYPE_ATTRIBUTE: "attribute",
+	kuksa.EntryType_ENTRY_TYPE_SENSOR: "sensor",
+	kuksa.EntryType_ENTRY_TYPE_ACTUATOR: "actuator",
+}
+
+// EntryTypeName returns the VSS node type of a KUKSA entry type, e.g. "actuator".
+func EntryTypeName(entryType kuksa.EntryType) string {
+	return entryTypeNames[entryType]
+}
+
+// EntryTypeOf returns the KUKSA entry type of a VSS node type, or ENTRY_TYPE_UNSPECIFIED if it is not a leaf type.
+func EntryTypeOf(nodeType string) kuksa.EntryType {
+	for entryType, name := range entryTypeNames {
+		if name == nodeType {
+			return entryType
+		}
+	}
+	return kuksa.EntryType_ENTRY_TYPE_UNSPECIFIED
+}
+
+/* ParseValue converts a VISS value to a KUKSA value of dataType. Integer types accept a decimal value that is rounded,
+*  and the values of the array types are JSON arrays, e.g. ["1", "2"] or [1, 2]. */
+func ParseValue(value string, dataType kuksa.DataType) (*kuksa.Value, error) {
+	if strings.HasSuffix(DatatypeName(dataType), "[]") {
+		return parseArrayValue(value, dataType)
+	}
+	switch dataType {
+		case kuksa.DataType_DATA_TYPE_STRING:
+			return &kuksa.Value{TypedValue: &kuksa.Value_String_{String_: value}}, nil
+		case kuksa.DataType_DATA_TYPE_BOOLEAN:
+			boolValue, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, err
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Bool{Bool: boolValue}}, nil
+		case kuksa.DataType_DATA_TYPE_INT8, kuksa.DataType_DATA_TYPE_INT16, kuksa.DataType_DATA_TYPE_INT32:
+			intValue, err := parseInteger(value, math.MinInt32, math.MaxInt32)
+			if err != nil {
+				return nil, err
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Int32{Int32: int32(intValue)}}, nil
+		case kuksa.DataType_DATA_TYPE_INT64:
+			intValue, err := parseInteger(value, math.MinInt64, math.MaxInt64)
+			if err != nil {
+				return nil, err
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Int64{Int64: int64(intValue)}}, nil
+		case kuksa.DataType_DATA_TYPE_UINT8, kuksa.DataType_DATA_TYPE_UINT16, kuksa.DataType_DATA_TYPE_UINT32:
+			intValue, err := parseInteger(value, 0, math.MaxUint32)
+			if err != nil {
+				return nil, err
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Uint32{Uint32: uint32(intValue)}}, nil
+		case kuksa.DataType_DATA_TYPE_UINT64, kuksa.DataType_DATA_TYPE_TIMESTAMP:
+			intValue, err := parseInteger(value, 0, math.MaxUint64)
+			if err != nil {
+				return nil, err
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Uint64{Uint64: uint64(intValue)}}, nil
+		case kuksa.DataType_DATA_TYPE_FLOAT:
+			floatValue, err := strconv.ParseFloat(value, 32)
+			if err != nil {
+				return nil, err
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Float{Float: float32(floatValue)}}, nil
+		case kuksa.DataType_DATA_TYPE_DOUBLE:
+			floatValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, err
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Double{Double: floatValue}}, nil
+	}
+	return nil, fmt.Errorf("unsupported data type %s", dataType)
+}
+
+func parseInteger(value string, min float64, max float64) (float64, error) {
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	floatValue = math.Round(floatValue)
+	if floatValue < min || floatValue > max {
+		return 0, fmt.Errorf("value %s out of range", value)
+	}
+	return floatValue, nil
+}
+
+func parseArrayValue(value string, dataType kuksa.DataType) (*kuksa.Value, error) {
+	var elements []interface{}
+	err := json.Unmarshal([]byte(value), &elements)
+	if err != nil {
+		return nil, err
+	}
+	elementType := DataTypeOf(strings.TrimSuffix(DatatypeName(dataType), "[]"))
+	parsed := make([]*kuksa.Value, len(elements))
+	for i := 0; i < len(elements); i++ {
+		parsed[i], err = ParseValue(fmt.Sprint(elements[i]), elementType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch dataType {
+		case kuksa.DataType_DATA_TYPE_STRING_ARRAY:
+			array := &kuksa.StringArray{}
+			for _, element := range parsed {
+				array.Values = append(array.Values, element.GetString_())
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_StringArray{StringArray: array}}, nil
+		case kuksa.DataType_DATA_TYPE_BOOLEAN_ARRAY:
+			array := &kuksa.BoolArray{}
+			for _, element := range parsed {
+				array.Values = append(array.Values, element.GetBool())
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_BoolArray{BoolArray: array}}, nil
+		case kuksa.DataType_DATA_TYPE_INT8_ARRAY, kuksa.DataType_DATA_TYPE_INT16_ARRAY, kuksa.DataType_DATA_TYPE_INT32_ARRAY:
+			array := &kuksa.Int32Array{}
+			for _, element := range parsed {
+				array.Values = append(array.Values, element.GetInt32())
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Int32Array{Int32Array: array}}, nil
+		case kuksa.DataType_DATA_TYPE_INT64_ARRAY:
+			array := &kuksa.Int64Array{}
+			for _, element := range parsed {
+				array.Values = append(array.Values, element.GetInt64())
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Int64Array{Int64Array: array}}, nil
+		case kuksa.DataType_DATA_TYPE_UINT8_ARRAY, kuksa.DataType_DATA_TYPE_UINT16_ARRAY, kuksa.DataType_DATA_TYPE_UINT32_ARRAY:
+			array := &kuksa.Uint32Array{}
+			for _, element := range parsed {
+				array.Values = append(array.Values, element.GetUint32())
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Uint32Array{Uint32Array: array}}, nil
+		case kuksa.DataType_DATA_TYPE_UINT64_ARRAY, kuksa.DataType_DATA_TYPE_TIMESTAMP_ARRAY:
+			array := &kuksa.Uint64Array{}
+			for _, element := range parsed {
+				array.Values = append(array.Values, element.GetUint64())
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_Uint64Array{Uint64Array: array}}, nil
+		case kuksa.DataType_DATA_TYPE_FLOAT_ARRAY:
+			array := &kuksa.FloatArray{}
+			for _, element := range parsed {
+				array.Values = append(array.Values, element.GetFloat())
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_FloatArray{FloatArray: array}}, nil
+		case kuksa.DataType_DATA_TYPE_DOUBLE_ARRAY:
+			array := &kuksa.DoubleArray{}
+			for _, element := range parsed {
+				array.Values = append(array.Values, element.GetDouble())
+			}
+			return &kuksa.Value{TypedValue: &kuksa.Value_DoubleArray{DoubleArray: array}}, nil
+	}
+	return nil, fmt.Errorf("unsupported data type %s", dataType)
+}
+
+// FormatValue converts a KUKSA value to a VISS value, an array is formatted as a JSON array of strings. A value that is not set is "".
+func FormatValue(value *kuksa.Value) string {
+	switch typedValue := value.GetTypedValue().(type) {
+		case *kuksa.Value_String_:
+			return typedValue.String_
+		case *kuksa.Value_Bool:
+			return strconv.FormatBool(typedValue.Bool)
+		case *kuksa.Value_Int32:
+			return strconv.FormatInt(int64(typedValue.Int32), 10)
+		case *kuksa.Value_Int64:
+			return strconv.FormatInt(typedValue.Int64, 10)
+		case *kuksa.Value_Uint32:
+			return strconv.FormatUint(uint64(typedValue.Uint32), 10)
+		case *kuksa.Value_Uint64:
+			return strconv.FormatUint(typedValue.Uint64, 10)
+		case *kuksa.Value_Float:
+			return strconv.FormatFloat(float64(typedValue.Float), 'f', -1, 32)
+		case *kuksa.Value_Double:
+			return strconv.FormatFloat(typedValue.Double, 'f', -1, 64)
+		case *kuksa.Value_StringArray:
+			return formatArray(typedValue.StringArray.GetValues(), func(element string) string { return element })
+		case *kuksa.Value_BoolArray:
+			return formatArray(typedValue.BoolArray.GetValues(), strconv.FormatBool)
+		case *kuksa.Value_Int32Array:
+			return formatArray(typedValue.Int32Array.GetValues(), func(element int32) string { return strconv.FormatInt(int64(element), 10) })
+		case *kuksa.Value_Int64Array:
+			return formatArray(typedValue.Int64Array.GetValues(), func(element int64) string { return strconv.FormatInt(element, 10) })
+		case *kuksa.Value_Uint32Array:
+			return formatArray(typedValue.Uint32Array.GetValues(), func(element uint32) string { return strconv.FormatUint(uint64(element), 10) })
+		case *kuksa.Value_Uint64Array:
+			return formatArray(typedValue.Uint64Array.GetValues(), func(element uint64) string { return strconv.FormatUint(element, 10) })
+		case *kuksa.Value_FloatArray:
+			return formatArray(typedValue.FloatArray.GetValues(), func(element float32) string { return strconv.FormatFloat(float64(element), 'f', -1, 32) })
+		case *kuksa.Value_DoubleArray:
+			return formatArray(typedValue.DoubleArray.GetValues(), func(element float64) string { return strconv.FormatFloat(element, 'f', -1, 64) })
+	}
+	return ""
+}
+
+func formatArray[T any](elements []T, format func(T) string) string {
+	formatted := make([]string, len(elements))
+	for i := 0; i < len(elements); i++ {
+		formatted[i] = format(elements[i])
+	}
+	data, _ := json.Marshal(formatted)
+	return string(data)
+}
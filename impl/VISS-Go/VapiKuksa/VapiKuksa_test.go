@@ -0,0 +1,234 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiKuksa_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"VISS-Go/KuksaMock"
+	"VISS-Go/VapiKuksa"
+	kuksa "VISS-Go/VapiKuksa/kuksa/val/v2"
+	"VISS-Go/VissMock"
+	"VISS-Go/vapi"
+)
+
+// startMockDatabroker starts a fake databroker, and returns it together with a backend and the handle of a vehicle that is connected to it.
+func startMockDatabroker(t *testing.T) (*KuksaMock.Server, vapi.Vapi, vapi.VehicleHandle) {
+	t.Helper()
+	signals := VissMock.DefaultSignals()
+	for i := 0; i < len(signals); i++ {
+		if signals[i].Rate > 0 {
+			signals[i].Rate = 10000
+		}
+	}
+	server := KuksaMock.NewServer(signals)
+	_, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Start: %s", err)
+	}
+	vehicleGuid := "kuksaVin-" + t.Name()
+	VapiKuksa.RegisterVehicle(vehicleGuid, "127.0.0.1", server.Port())
+	backend := VapiKuksa.NewBackend()
+	getVehicleOut := backend.GetVehicle(vehicleGuid)
+	if getVehicleOut.Status != vapi.SUCCESSFUL {
+		t.Fatalf("GetVehicle: %v", getVehicleOut.Error)
+	}
+	connectOut := backend.Connect(getVehicleOut.VehicleId, VapiKuksa.KUKSA_PROTOCOL, "")
+	if connectOut.Status != vapi.SUCCESSFUL {
+		t.Fatalf("Connect: %v", connectOut.Error)
+	}
+	t.Cleanup(func() {
+		backend.Disconnect(getVehicleOut.VehicleId, VapiKuksa.KUKSA_PROTOCOL)
+		backend.ReleaseVehicle(getVehicleOut.VehicleId)
+		server.Close()
+	})
+	return server, backend, getVehicleOut.VehicleId
+}
+
+func waitFor[T any](t *testing.T, eventChan chan T, isFinal func(T) bool) T {
+	t.Helper()
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case event := <- eventChan:
+			if isFinal(event) {
+				return event
+			}
+		case <- timeout:
+			t.Fatalf("timeout waiting for final callback")
+		}
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	server, backend, vehicleId := startMockDatabroker(t)
+	setOut := backend.Set(vehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType", vapi.ROLL, "")
+	if setOut.Status != vapi.SUCCESSFUL {
+		t.Fatalf("Set: %v", setOut.Error)
+	}
+	getOut := backend.Get(vehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType", "", "")
+	if getOut.Status != vapi.SUCCESSFUL || len(getOut.Data) != 1 || getOut.Data[0].Dp[0].Value != vapi.ROLL {
+		t.Errorf("Get: status=%d, data=%v, error=%v", getOut.Status, getOut.Data, getOut.Error)
+	}
+	setOut = backend.Set(vehicleId, "Vehicle.Speed", "12.5", "")  // a sensor is set by PublishValue
+	if value, _ := server.GetValue("Vehicle.Speed"); setOut.Status != vapi.SUCCESSFUL || value != "12.5" {
+		t.Errorf("Set of a sensor: status=%d, value=%s, error=%v", setOut.Status, value, setOut.Error)
+	}
+	getOut = backend.Get(vehicleId, "Vehicle.CurrentLocation", `{"variant":"paths","parameter":["Latitude", "Longitude"]}`, "")
+	if getOut.Status != vapi.SUCCESSFUL || len(getOut.Data) != 2 || getOut.Data[0].Dp[0].Value != "57.7" {
+		t.Errorf("Get with paths filter: status=%d, data=%v", getOut.Status, getOut.Data)
+	}
+	getOut = backend.Get(vehicleId, "Vehicle.Unknown", "", "")
+	if getOut.Status != vapi.FAILED || getOut.Error == nil || getOut.Error.Code != 404 {
+		t.Errorf("Get of unknown path: status=%d, error=%v", getOut.Status, getOut.Error)
+	}
+	getOut = backend.Get(vehicleId, "Vehicle.Speed", `{"variant":"history","parameter":"PT1M"}`, "")
+	if getOut.Status != vapi.FAILED || getOut.Error == nil || getOut.Error.Code != 400 {
+		t.Errorf("Get with history filter: status=%d, error=%v", getOut.Status, getOut.Error)
+	}
+	setOut = backend.Set(vehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide.Height", "high", "")
+	if setOut.Status != vapi.FAILED || setOut.Error.Code != 400 {
+		t.Errorf("Set of an invalid value: status=%d, error=%v", setOut.Status, setOut.Error)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	server, backend, vehicleId := startMockDatabroker(t)
+	server.SetValue("Vehicle.Speed", "25")
+	eventChan := make(chan vapi.SubscribeOutput, 10)
+	subscribeOut := backend.Subscribe(vehicleId, "Vehicle.Speed", "", "", func(out vapi.SubscribeOutput) {
+		eventChan <- out
+	})
+	if subscribeOut.Status != vapi.ONGOING {
+		t.Fatalf("Subscribe: status=%d, error=%v", subscribeOut.Status, subscribeOut.Error)
+	}
+	event := waitFor(t, eventChan, func(out vapi.SubscribeOutput) bool { return true })
+	if event.Status != vapi.SUCCESSFUL || event.ServiceId != subscribeOut.ServiceId || event.Data[0].Dp[0].Value != "25" {
+		t.Errorf("unexpected first event: %+v", event)
+	}
+	server.SetValue("Vehicle.Speed", "30")
+	event = waitFor(t, eventChan, func(out vapi.SubscribeOutput) bool { return true })
+	if event.Data[0].Dp[0].Value != "30" {
+		t.Errorf("event after change: %+v", event)
+	}
+	unsubscribeOut := backend.Unsubscribe(vehicleId, subscribeOut.ServiceId)
+	if unsubscribeOut.Status != vapi.SUCCESSFUL {
+		t.Errorf("Unsubscribe: %v", unsubscribeOut.Error)
+	}
+	subscribeOut = backend.Subscribe(vehicleId, "Vehicle.CurrentLocation", `{"variant":"timebased","parameter":{"period":"100"}}`, "", func(out vapi.SubscribeOutput) {
+		eventChan <- out
+	})
+	if subscribeOut.Status != vapi.ONGOING {
+		t.Fatalf("timebased Subscribe: status=%d, error=%v", subscribeOut.Status, subscribeOut.Error)
+	}
+	event = waitFor(t, eventChan, func(out vapi.SubscribeOutput) bool { return out.ServiceId == subscribeOut.ServiceId })
+	if event.Status != vapi.SUCCESSFUL || len(event.Data) != 2 {
+		t.Errorf("timebased event: %+v", event)
+	}
+	backend.Unsubscribe(vehicleId, subscribeOut.ServiceId)
+	subscribeOut = backend.Subscribe(vehicleId, "Vehicle.Unknown", "", "", func(out vapi.SubscribeOutput) {})
+	if subscribeOut.Status != vapi.FAILED || subscribeOut.Error.Code != 404 {
+		t.Errorf("Subscribe of unknown path: status=%d, error=%v", subscribeOut.Status, subscribeOut.Error)
+	}
+}
+
+func TestGetMetadata(t *testing.T) {
+	_, backend, vehicleId := startMockDatabroker(t)
+	out := backend.GetMetadata(vehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide", "")
+	if out.Status != vapi.SUCCESSFUL || !strings.Contains(out.Metadata, `"Position"`) || !strings.Contains(out.Metadata, `"actuator"`) {
+		t.Errorf("GetMetadata: status=%d, metadata=%s, error=%v", out.Status, out.Metadata, out.Error)
+	}
+	out = backend.GetMetadata(vehicleId, "Vehicle.Unknown", "")
+	if out.Status != vapi.FAILED || out.Error.Code != 404 {
+		t.Errorf("GetMetadata of unknown path: status=%d, error=%v", out.Status, out.Error)
+	}
+}
+
+func TestMoveSeat(t *testing.T) {
+	server, backend, vehicleId := startMockDatabroker(t)
+	eventChan := make(chan vapi.MoveSeatOutput, 10)
+	seatId := vapi.MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	moveSeatOut := backend.MoveSeat(vehicleId, seatId, vapi.LONGITUDINAL, vapi.BACKWARD, "", func(out vapi.MoveSeatOutput) {
+		eventChan <- out
+	})
+	if moveSeatOut.Status != vapi.ONGOING {
+		t.Fatalf("MoveSeat: status=%d, error=%v", moveSeatOut.Status, moveSeatOut.Error)
+	}
+	event := waitFor(t, eventChan, func(out vapi.MoveSeatOutput) bool { return out.Status != vapi.ONGOING })
+	if event.Status != vapi.SUCCESSFUL || event.Position != 100 {
+		t.Errorf("final callback: status=%d, position=%f, error=%v", event.Status, event.Position, event.Error)
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Position"); value != "300" {
+		t.Errorf("actuator value = %s, want 300", value)
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	server, backend, vehicleId := startMockDatabroker(t)
+	server.RequireToken("secret")
+	getOut := backend.Get(vehicleId, "Vehicle.Speed", "", "")
+	if getOut.Status != vapi.FAILED || getOut.Error.Code != 401 {
+		t.Errorf("Get without credentials: status=%d, error=%v", getOut.Status, getOut.Error)
+	}
+	getOut = backend.Get(vehicleId, "Vehicle.Speed", "", "wrong")
+	if getOut.Status != vapi.FAILED || getOut.Error.Code != 403 {
+		t.Errorf("Get with wrong credentials: status=%d, error=%v", getOut.Status, getOut.Error)
+	}
+	getOut = backend.Get(vehicleId, "Vehicle.Speed", "", "secret")
+	if getOut.Status != vapi.SUCCESSFUL {
+		t.Errorf("Get with credentials: %v", getOut.Error)
+	}
+}
+
+func TestConnectFailure(t *testing.T) {
+	server := KuksaMock.NewServer(VissMock.DefaultSignals())
+	server.Start("127.0.0.1:0")
+	port := server.Port()
+	server.Close()
+	VapiKuksa.RegisterVehicle("closedKuksaVin", "127.0.0.1", port)
+	backend := VapiKuksa.NewBackend()
+	getVehicleOut := backend.GetVehicle("closedKuksaVin")
+	connectOut := backend.Connect(getVehicleOut.VehicleId, VapiKuksa.KUKSA_PROTOCOL, "")
+	if connectOut.Status != vapi.FAILED {
+		t.Errorf("Connect to a closed port did not fail")
+	}
+	backend.ReleaseVehicle(getVehicleOut.VehicleId)
+}
+
+func TestValueConversion(t *testing.T) {
+	tests := []struct {
+		value string
+		dataType kuksa.DataType
+		formatted string
+	}{
+		{"true", kuksa.DataType_DATA_TYPE_BOOLEAN, "true"},
+		{"-5", kuksa.DataType_DATA_TYPE_INT8, "-5"},
+		{"299.6", kuksa.DataType_DATA_TYPE_UINT16, "300"},
+		{"1.5", kuksa.DataType_DATA_TYPE_FLOAT, "1.5"},
+		{"57.7", kuksa.DataType_DATA_TYPE_DOUBLE, "57.7"},
+		{"abc", kuksa.DataType_DATA_TYPE_STRING, "abc"},
+		{`["a", "b"]`, kuksa.DataType_DATA_TYPE_STRING_ARRAY, `["a","b"]`},
+		{"[1, 2]", kuksa.DataType_DATA_TYPE_UINT8_ARRAY, `["1","2"]`},
+	}
+	for _, test := range tests {
+		value, err := VapiKuksa.ParseValue(test.value, test.dataType)
+		if err != nil {
+			t.Errorf("ParseValue(%s, %s): %s", test.value, test.dataType, err)
+			continue
+		}
+		if formatted := VapiKuksa.FormatValue(value); formatted != test.formatted {
+			t.Errorf("FormatValue(ParseValue(%s, %s)) = %s, want %s", test.value, test.dataType, formatted, test.formatted)
+		}
+	}
+	if _, err := VapiKuksa.ParseValue("-1", kuksa.DataType_DATA_TYPE_UINT32); err == nil {
+		t.Errorf("ParseValue of a negative unsigned value did not fail")
+	}
+}
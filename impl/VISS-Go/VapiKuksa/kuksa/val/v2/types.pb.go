@@ -0,0 +1,1325 @@
+//*
+// (C) 2025 Ford Motor Company
+//
+// All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+// are licensed under the provisions of the license provided by the LICENSE file in this repository.
+//
+
+// The subset of the Eclipse KUKSA databroker kuksa.val.v2 types that is used by VapiKuksa.
+// The field numbers are the ones of the databroker API, so that the messages are wire compatible.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: kuksa/val/v2/types.proto
+
+package kuksa
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DataType int32
+
+const (
+	DataType_DATA_TYPE_UNSPECIFIED     DataType = 0
+	DataType_DATA_TYPE_STRING          DataType = 1
+	DataType_DATA_TYPE_BOOLEAN         DataType = 2
+	DataType_DATA_TYPE_INT8            DataType = 3
+	DataType_DATA_TYPE_INT16           DataType = 4
+	DataType_DATA_TYPE_INT32           DataType = 5
+	DataType_DATA_TYPE_INT64           DataType = 6
+	DataType_DATA_TYPE_UINT8           DataType = 7
+	DataType_DATA_TYPE_UINT16          DataType = 8
+	DataType_DATA_TYPE_UINT32          DataType = 9
+	DataType_DATA_TYPE_UINT64          DataType = 10
+	DataType_DATA_TYPE_FLOAT           DataType = 11
+	DataType_DATA_TYPE_DOUBLE          DataType = 12
+	DataType_DATA_TYPE_TIMESTAMP       DataType = 13
+	DataType_DATA_TYPE_STRING_ARRAY    DataType = 20
+	DataType_DATA_TYPE_BOOLEAN_ARRAY   DataType = 21
+	DataType_DATA_TYPE_INT8_ARRAY      DataType = 22
+	DataType_DATA_TYPE_INT16_ARRAY     DataType = 23
+	DataType_DATA_TYPE_INT32_ARRAY     DataType = 24
+	DataType_DATA_TYPE_INT64_ARRAY     DataType = 25
+	DataType_DATA_TYPE_UINT8_ARRAY     DataType = 26
+	DataType_DATA_TYPE_UINT16_ARRAY    DataType = 27
+	DataType_DATA_TYPE_UINT32_ARRAY    DataType = 28
+	DataType_DATA_TYPE_UINT64_ARRAY    DataType = 29
+	DataType_DATA_TYPE_FLOAT_ARRAY     DataType = 30
+	DataType_DATA_TYPE_DOUBLE_ARRAY    DataType = 31
+	DataType_DATA_TYPE_TIMESTAMP_ARRAY DataType = 32
+)
+
+// Enum value maps for DataType.
+var (
+	DataType_name = map[int32]string{
+		0:  "DATA_TYPE_UNSPECIFIED",
+		1:  "DATA_TYPE_STRING",
+		2:  "DATA_TYPE_BOOLEAN",
+		3:  "DATA_TYPE_INT8",
+		4:  "DATA_TYPE_INT16",
+		5:  "DATA_TYPE_INT32",
+		6:  "DATA_TYPE_INT64",
+		7:  "DATA_TYPE_UINT8",
+		8:  "DATA_TYPE_UINT16",
+		9:  "DATA_TYPE_UINT32",
+		10: "DATA_TYPE_UINT64",
+		11: "DATA_TYPE_FLOAT",
+		12: "DATA_TYPE_DOUBLE",
+		13: "DATA_TYPE_TIMESTAMP",
+		20: "DATA_TYPE_STRING_ARRAY",
+		21: "DATA_TYPE_BOOLEAN_ARRAY",
+		22: "DATA_TYPE_INT8_ARRAY",
+		23: "DATA_TYPE_INT16_ARRAY",
+		24: "DATA_TYPE_INT32_ARRAY",
+		25: "DATA_TYPE_INT64_ARRAY",
+		26: "DATA_TYPE_UINT8_ARRAY",
+		27: "DATA_TYPE_UINT16_ARRAY",
+		28: "DATA_TYPE_UINT32_ARRAY",
+		29: "DATA_TYPE_UINT64_ARRAY",
+		30: "DATA_TYPE_FLOAT_ARRAY",
+		31: "DATA_TYPE_DOUBLE_ARRAY",
+		32: "DATA_TYPE_TIMESTAMP_ARRAY",
+	}
+	DataType_value = map[string]int32{
+		"DATA_TYPE_UNSPECIFIED":     0,
+		"DATA_TYPE_STRING":          1,
+		"DATA_TYPE_BOOLEAN":         2,
+		"DATA_TYPE_INT8":            3,
+		"DATA_TYPE_INT16":           4,
+		"DATA_TYPE_INT32":           5,
+		"DATA_TYPE_INT64":           6,
+		"DATA_TYPE_UINT8":           7,
+		"DATA_TYPE_UINT16":          8,
+		"DATA_TYPE_UINT32":          9,
+		"DATA_TYPE_UINT64":          10,
+		"DATA_TYPE_FLOAT":           11,
+		"DATA_TYPE_DOUBLE":          12,
+		"DATA_TYPE_TIMESTAMP":       13,
+		"DATA_TYPE_STRING_ARRAY":    20,
+		"DATA_TYPE_BOOLEAN_ARRAY":   21,
+		"DATA_TYPE_INT8_ARRAY":      22,
+		"DATA_TYPE_INT16_ARRAY":     23,
+		"DATA_TYPE_INT32_ARRAY":     24,
+		"DATA_TYPE_INT64_ARRAY":     25,
+		"DATA_TYPE_UINT8_ARRAY":     26,
+		"DATA_TYPE_UINT16_ARRAY":    27,
+		"DATA_TYPE_UINT32_ARRAY":    28,
+		"DATA_TYPE_UINT64_ARRAY":    29,
+		"DATA_TYPE_FLOAT_ARRAY":     30,
+		"DATA_TYPE_DOUBLE_ARRAY":    31,
+		"DATA_TYPE_TIMESTAMP_ARRAY": 32,
+	}
+)
+
+func (x DataType) Enum() *DataType {
+	p := new(DataType)
+	*p = x
+	return p
+}
+
+func (x DataType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataType) Descriptor() protoreflect.EnumDescriptor {
+	return file_kuksa_val_v2_types_proto_enumTypes[0].Descriptor()
+}
+
+func (DataType) Type() protoreflect.EnumType {
+	return &file_kuksa_val_v2_types_proto_enumTypes[0]
+}
+
+func (x DataType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataType.Descriptor instead.
+func (DataType) EnumDescriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{0}
+}
+
+type EntryType int32
+
+const (
+	EntryType_ENTRY_TYPE_UNSPECIFIED EntryType = 0
+	EntryType_ENTRY_TYPE_ATTRIBUTE   EntryType = 1
+	EntryType_ENTRY_TYPE_SENSOR      EntryType = 2
+	EntryType_ENTRY_TYPE_ACTUATOR    EntryType = 3
+)
+
+// Enum value maps for EntryType.
+var (
+	EntryType_name = map[int32]string{
+		0: "ENTRY_TYPE_UNSPECIFIED",
+		1: "ENTRY_TYPE_ATTRIBUTE",
+		2: "ENTRY_TYPE_SENSOR",
+		3: "ENTRY_TYPE_ACTUATOR",
+	}
+	EntryType_value = map[string]int32{
+		"ENTRY_TYPE_UNSPECIFIED": 0,
+		"ENTRY_TYPE_ATTRIBUTE":   1,
+		"ENTRY_TYPE_SENSOR":      2,
+		"ENTRY_TYPE_ACTUATOR":    3,
+	}
+)
+
+func (x EntryType) Enum() *EntryType {
+	p := new(EntryType)
+	*p = x
+	return p
+}
+
+func (x EntryType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntryType) Descriptor() protoreflect.EnumDescriptor {
+	return file_kuksa_val_v2_types_proto_enumTypes[1].Descriptor()
+}
+
+func (EntryType) Type() protoreflect.EnumType {
+	return &file_kuksa_val_v2_types_proto_enumTypes[1]
+}
+
+func (x EntryType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntryType.Descriptor instead.
+func (EntryType) EnumDescriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{1}
+}
+
+type Datapoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Value         *Value                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Datapoint) Reset() {
+	*x = Datapoint{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Datapoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Datapoint) ProtoMessage() {}
+
+func (x *Datapoint) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Datapoint.ProtoReflect.Descriptor instead.
+func (*Datapoint) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Datapoint) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Datapoint) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type Value struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to TypedValue:
+	//
+	//	*Value_String_
+	//	*Value_Bool
+	//	*Value_Int32
+	//	*Value_Int64
+	//	*Value_Uint32
+	//	*Value_Uint64
+	//	*Value_Float
+	//	*Value_Double
+	//	*Value_StringArray
+	//	*Value_BoolArray
+	//	*Value_Int32Array
+	//	*Value_Int64Array
+	//	*Value_Uint32Array
+	//	*Value_Uint64Array
+	//	*Value_FloatArray
+	//	*Value_DoubleArray
+	TypedValue    isValue_TypedValue `protobuf_oneof:"typed_value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Value) GetTypedValue() isValue_TypedValue {
+	if x != nil {
+		return x.TypedValue
+	}
+	return nil
+}
+
+func (x *Value) GetString_() string {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_String_); ok {
+			return x.String_
+		}
+	}
+	return ""
+}
+
+func (x *Value) GetBool() bool {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Bool); ok {
+			return x.Bool
+		}
+	}
+	return false
+}
+
+func (x *Value) GetInt32() int32 {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Int32); ok {
+			return x.Int32
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetInt64() int64 {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Int64); ok {
+			return x.Int64
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetUint32() uint32 {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Uint32); ok {
+			return x.Uint32
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetUint64() uint64 {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Uint64); ok {
+			return x.Uint64
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetFloat() float32 {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Float); ok {
+			return x.Float
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetDouble() float64 {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Double); ok {
+			return x.Double
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetStringArray() *StringArray {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_StringArray); ok {
+			return x.StringArray
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetBoolArray() *BoolArray {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_BoolArray); ok {
+			return x.BoolArray
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetInt32Array() *Int32Array {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Int32Array); ok {
+			return x.Int32Array
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetInt64Array() *Int64Array {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Int64Array); ok {
+			return x.Int64Array
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetUint32Array() *Uint32Array {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Uint32Array); ok {
+			return x.Uint32Array
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetUint64Array() *Uint64Array {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_Uint64Array); ok {
+			return x.Uint64Array
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetFloatArray() *FloatArray {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_FloatArray); ok {
+			return x.FloatArray
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetDoubleArray() *DoubleArray {
+	if x != nil {
+		if x, ok := x.TypedValue.(*Value_DoubleArray); ok {
+			return x.DoubleArray
+		}
+	}
+	return nil
+}
+
+type isValue_TypedValue interface {
+	isValue_TypedValue()
+}
+
+type Value_String_ struct {
+	String_ string `protobuf:"bytes,11,opt,name=string,proto3,oneof"`
+}
+
+type Value_Bool struct {
+	Bool bool `protobuf:"varint,12,opt,name=bool,proto3,oneof"`
+}
+
+type Value_Int32 struct {
+	Int32 int32 `protobuf:"zigzag32,13,opt,name=int32,proto3,oneof"`
+}
+
+type Value_Int64 struct {
+	Int64 int64 `protobuf:"zigzag64,14,opt,name=int64,proto3,oneof"`
+}
+
+type Value_Uint32 struct {
+	Uint32 uint32 `protobuf:"varint,15,opt,name=uint32,proto3,oneof"`
+}
+
+type Value_Uint64 struct {
+	Uint64 uint64 `protobuf:"varint,16,opt,name=uint64,proto3,oneof"`
+}
+
+type Value_Float struct {
+	Float float32 `protobuf:"fixed32,17,opt,name=float,proto3,oneof"`
+}
+
+type Value_Double struct {
+	Double float64 `protobuf:"fixed64,18,opt,name=double,proto3,oneof"`
+}
+
+type Value_StringArray struct {
+	StringArray *StringArray `protobuf:"bytes,21,opt,name=string_array,json=stringArray,proto3,oneof"`
+}
+
+type Value_BoolArray struct {
+	BoolArray *BoolArray `protobuf:"bytes,22,opt,name=bool_array,json=boolArray,proto3,oneof"`
+}
+
+type Value_Int32Array struct {
+	Int32Array *Int32Array `protobuf:"bytes,23,opt,name=int32_array,json=int32Array,proto3,oneof"`
+}
+
+type Value_Int64Array struct {
+	Int64Array *Int64Array `protobuf:"bytes,24,opt,name=int64_array,json=int64Array,proto3,oneof"`
+}
+
+type Value_Uint32Array struct {
+	Uint32Array *Uint32Array `protobuf:"bytes,25,opt,name=uint32_array,json=uint32Array,proto3,oneof"`
+}
+
+type Value_Uint64Array struct {
+	Uint64Array *Uint64Array `protobuf:"bytes,26,opt,name=uint64_array,json=uint64Array,proto3,oneof"`
+}
+
+type Value_FloatArray struct {
+	FloatArray *FloatArray `protobuf:"bytes,27,opt,name=float_array,json=floatArray,proto3,oneof"`
+}
+
+type Value_DoubleArray struct {
+	DoubleArray *DoubleArray `protobuf:"bytes,28,opt,name=double_array,json=doubleArray,proto3,oneof"`
+}
+
+func (*Value_String_) isValue_TypedValue() {}
+
+func (*Value_Bool) isValue_TypedValue() {}
+
+func (*Value_Int32) isValue_TypedValue() {}
+
+func (*Value_Int64) isValue_TypedValue() {}
+
+func (*Value_Uint32) isValue_TypedValue() {}
+
+func (*Value_Uint64) isValue_TypedValue() {}
+
+func (*Value_Float) isValue_TypedValue() {}
+
+func (*Value_Double) isValue_TypedValue() {}
+
+func (*Value_StringArray) isValue_TypedValue() {}
+
+func (*Value_BoolArray) isValue_TypedValue() {}
+
+func (*Value_Int32Array) isValue_TypedValue() {}
+
+func (*Value_Int64Array) isValue_TypedValue() {}
+
+func (*Value_Uint32Array) isValue_TypedValue() {}
+
+func (*Value_Uint64Array) isValue_TypedValue() {}
+
+func (*Value_FloatArray) isValue_TypedValue() {}
+
+func (*Value_DoubleArray) isValue_TypedValue() {}
+
+type SignalID struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Signal:
+	//
+	//	*SignalID_Id
+	//	*SignalID_Path
+	Signal        isSignalID_Signal `protobuf_oneof:"signal"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalID) Reset() {
+	*x = SignalID{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalID) ProtoMessage() {}
+
+func (x *SignalID) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalID.ProtoReflect.Descriptor instead.
+func (*SignalID) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SignalID) GetSignal() isSignalID_Signal {
+	if x != nil {
+		return x.Signal
+	}
+	return nil
+}
+
+func (x *SignalID) GetId() int32 {
+	if x != nil {
+		if x, ok := x.Signal.(*SignalID_Id); ok {
+			return x.Id
+		}
+	}
+	return 0
+}
+
+func (x *SignalID) GetPath() string {
+	if x != nil {
+		if x, ok := x.Signal.(*SignalID_Path); ok {
+			return x.Path
+		}
+	}
+	return ""
+}
+
+type isSignalID_Signal interface {
+	isSignalID_Signal()
+}
+
+type SignalID_Id struct {
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3,oneof"`
+}
+
+type SignalID_Path struct {
+	Path string `protobuf:"bytes,2,opt,name=path,proto3,oneof"`
+}
+
+func (*SignalID_Id) isSignalID_Signal() {}
+
+func (*SignalID_Path) isSignalID_Signal() {}
+
+type Metadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,9,opt,name=path,proto3" json:"path,omitempty"`
+	Id            int32                  `protobuf:"varint,10,opt,name=id,proto3" json:"id,omitempty"`
+	DataType      DataType               `protobuf:"varint,11,opt,name=data_type,json=dataType,proto3,enum=kuksa.val.v2.DataType" json:"data_type,omitempty"`
+	EntryType     EntryType              `protobuf:"varint,12,opt,name=entry_type,json=entryType,proto3,enum=kuksa.val.v2.EntryType" json:"entry_type,omitempty"`
+	Description   string                 `protobuf:"bytes,13,opt,name=description,proto3" json:"description,omitempty"`
+	Comment       string                 `protobuf:"bytes,14,opt,name=comment,proto3" json:"comment,omitempty"`
+	Deprecation   string                 `protobuf:"bytes,15,opt,name=deprecation,proto3" json:"deprecation,omitempty"`
+	Unit          string                 `protobuf:"bytes,16,opt,name=unit,proto3" json:"unit,omitempty"`
+	AllowedValues *Value                 `protobuf:"bytes,17,opt,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"`
+	Min           *Value                 `protobuf:"bytes,18,opt,name=min,proto3" json:"min,omitempty"`
+	Max           *Value                 `protobuf:"bytes,19,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Metadata) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Metadata) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Metadata) GetDataType() DataType {
+	if x != nil {
+		return x.DataType
+	}
+	return DataType_DATA_TYPE_UNSPECIFIED
+}
+
+func (x *Metadata) GetEntryType() EntryType {
+	if x != nil {
+		return x.EntryType
+	}
+	return EntryType_ENTRY_TYPE_UNSPECIFIED
+}
+
+func (x *Metadata) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Metadata) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Metadata) GetDeprecation() string {
+	if x != nil {
+		return x.Deprecation
+	}
+	return ""
+}
+
+func (x *Metadata) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Metadata) GetAllowedValues() *Value {
+	if x != nil {
+		return x.AllowedValues
+	}
+	return nil
+}
+
+func (x *Metadata) GetMin() *Value {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *Metadata) GetMax() *Value {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+type StringArray struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringArray) Reset() {
+	*x = StringArray{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringArray) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringArray) ProtoMessage() {}
+
+func (x *StringArray) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringArray.ProtoReflect.Descriptor instead.
+func (*StringArray) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StringArray) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type BoolArray struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []bool                 `protobuf:"varint,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoolArray) Reset() {
+	*x = BoolArray{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoolArray) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoolArray) ProtoMessage() {}
+
+func (x *BoolArray) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoolArray.ProtoReflect.Descriptor instead.
+func (*BoolArray) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BoolArray) GetValues() []bool {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Int32Array struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []int32                `protobuf:"zigzag32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int32Array) Reset() {
+	*x = Int32Array{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int32Array) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int32Array) ProtoMessage() {}
+
+func (x *Int32Array) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int32Array.ProtoReflect.Descriptor instead.
+func (*Int32Array) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Int32Array) GetValues() []int32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Int64Array struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []int64                `protobuf:"zigzag64,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Int64Array) Reset() {
+	*x = Int64Array{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Int64Array) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Int64Array) ProtoMessage() {}
+
+func (x *Int64Array) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Int64Array.ProtoReflect.Descriptor instead.
+func (*Int64Array) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Int64Array) GetValues() []int64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Uint32Array struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []uint32               `protobuf:"varint,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint32Array) Reset() {
+	*x = Uint32Array{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint32Array) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint32Array) ProtoMessage() {}
+
+func (x *Uint32Array) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint32Array.ProtoReflect.Descriptor instead.
+func (*Uint32Array) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Uint32Array) GetValues() []uint32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Uint64Array struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []uint64               `protobuf:"varint,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Uint64Array) Reset() {
+	*x = Uint64Array{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Uint64Array) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Uint64Array) ProtoMessage() {}
+
+func (x *Uint64Array) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Uint64Array.ProtoReflect.Descriptor instead.
+func (*Uint64Array) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Uint64Array) GetValues() []uint64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type FloatArray struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float32              `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FloatArray) Reset() {
+	*x = FloatArray{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FloatArray) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FloatArray) ProtoMessage() {}
+
+func (x *FloatArray) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FloatArray.ProtoReflect.Descriptor instead.
+func (*FloatArray) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FloatArray) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type DoubleArray struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float64              `protobuf:"fixed64,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoubleArray) Reset() {
+	*x = DoubleArray{}
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoubleArray) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoubleArray) ProtoMessage() {}
+
+func (x *DoubleArray) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_types_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoubleArray.ProtoReflect.Descriptor instead.
+func (*DoubleArray) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DoubleArray) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_kuksa_val_v2_types_proto protoreflect.FileDescriptor
+
+const file_kuksa_val_v2_types_proto_rawDesc = "" +
+	"\n" +
+	"\x18kuksa/val/v2/types.proto\x12\fkuksa.val.v2\x1a\x1fgoogle/protobuf/timestamp.proto\"p\n" +
+	"\tDatapoint\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.kuksa.val.v2.ValueR\x05value\"\xcd\x05\n" +
+	"\x05Value\x12\x18\n" +
+	"\x06string\x18\v \x01(\tH\x00R\x06string\x12\x14\n" +
+	"\x04bool\x18\f \x01(\bH\x00R\x04bool\x12\x16\n" +
+	"\x05int32\x18\r \x01(\x11H\x00R\x05int32\x12\x16\n" +
+	"\x05int64\x18\x0e \x01(\x12H\x00R\x05int64\x12\x18\n" +
+	"\x06uint32\x18\x0f \x01(\rH\x00R\x06uint32\x12\x18\n" +
+	"\x06uint64\x18\x10 \x01(\x04H\x00R\x06uint64\x12\x16\n" +
+	"\x05float\x18\x11 \x01(\x02H\x00R\x05float\x12\x18\n" +
+	"\x06double\x18\x12 \x01(\x01H\x00R\x06double\x12>\n" +
+	"\fstring_array\x18\x15 \x01(\v2\x19.kuksa.val.v2.StringArrayH\x00R\vstringArray\x128\n" +
+	"\n" +
+	"bool_array\x18\x16 \x01(\v2\x17.kuksa.val.v2.BoolArrayH\x00R\tboolArray\x12;\n" +
+	"\vint32_array\x18\x17 \x01(\v2\x18.kuksa.val.v2.Int32ArrayH\x00R\n" +
+	"int32Array\x12;\n" +
+	"\vint64_array\x18\x18 \x01(\v2\x18.kuksa.val.v2.Int64ArrayH\x00R\n" +
+	"int64Array\x12>\n" +
+	"\fuint32_array\x18\x19 \x01(\v2\x19.kuksa.val.v2.Uint32ArrayH\x00R\vuint32Array\x12>\n" +
+	"\fuint64_array\x18\x1a \x01(\v2\x19.kuksa.val.v2.Uint64ArrayH\x00R\vuint64Array\x12;\n" +
+	"\vfloat_array\x18\x1b \x01(\v2\x18.kuksa.val.v2.FloatArrayH\x00R\n" +
+	"floatArray\x12>\n" +
+	"\fdouble_array\x18\x1c \x01(\v2\x19.kuksa.val.v2.DoubleArrayH\x00R\vdoubleArrayB\r\n" +
+	"\vtyped_value\"<\n" +
+	"\bSignalID\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\x05H\x00R\x02id\x12\x14\n" +
+	"\x04path\x18\x02 \x01(\tH\x00R\x04pathB\b\n" +
+	"\x06signal\"\x97\x03\n" +
+	"\bMetadata\x12\x12\n" +
+	"\x04path\x18\t \x01(\tR\x04path\x12\x0e\n" +
+	"\x02id\x18\n" +
+	" \x01(\x05R\x02id\x123\n" +
+	"\tdata_type\x18\v \x01(\x0e2\x16.kuksa.val.v2.DataTypeR\bdataType\x126\n" +
+	"\n" +
+	"entry_type\x18\f \x01(\x0e2\x17.kuksa.val.v2.EntryTypeR\tentryType\x12 \n" +
+	"\vdescription\x18\r \x01(\tR\vdescription\x12\x18\n" +
+	"\acomment\x18\x0e \x01(\tR\acomment\x12 \n" +
+	"\vdeprecation\x18\x0f \x01(\tR\vdeprecation\x12\x12\n" +
+	"\x04unit\x18\x10 \x01(\tR\x04unit\x12:\n" +
+	"\x0eallowed_values\x18\x11 \x01(\v2\x13.kuksa.val.v2.ValueR\rallowedValues\x12%\n" +
+	"\x03min\x18\x12 \x01(\v2\x13.kuksa.val.v2.ValueR\x03min\x12%\n" +
+	"\x03max\x18\x13 \x01(\v2\x13.kuksa.val.v2.ValueR\x03max\"%\n" +
+	"\vStringArray\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"#\n" +
+	"\tBoolArray\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\bR\x06values\"$\n" +
+	"\n" +
+	"Int32Array\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x11R\x06values\"$\n" +
+	"\n" +
+	"Int64Array\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x12R\x06values\"%\n" +
+	"\vUint32Array\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\rR\x06values\"%\n" +
+	"\vUint64Array\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x04R\x06values\"$\n" +
+	"\n" +
+	"FloatArray\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x02R\x06values\"%\n" +
+	"\vDoubleArray\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x01R\x06values*\xa9\x05\n" +
+	"\bDataType\x12\x19\n" +
+	"\x15DATA_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10DATA_TYPE_STRING\x10\x01\x12\x15\n" +
+	"\x11DATA_TYPE_BOOLEAN\x10\x02\x12\x12\n" +
+	"\x0eDATA_TYPE_INT8\x10\x03\x12\x13\n" +
+	"\x0fDATA_TYPE_INT16\x10\x04\x12\x13\n" +
+	"\x0fDATA_TYPE_INT32\x10\x05\x12\x13\n" +
+	"\x0fDATA_TYPE_INT64\x10\x06\x12\x13\n" +
+	"\x0fDATA_TYPE_UINT8\x10\a\x12\x14\n" +
+	"\x10DATA_TYPE_UINT16\x10\b\x12\x14\n" +
+	"\x10DATA_TYPE_UINT32\x10\t\x12\x14\n" +
+	"\x10DATA_TYPE_UINT64\x10\n" +
+	"\x12\x13\n" +
+	"\x0fDATA_TYPE_FLOAT\x10\v\x12\x14\n" +
+	"\x10DATA_TYPE_DOUBLE\x10\f\x12\x17\n" +
+	"\x13DATA_TYPE_TIMESTAMP\x10\r\x12\x1a\n" +
+	"\x16DATA_TYPE_STRING_ARRAY\x10\x14\x12\x1b\n" +
+	"\x17DATA_TYPE_BOOLEAN_ARRAY\x10\x15\x12\x18\n" +
+	"\x14DATA_TYPE_INT8_ARRAY\x10\x16\x12\x19\n" +
+	"\x15DATA_TYPE_INT16_ARRAY\x10\x17\x12\x19\n" +
+	"\x15DATA_TYPE_INT32_ARRAY\x10\x18\x12\x19\n" +
+	"\x15DATA_TYPE_INT64_ARRAY\x10\x19\x12\x19\n" +
+	"\x15DATA_TYPE_UINT8_ARRAY\x10\x1a\x12\x1a\n" +
+	"\x16DATA_TYPE_UINT16_ARRAY\x10\x1b\x12\x1a\n" +
+	"\x16DATA_TYPE_UINT32_ARRAY\x10\x1c\x12\x1a\n" +
+	"\x16DATA_TYPE_UINT64_ARRAY\x10\x1d\x12\x19\n" +
+	"\x15DATA_TYPE_FLOAT_ARRAY\x10\x1e\x12\x1a\n" +
+	"\x16DATA_TYPE_DOUBLE_ARRAY\x10\x1f\x12\x1d\n" +
+	"\x19DATA_TYPE_TIMESTAMP_ARRAY\x10 *q\n" +
+	"\tEntryType\x12\x1a\n" +
+	"\x16ENTRY_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ENTRY_TYPE_ATTRIBUTE\x10\x01\x12\x15\n" +
+	"\x11ENTRY_TYPE_SENSOR\x10\x02\x12\x17\n" +
+	"\x13ENTRY_TYPE_ACTUATOR\x10\x03B&Z$VISS-Go/VapiKuksa/kuksa/val/v2;kuksab\x06proto3"
+
+var (
+	file_kuksa_val_v2_types_proto_rawDescOnce sync.Once
+	file_kuksa_val_v2_types_proto_rawDescData []byte
+)
+
+func file_kuksa_val_v2_types_proto_rawDescGZIP() []byte {
+	file_kuksa_val_v2_types_proto_rawDescOnce.Do(func() {
+		file_kuksa_val_v2_types_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kuksa_val_v2_types_proto_rawDesc), len(file_kuksa_val_v2_types_proto_rawDesc)))
+	})
+	return file_kuksa_val_v2_types_proto_rawDescData
+}
+
+var file_kuksa_val_v2_types_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_kuksa_val_v2_types_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_kuksa_val_v2_types_proto_goTypes = []any{
+	(DataType)(0),                 // 0: kuksa.val.v2.DataType
+	(EntryType)(0),                // 1: kuksa.val.v2.EntryType
+	(*Datapoint)(nil),             // 2: kuksa.val.v2.Datapoint
+	(*Value)(nil),                 // 3: kuksa.val.v2.Value
+	(*SignalID)(nil),              // 4: kuksa.val.v2.SignalID
+	(*Metadata)(nil),              // 5: kuksa.val.v2.Metadata
+	(*StringArray)(nil),           // 6: kuksa.val.v2.StringArray
+	(*BoolArray)(nil),             // 7: kuksa.val.v2.BoolArray
+	(*Int32Array)(nil),            // 8: kuksa.val.v2.Int32Array
+	(*Int64Array)(nil),            // 9: kuksa.val.v2.Int64Array
+	(*Uint32Array)(nil),           // 10: kuksa.val.v2.Uint32Array
+	(*Uint64Array)(nil),           // 11: kuksa.val.v2.Uint64Array
+	(*FloatArray)(nil),            // 12: kuksa.val.v2.FloatArray
+	(*DoubleArray)(nil),           // 13: kuksa.val.v2.DoubleArray
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+}
+var file_kuksa_val_v2_types_proto_depIdxs = []int32{
+	14, // 0: kuksa.val.v2.Datapoint.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 1: kuksa.val.v2.Datapoint.value:type_name -> kuksa.val.v2.Value
+	6,  // 2: kuksa.val.v2.Value.string_array:type_name -> kuksa.val.v2.StringArray
+	7,  // 3: kuksa.val.v2.Value.bool_array:type_name -> kuksa.val.v2.BoolArray
+	8,  // 4: kuksa.val.v2.Value.int32_array:type_name -> kuksa.val.v2.Int32Array
+	9,  // 5: kuksa.val.v2.Value.int64_array:type_name -> kuksa.val.v2.Int64Array
+	10, // 6: kuksa.val.v2.Value.uint32_array:type_name -> kuksa.val.v2.Uint32Array
+	11, // 7: kuksa.val.v2.Value.uint64_array:type_name -> kuksa.val.v2.Uint64Array
+	12, // 8: kuksa.val.v2.Value.float_array:type_name -> kuksa.val.v2.FloatArray
+	13, // 9: kuksa.val.v2.Value.double_array:type_name -> kuksa.val.v2.DoubleArray
+	0,  // 10: kuksa.val.v2.Metadata.data_type:type_name -> kuksa.val.v2.DataType
+	1,  // 11: kuksa.val.v2.Metadata.entry_type:type_name -> kuksa.val.v2.EntryType
+	3,  // 12: kuksa.val.v2.Metadata.allowed_values:type_name -> kuksa.val.v2.Value
+	3,  // 13: kuksa.val.v2.Metadata.min:type_name -> kuksa.val.v2.Value
+	3,  // 14: kuksa.val.v2.Metadata.max:type_name -> kuksa.val.v2.Value
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_kuksa_val_v2_types_proto_init() }
+func file_kuksa_val_v2_types_proto_init() {
+	if File_kuksa_val_v2_types_proto != nil {
+		return
+	}
+	file_kuksa_val_v2_types_proto_msgTypes[1].OneofWrappers = []any{
+		(*Value_String_)(nil),
+		(*Value_Bool)(nil),
+		(*Value_Int32)(nil),
+		(*Value_Int64)(nil),
+		(*Value_Uint32)(nil),
+		(*Value_Uint64)(nil),
+		(*Value_Float)(nil),
+		(*Value_Double)(nil),
+		(*Value_StringArray)(nil),
+		(*Value_BoolArray)(nil),
+		(*Value_Int32Array)(nil),
+		(*Value_Int64Array)(nil),
+		(*Value_Uint32Array)(nil),
+		(*Value_Uint64Array)(nil),
+		(*Value_FloatArray)(nil),
+		(*Value_DoubleArray)(nil),
+	}
+	file_kuksa_val_v2_types_proto_msgTypes[2].OneofWrappers = []any{
+		(*SignalID_Id)(nil),
+		(*SignalID_Path)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kuksa_val_v2_types_proto_rawDesc), len(file_kuksa_val_v2_types_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_kuksa_val_v2_types_proto_goTypes,
+		DependencyIndexes: file_kuksa_val_v2_types_proto_depIdxs,
+		EnumInfos:         file_kuksa_val_v2_types_proto_enumTypes,
+		MessageInfos:      file_kuksa_val_v2_types_proto_msgTypes,
+	}.Build()
+	File_kuksa_val_v2_types_proto = out.File
+	file_kuksa_val_v2_types_proto_goTypes = nil
+	file_kuksa_val_v2_types_proto_depIdxs = nil
+}
@@ -0,0 +1,817 @@
+//*
+// (C) 2025 Ford Motor Company
+//
+// All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+// are licensed under the provisions of the license provided by the LICENSE file in this repository.
+//
+
+// The subset of the Eclipse KUKSA databroker kuksa.val.v2 VAL service that is used by VapiKuksa.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: kuksa/val/v2/val.proto
+
+package kuksa
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetValueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SignalId      *SignalID              `protobuf:"bytes,1,opt,name=signal_id,json=signalId,proto3" json:"signal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetValueRequest) Reset() {
+	*x = GetValueRequest{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValueRequest) ProtoMessage() {}
+
+func (x *GetValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValueRequest.ProtoReflect.Descriptor instead.
+func (*GetValueRequest) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetValueRequest) GetSignalId() *SignalID {
+	if x != nil {
+		return x.SignalId
+	}
+	return nil
+}
+
+type GetValueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DataPoint     *Datapoint             `protobuf:"bytes,1,opt,name=data_point,json=dataPoint,proto3" json:"data_point,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetValueResponse) Reset() {
+	*x = GetValueResponse{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetValueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValueResponse) ProtoMessage() {}
+
+func (x *GetValueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValueResponse.ProtoReflect.Descriptor instead.
+func (*GetValueResponse) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetValueResponse) GetDataPoint() *Datapoint {
+	if x != nil {
+		return x.DataPoint
+	}
+	return nil
+}
+
+type GetValuesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SignalIds     []*SignalID            `protobuf:"bytes,1,rep,name=signal_ids,json=signalIds,proto3" json:"signal_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetValuesRequest) Reset() {
+	*x = GetValuesRequest{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetValuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValuesRequest) ProtoMessage() {}
+
+func (x *GetValuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValuesRequest.ProtoReflect.Descriptor instead.
+func (*GetValuesRequest) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetValuesRequest) GetSignalIds() []*SignalID {
+	if x != nil {
+		return x.SignalIds
+	}
+	return nil
+}
+
+type GetValuesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DataPoints    []*Datapoint           `protobuf:"bytes,1,rep,name=data_points,json=dataPoints,proto3" json:"data_points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetValuesResponse) Reset() {
+	*x = GetValuesResponse{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetValuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetValuesResponse) ProtoMessage() {}
+
+func (x *GetValuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetValuesResponse.ProtoReflect.Descriptor instead.
+func (*GetValuesResponse) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetValuesResponse) GetDataPoints() []*Datapoint {
+	if x != nil {
+		return x.DataPoints
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SignalPaths   []string               `protobuf:"bytes,1,rep,name=signal_paths,json=signalPaths,proto3" json:"signal_paths,omitempty"`
+	BufferSize    uint32                 `protobuf:"varint,2,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SubscribeRequest) GetSignalPaths() []string {
+	if x != nil {
+		return x.SignalPaths
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetBufferSize() uint32 {
+	if x != nil {
+		return x.BufferSize
+	}
+	return 0
+}
+
+type SubscribeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       map[string]*Datapoint  `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeResponse) Reset() {
+	*x = SubscribeResponse{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeResponse) ProtoMessage() {}
+
+func (x *SubscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubscribeResponse) GetEntries() map[string]*Datapoint {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ActuateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SignalId      *SignalID              `protobuf:"bytes,1,opt,name=signal_id,json=signalId,proto3" json:"signal_id,omitempty"`
+	Value         *Value                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActuateRequest) Reset() {
+	*x = ActuateRequest{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActuateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActuateRequest) ProtoMessage() {}
+
+func (x *ActuateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActuateRequest.ProtoReflect.Descriptor instead.
+func (*ActuateRequest) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ActuateRequest) GetSignalId() *SignalID {
+	if x != nil {
+		return x.SignalId
+	}
+	return nil
+}
+
+func (x *ActuateRequest) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type ActuateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActuateResponse) Reset() {
+	*x = ActuateResponse{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActuateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActuateResponse) ProtoMessage() {}
+
+func (x *ActuateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActuateResponse.ProtoReflect.Descriptor instead.
+func (*ActuateResponse) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{7}
+}
+
+type ListMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Root          string                 `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Filter        string                 `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMetadataRequest) Reset() {
+	*x = ListMetadataRequest{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMetadataRequest) ProtoMessage() {}
+
+func (x *ListMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMetadataRequest.ProtoReflect.Descriptor instead.
+func (*ListMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListMetadataRequest) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *ListMetadataRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+type ListMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      []*Metadata            `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMetadataResponse) Reset() {
+	*x = ListMetadataResponse{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMetadataResponse) ProtoMessage() {}
+
+func (x *ListMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMetadataResponse.ProtoReflect.Descriptor instead.
+func (*ListMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListMetadataResponse) GetMetadata() []*Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type PublishValueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SignalId      *SignalID              `protobuf:"bytes,1,opt,name=signal_id,json=signalId,proto3" json:"signal_id,omitempty"`
+	DataPoint     *Datapoint             `protobuf:"bytes,2,opt,name=data_point,json=dataPoint,proto3" json:"data_point,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishValueRequest) Reset() {
+	*x = PublishValueRequest{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishValueRequest) ProtoMessage() {}
+
+func (x *PublishValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishValueRequest.ProtoReflect.Descriptor instead.
+func (*PublishValueRequest) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PublishValueRequest) GetSignalId() *SignalID {
+	if x != nil {
+		return x.SignalId
+	}
+	return nil
+}
+
+func (x *PublishValueRequest) GetDataPoint() *Datapoint {
+	if x != nil {
+		return x.DataPoint
+	}
+	return nil
+}
+
+type PublishValueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishValueResponse) Reset() {
+	*x = PublishValueResponse{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishValueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishValueResponse) ProtoMessage() {}
+
+func (x *PublishValueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishValueResponse.ProtoReflect.Descriptor instead.
+func (*PublishValueResponse) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{11}
+}
+
+type GetServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoRequest) Reset() {
+	*x = GetServerInfoRequest{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoRequest) ProtoMessage() {}
+
+func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{12}
+}
+
+type GetServerInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	CommitHash    string                 `protobuf:"bytes,3,opt,name=commit_hash,json=commitHash,proto3" json:"commit_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoResponse) Reset() {
+	*x = GetServerInfoResponse{}
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoResponse) ProtoMessage() {}
+
+func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuksa_val_v2_val_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetServerInfoResponse) Descriptor() ([]byte, []int) {
+	return file_kuksa_val_v2_val_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetServerInfoResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetCommitHash() string {
+	if x != nil {
+		return x.CommitHash
+	}
+	return ""
+}
+
+var File_kuksa_val_v2_val_proto protoreflect.FileDescriptor
+
+const file_kuksa_val_v2_val_proto_rawDesc = "" +
+	"\n" +
+	"\x16kuksa/val/v2/val.proto\x12\fkuksa.val.v2\x1a\x18kuksa/val/v2/types.proto\"F\n" +
+	"\x0fGetValueRequest\x123\n" +
+	"\tsignal_id\x18\x01 \x01(\v2\x16.kuksa.val.v2.SignalIDR\bsignalId\"J\n" +
+	"\x10GetValueResponse\x126\n" +
+	"\n" +
+	"data_point\x18\x01 \x01(\v2\x17.kuksa.val.v2.DatapointR\tdataPoint\"I\n" +
+	"\x10GetValuesRequest\x125\n" +
+	"\n" +
+	"signal_ids\x18\x01 \x03(\v2\x16.kuksa.val.v2.SignalIDR\tsignalIds\"M\n" +
+	"\x11GetValuesResponse\x128\n" +
+	"\vdata_points\x18\x01 \x03(\v2\x17.kuksa.val.v2.DatapointR\n" +
+	"dataPoints\"V\n" +
+	"\x10SubscribeRequest\x12!\n" +
+	"\fsignal_paths\x18\x01 \x03(\tR\vsignalPaths\x12\x1f\n" +
+	"\vbuffer_size\x18\x02 \x01(\rR\n" +
+	"bufferSize\"\xb0\x01\n" +
+	"\x11SubscribeResponse\x12F\n" +
+	"\aentries\x18\x01 \x03(\v2,.kuksa.val.v2.SubscribeResponse.EntriesEntryR\aentries\x1aS\n" +
+	"\fEntriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.kuksa.val.v2.DatapointR\x05value:\x028\x01\"p\n" +
+	"\x0eActuateRequest\x123\n" +
+	"\tsignal_id\x18\x01 \x01(\v2\x16.kuksa.val.v2.SignalIDR\bsignalId\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.kuksa.val.v2.ValueR\x05value\"\x11\n" +
+	"\x0fActuateResponse\"A\n" +
+	"\x13ListMetadataRequest\x12\x12\n" +
+	"\x04root\x18\x01 \x01(\tR\x04root\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\"J\n" +
+	"\x14ListMetadataResponse\x122\n" +
+	"\bmetadata\x18\x01 \x03(\v2\x16.kuksa.val.v2.MetadataR\bmetadata\"\x82\x01\n" +
+	"\x13PublishValueRequest\x123\n" +
+	"\tsignal_id\x18\x01 \x01(\v2\x16.kuksa.val.v2.SignalIDR\bsignalId\x126\n" +
+	"\n" +
+	"data_point\x18\x02 \x01(\v2\x17.kuksa.val.v2.DatapointR\tdataPoint\"\x16\n" +
+	"\x14PublishValueResponse\"\x16\n" +
+	"\x14GetServerInfoRequest\"f\n" +
+	"\x15GetServerInfoResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1f\n" +
+	"\vcommit_hash\x18\x03 \x01(\tR\n" +
+	"commitHash2\xbe\x04\n" +
+	"\x03VAL\x12I\n" +
+	"\bGetValue\x12\x1d.kuksa.val.v2.GetValueRequest\x1a\x1e.kuksa.val.v2.GetValueResponse\x12L\n" +
+	"\tGetValues\x12\x1e.kuksa.val.v2.GetValuesRequest\x1a\x1f.kuksa.val.v2.GetValuesResponse\x12N\n" +
+	"\tSubscribe\x12\x1e.kuksa.val.v2.SubscribeRequest\x1a\x1f.kuksa.val.v2.SubscribeResponse0\x01\x12F\n" +
+	"\aActuate\x12\x1c.kuksa.val.v2.ActuateRequest\x1a\x1d.kuksa.val.v2.ActuateResponse\x12U\n" +
+	"\fListMetadata\x12!.kuksa.val.v2.ListMetadataRequest\x1a\".kuksa.val.v2.ListMetadataResponse\x12U\n" +
+	"\fPublishValue\x12!.kuksa.val.v2.PublishValueRequest\x1a\".kuksa.val.v2.PublishValueResponse\x12X\n" +
+	"\rGetServerInfo\x12\".kuksa.val.v2.GetServerInfoRequest\x1a#.kuksa.val.v2.GetServerInfoResponseB&Z$VISS-Go/VapiKuksa/kuksa/val/v2;kuksab\x06proto3"
+
+var (
+	file_kuksa_val_v2_val_proto_rawDescOnce sync.Once
+	file_kuksa_val_v2_val_proto_rawDescData []byte
+)
+
+func file_kuksa_val_v2_val_proto_rawDescGZIP() []byte {
+	file_kuksa_val_v2_val_proto_rawDescOnce.Do(func() {
+		file_kuksa_val_v2_val_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kuksa_val_v2_val_proto_rawDesc), len(file_kuksa_val_v2_val_proto_rawDesc)))
+	})
+	return file_kuksa_val_v2_val_proto_rawDescData
+}
+
+var file_kuksa_val_v2_val_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_kuksa_val_v2_val_proto_goTypes = []any{
+	(*GetValueRequest)(nil),       // 0: kuksa.val.v2.GetValueRequest
+	(*GetValueResponse)(nil),      // 1: kuksa.val.v2.GetValueResponse
+	(*GetValuesRequest)(nil),      // 2: kuksa.val.v2.GetValuesRequest
+	(*GetValuesResponse)(nil),     // 3: kuksa.val.v2.GetValuesResponse
+	(*SubscribeRequest)(nil),      // 4: kuksa.val.v2.SubscribeRequest
+	(*SubscribeResponse)(nil),     // 5: kuksa.val.v2.SubscribeResponse
+	(*ActuateRequest)(nil),        // 6: kuksa.val.v2.ActuateRequest
+	(*ActuateResponse)(nil),       // 7: kuksa.val.v2.ActuateResponse
+	(*ListMetadataRequest)(nil),   // 8: kuksa.val.v2.ListMetadataRequest
+	(*ListMetadataResponse)(nil),  // 9: kuksa.val.v2.ListMetadataResponse
+	(*PublishValueRequest)(nil),   // 10: kuksa.val.v2.PublishValueRequest
+	(*PublishValueResponse)(nil),  // 11: kuksa.val.v2.PublishValueResponse
+	(*GetServerInfoRequest)(nil),  // 12: kuksa.val.v2.GetServerInfoRequest
+	(*GetServerInfoResponse)(nil), // 13: kuksa.val.v2.GetServerInfoResponse
+	nil,                           // 14: kuksa.val.v2.SubscribeResponse.EntriesEntry
+	(*SignalID)(nil),              // 15: kuksa.val.v2.SignalID
+	(*Datapoint)(nil),             // 16: kuksa.val.v2.Datapoint
+	(*Value)(nil),                 // 17: kuksa.val.v2.Value
+	(*Metadata)(nil),              // 18: kuksa.val.v2.Metadata
+}
+var file_kuksa_val_v2_val_proto_depIdxs = []int32{
+	15, // 0: kuksa.val.v2.GetValueRequest.signal_id:type_name -> kuksa.val.v2.SignalID
+	16, // 1: kuksa.val.v2.GetValueResponse.data_point:type_name -> kuksa.val.v2.Datapoint
+	15, // 2: kuksa.val.v2.GetValuesRequest.signal_ids:type_name -> kuksa.val.v2.SignalID
+	16, // 3: kuksa.val.v2.GetValuesResponse.data_points:type_name -> kuksa.val.v2.Datapoint
+	14, // 4: kuksa.val.v2.SubscribeResponse.entries:type_name -> kuksa.val.v2.SubscribeResponse.EntriesEntry
+	15, // 5: kuksa.val.v2.ActuateRequest.signal_id:type_name -> kuksa.val.v2.SignalID
+	17, // 6: kuksa.val.v2.ActuateRequest.value:type_name -> kuksa.val.v2.Value
+	18, // 7: kuksa.val.v2.ListMetadataResponse.metadata:type_name -> kuksa.val.v2.Metadata
+	15, // 8: kuksa.val.v2.PublishValueRequest.signal_id:type_name -> kuksa.val.v2.SignalID
+	16, // 9: kuksa.val.v2.PublishValueRequest.data_point:type_name -> kuksa.val.v2.Datapoint
+	16, // 10: kuksa.val.v2.SubscribeResponse.EntriesEntry.value:type_name -> kuksa.val.v2.Datapoint
+	0,  // 11: kuksa.val.v2.VAL.GetValue:input_type -> kuksa.val.v2.GetValueRequest
+	2,  // 12: kuksa.val.v2.VAL.GetValues:input_type -> kuksa.val.v2.GetValuesRequest
+	4,  // 13: kuksa.val.v2.VAL.Subscribe:input_type -> kuksa.val.v2.SubscribeRequest
+	6,  // 14: kuksa.val.v2.VAL.Actuate:input_type -> kuksa.val.v2.ActuateRequest
+	8,  // 15: kuksa.val.v2.VAL.ListMetadata:input_type -> kuksa.val.v2.ListMetadataRequest
+	10, // 16: kuksa.val.v2.VAL.PublishValue:input_type -> kuksa.val.v2.PublishValueRequest
+	12, // 17: kuksa.val.v2.VAL.GetServerInfo:input_type -> kuksa.val.v2.GetServerInfoRequest
+	1,  // 18: kuksa.val.v2.VAL.GetValue:output_type -> kuksa.val.v2.GetValueResponse
+	3,  // 19: kuksa.val.v2.VAL.GetValues:output_type -> kuksa.val.v2.GetValuesResponse
+	5,  // 20: kuksa.val.v2.VAL.Subscribe:output_type -> kuksa.val.v2.SubscribeResponse
+	7,  // 21: kuksa.val.v2.VAL.Actuate:output_type -> kuksa.val.v2.ActuateResponse
+	9,  // 22: kuksa.val.v2.VAL.ListMetadata:output_type -> kuksa.val.v2.ListMetadataResponse
+	11, // 23: kuksa.val.v2.VAL.PublishValue:output_type -> kuksa.val.v2.PublishValueResponse
+	13, // 24: kuksa.val.v2.VAL.GetServerInfo:output_type -> kuksa.val.v2.GetServerInfoResponse
+	18, // [18:25] is the sub-list for method output_type
+	11, // [11:18] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_kuksa_val_v2_val_proto_init() }
+func file_kuksa_val_v2_val_proto_init() {
+	if File_kuksa_val_v2_val_proto != nil {
+		return
+	}
+	file_kuksa_val_v2_types_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kuksa_val_v2_val_proto_rawDesc), len(file_kuksa_val_v2_val_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kuksa_val_v2_val_proto_goTypes,
+		DependencyIndexes: file_kuksa_val_v2_val_proto_depIdxs,
+		MessageInfos:      file_kuksa_val_v2_val_proto_msgTypes,
+	}.Build()
+	File_kuksa_val_v2_val_proto = out.File
+	file_kuksa_val_v2_val_proto_goTypes = nil
+	file_kuksa_val_v2_val_proto_depIdxs = nil
+}
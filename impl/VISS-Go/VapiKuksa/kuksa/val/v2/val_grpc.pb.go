@@ -0,0 +1,376 @@
+//*
+// (C) 2025 Ford Motor Company
+//
+// All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+// are licensed under the provisions of the license provided by the LICENSE file in this repository.
+//
+
+// The subset of the Eclipse KUKSA databroker kuksa.val.v2 VAL service that is used by VapiKuksa.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: kuksa/val/v2/val.proto
+
+package kuksa
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VAL_GetValue_FullMethodName      = "/kuksa.val.v2.VAL/GetValue"
+	VAL_GetValues_FullMethodName     = "/kuksa.val.v2.VAL/GetValues"
+	VAL_Subscribe_FullMethodName     = "/kuksa.val.v2.VAL/Subscribe"
+	VAL_Actuate_FullMethodName       = "/kuksa.val.v2.VAL/Actuate"
+	VAL_ListMetadata_FullMethodName  = "/kuksa.val.v2.VAL/ListMetadata"
+	VAL_PublishValue_FullMethodName  = "/kuksa.val.v2.VAL/PublishValue"
+	VAL_GetServerInfo_FullMethodName = "/kuksa.val.v2.VAL/GetServerInfo"
+)
+
+// VALClient is the client API for VAL service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VALClient interface {
+	// Get the latest value of a signal.
+	GetValue(ctx context.Context, in *GetValueRequest, opts ...grpc.CallOption) (*GetValueResponse, error)
+	// Get the latest values of a set of signals, in the order of the request.
+	GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesResponse, error)
+	// Subscribe to a set of signals. The first response contains the current values.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeResponse], error)
+	// Actuate a single actuator, the request is forwarded to the provider of the actuator.
+	Actuate(ctx context.Context, in *ActuateRequest, opts ...grpc.CallOption) (*ActuateResponse, error)
+	// List the metadata of the signals at and below root.
+	ListMetadata(ctx context.Context, in *ListMetadataRequest, opts ...grpc.CallOption) (*ListMetadataResponse, error)
+	// Publish a signal value, as done by a provider.
+	PublishValue(ctx context.Context, in *PublishValueRequest, opts ...grpc.CallOption) (*PublishValueResponse, error)
+	// Get the name and the version of the databroker.
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error)
+}
+
+type vALClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVALClient(cc grpc.ClientConnInterface) VALClient {
+	return &vALClient{cc}
+}
+
+func (c *vALClient) GetValue(ctx context.Context, in *GetValueRequest, opts ...grpc.CallOption) (*GetValueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetValueResponse)
+	err := c.cc.Invoke(ctx, VAL_GetValue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vALClient) GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetValuesResponse)
+	err := c.cc.Invoke(ctx, VAL_GetValues_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vALClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SubscribeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VAL_ServiceDesc.Streams[0], VAL_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, SubscribeResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VAL_SubscribeClient = grpc.ServerStreamingClient[SubscribeResponse]
+
+func (c *vALClient) Actuate(ctx context.Context, in *ActuateRequest, opts ...grpc.CallOption) (*ActuateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActuateResponse)
+	err := c.cc.Invoke(ctx, VAL_Actuate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vALClient) ListMetadata(ctx context.Context, in *ListMetadataRequest, opts ...grpc.CallOption) (*ListMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMetadataResponse)
+	err := c.cc.Invoke(ctx, VAL_ListMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vALClient) PublishValue(ctx context.Context, in *PublishValueRequest, opts ...grpc.CallOption) (*PublishValueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishValueResponse)
+	err := c.cc.Invoke(ctx, VAL_PublishValue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vALClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServerInfoResponse)
+	err := c.cc.Invoke(ctx, VAL_GetServerInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VALServer is the server API for VAL service.
+// All implementations must embed UnimplementedVALServer
+// for forward compatibility.
+type VALServer interface {
+	// Get the latest value of a signal.
+	GetValue(context.Context, *GetValueRequest) (*GetValueResponse, error)
+	// Get the latest values of a set of signals, in the order of the request.
+	GetValues(context.Context, *GetValuesRequest) (*GetValuesResponse, error)
+	// Subscribe to a set of signals. The first response contains the current values.
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[SubscribeResponse]) error
+	// Actuate a single actuator, the request is forwarded to the provider of the actuator.
+	Actuate(context.Context, *ActuateRequest) (*ActuateResponse, error)
+	// List the metadata of the signals at and below root.
+	ListMetadata(context.Context, *ListMetadataRequest) (*ListMetadataResponse, error)
+	// Publish a signal value, as done by a provider.
+	PublishValue(context.Context, *PublishValueRequest) (*PublishValueResponse, error)
+	// Get the name and the version of the databroker.
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error)
+	mustEmbedUnimplementedVALServer()
+}
+
+// UnimplementedVALServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVALServer struct{}
+
+func (UnimplementedVALServer) GetValue(context.Context, *GetValueRequest) (*GetValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValue not implemented")
+}
+func (UnimplementedVALServer) GetValues(context.Context, *GetValuesRequest) (*GetValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValues not implemented")
+}
+func (UnimplementedVALServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[SubscribeResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedVALServer) Actuate(context.Context, *ActuateRequest) (*ActuateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Actuate not implemented")
+}
+func (UnimplementedVALServer) ListMetadata(context.Context, *ListMetadataRequest) (*ListMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMetadata not implemented")
+}
+func (UnimplementedVALServer) PublishValue(context.Context, *PublishValueRequest) (*PublishValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishValue not implemented")
+}
+func (UnimplementedVALServer) GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (UnimplementedVALServer) mustEmbedUnimplementedVALServer() {}
+func (UnimplementedVALServer) testEmbeddedByValue()             {}
+
+// UnsafeVALServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VALServer will
+// result in compilation errors.
+type UnsafeVALServer interface {
+	mustEmbedUnimplementedVALServer()
+}
+
+func RegisterVALServer(s grpc.ServiceRegistrar, srv VALServer) {
+	// If the following call pancis, it indicates UnimplementedVALServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VAL_ServiceDesc, srv)
+}
+
+func _VAL_GetValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VALServer).GetValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VAL_GetValue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VALServer).GetValue(ctx, req.(*GetValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VAL_GetValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VALServer).GetValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VAL_GetValues_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VALServer).GetValues(ctx, req.(*GetValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VAL_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VALServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, SubscribeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VAL_SubscribeServer = grpc.ServerStreamingServer[SubscribeResponse]
+
+func _VAL_Actuate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActuateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VALServer).Actuate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VAL_Actuate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VALServer).Actuate(ctx, req.(*ActuateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VAL_ListMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VALServer).ListMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VAL_ListMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VALServer).ListMetadata(ctx, req.(*ListMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VAL_PublishValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VALServer).PublishValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VAL_PublishValue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VALServer).PublishValue(ctx, req.(*PublishValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VAL_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VALServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VAL_GetServerInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VALServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VAL_ServiceDesc is the grpc.ServiceDesc for VAL service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VAL_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kuksa.val.v2.VAL",
+	HandlerType: (*VALServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetValue",
+			Handler:    _VAL_GetValue_Handler,
+		},
+		{
+			MethodName: "GetValues",
+			Handler:    _VAL_GetValues_Handler,
+		},
+		{
+			MethodName: "Actuate",
+			Handler:    _VAL_Actuate_Handler,
+		},
+		{
+			MethodName: "ListMetadata",
+			Handler:    _VAL_ListMetadata_Handler,
+		},
+		{
+			MethodName: "PublishValue",
+			Handler:    _VAL_PublishValue_Handler,
+		},
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _VAL_GetServerInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _VAL_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "kuksa/val/v2/val.proto",
+}
@@ -0,0 +1,346 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ****************** Client side arbitration ***************
+/* A client side deployment of VAPI has no global arbitration between clients that use the same vehicle.
+*  If an arbiter is set, actuating services acquire a lease on the resource they actuate, e.g. "Row1.DriverSide.longitudinal", before they are executed.
+*  A lease is granted if the resource is free, or if it is held with a lower priority, in which case the holder is preempted.
+*  The arbiter can be in-process (LocalArbiter), or a local daemon that is shared by all clients in the vehicle (ArbiterClient + ServeArbiter). */
+type Arbiter interface {
+	Acquire(vehicleGuid string, resource string, priority int, preempted func()) (uint32, *ErrorData)  // preempted is called if the lease is taken over by another client
+	Release(leaseId uint32)
+}
+
+var arbiter Arbiter
+var arbitrationPriority int
+var arbiterMutex sync.Mutex
+
+// SetArbiter enables arbitration of the actuating services with the given priority, a higher value has precedence. A nil arbiter disables arbitration.
+func SetArbiter(newArbiter Arbiter, priority int) {
+	arbiterMutex.Lock()
+	arbiter = newArbiter
+	arbitrationPriority = priority
+	arbiterMutex.Unlock()
+}
+
+func acquireServiceLease(vehConn *VehicleConnection, resource string, preemptChan chan *ErrorData) (uint32, *ErrorData) {
+	arbiterMutex.Lock()
+	currentArbiter := arbiter
+	priority := arbitrationPriority
+	arbiterMutex.Unlock()
+	if currentArbiter == nil {
+		return 0, nil
+	}
+	return currentArbiter.Acquire(vehConn.vehicleGuid, resource, priority, func() {
+		select {
+			case preemptChan <- getErrorObject(503, "service_unavailable", "Preempted by a client with higher priority on " + resource):
+			default:
+		}
+	})
+}
+
+func releaseServiceLease(leaseId uint32) {
+	if leaseId == 0 {
+		return
+	}
+	arbiterMutex.Lock()
+	currentArbiter := arbiter
+	arbiterMutex.Unlock()
+	if currentArbiter != nil {
+		currentArbiter.Release(leaseId)
+	}
+}
+
+func createSeatResourceName(seatId MatrixId, resourceType string) string {
+	return seatId.RowName + "." + seatId.ColumnName + "." + resourceType
+}
+
+type lease struct {
+	leaseId uint32
+	key string
+	priority int
+	preempted func()
+}
+
+type LocalArbiter struct {
+	mutex sync.Mutex
+	leases map[string]*lease  // key = vehicleGuid:resource
+	leaseKeys map[uint32]string
+}
+
+func NewLocalArbiter() *LocalArbiter {
+	var localArbiter LocalArbiter
+	localArbiter.leases = make(map[string]*lease)
+	localArbiter.leaseKeys = make(map[uint32]string)
+	return &localArbiter
+}
+
+func (localArbiter *LocalArbiter) Acquire(vehicleGuid string, resource string, priority int, preempted func()) (uint32, *ErrorData) {
+	key := vehicleGuid + ":" + resource
+	localArbiter.mutex.Lock()
+	holder := localArbiter.leases[key]
+	if holder != nil && holder.priority >= priority {
+		localArbiter.mutex.Unlock()
+		return 0, getErrorObject(503, "service_unavailable", "Resource " + resource + " is leased by another client")
+	}
+	if holder != nil {
+		delete(localArbiter.leaseKeys, holder.leaseId)
+	}
+	newLease := &lease{generateRandomUint32(), key, priority, preempted}
+	for newLease.leaseId == 0 || localArbiter.leaseKeys[newLease.leaseId] != "" {
+		newLease.leaseId = generateRandomUint32()
+	}
+	localArbiter.leases[key] = newLease
+	localArbiter.leaseKeys[newLease.leaseId] = key
+	localArbiter.mutex.Unlock()
+	if holder != nil && holder.preempted != nil {
+		go holder.preempted()
+	}
+	return newLease.leaseId, nil
+}
+
+func (localArbiter *LocalArbiter) Release(leaseId uint32) {
+	localArbiter.mutex.Lock()
+	defer localArbiter.mutex.Unlock()
+	key, ok := localArbiter.leaseKeys[leaseId]
+	if !ok {
+		return
+	}
+	delete(localArbiter.leaseKeys, leaseId)
+	if localArbiter.leases[key] != nil && localArbiter.leases[key].leaseId == leaseId {
+		delete(localArbiter.leases, key)
+	}
+}
+
+/* The arbiter daemon protocol is JSON objects separated by newlines on a Unix socket.
+*  client: {"action":"acquire", "vehicleGuid":"...", "resource":"...", "priority":"1", "requestId":"..."}
+*  server: {"requestId":"...", "leaseId":"..."} or {"requestId":"...", "error":{"number":"503", "reason":"...", "description":"..."}}
+*  client: {"action":"release", "leaseId":"..."}
+*  server: {"action":"preempted", "leaseId":"..."}
+*  All leases of a client are released when its connection is closed. */
+type ArbiterClient struct {
+	conn net.Conn
+	mutex sync.Mutex
+	pending map[string]pendingAcquire  // by request id
+	preempted map[uint32]func()  // by lease id
+}
+
+type pendingAcquire struct {
+	responseChan chan map[string]interface{}
+	preempted func()  // keyed by the lease id when the response is received, as a preemption may follow it before Acquire returns
+}
+
+func DialArbiter(socketPath string) (*ArbiterClient, *ErrorData) {
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return nil, getErrorObject(502, "bad_gateway", "Arbiter not reachable: " + err.Error())
+	}
+	var client ArbiterClient
+	client.conn = conn
+	client.pending = make(map[string]pendingAcquire)
+	client.preempted = make(map[uint32]func())
+	go client.receiveMessages()
+	return &client, nil
+}
+
+func (client *ArbiterClient) Close() {
+	client.conn.Close()
+}
+
+func (client *ArbiterClient) Acquire(vehicleGuid string, resource string, priority int, preempted func()) (uint32, *ErrorData) {
+	requestId := generateRandomString()
+	responseChan := make(chan map[string]interface{}, 1)
+	client.mutex.Lock()
+	client.pending[requestId] = pendingAcquire{responseChan, preempted}
+	client.mutex.Unlock()
+	request := map[string]string{"action": "acquire", "vehicleGuid": vehicleGuid, "resource": resource, "priority": strconv.Itoa(priority), "requestId": requestId}
+	if !client.sendMessage(request) {
+		client.mutex.Lock()
+		delete(client.pending, requestId)
+		client.mutex.Unlock()
+		return 0, getErrorObject(502, "bad_gateway", "Arbiter not reachable")
+	}
+	responseMap, ok := <- responseChan
+	if !ok {
+		return 0, getErrorObject(502, "bad_gateway", "Arbiter connection closed")
+	}
+	if responseMap["error"] != nil {
+		return 0, getErrorInfo(responseMap["error"].(map[string]interface{}))
+	}
+	leaseId, _ := strconv.ParseUint(responseMap["leaseId"].(string), 10, 32)
+	return uint32(leaseId), nil
+}
+
+func (client *ArbiterClient) Release(leaseId uint32) {
+	client.mutex.Lock()
+	delete(client.preempted, leaseId)
+	client.mutex.Unlock()
+	client.sendMessage(map[string]string{"action": "release", "leaseId": strconv.FormatUint(uint64(leaseId), 10)})
+}
+
+func (client *ArbiterClient) sendMessage(message map[string]string) bool {
+	data, _ := json.Marshal(message)
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	_, err := client.conn.Write(append(data, '\n'))
+	return err == nil
+}
+
+func (client *ArbiterClient) receiveMessages() {
+	scanner := bufio.NewScanner(client.conn)
+	for scanner.Scan() {
+		var messageMap map[string]interface{}
+		err := json.Unmarshal(scanner.Bytes(), &messageMap)
+		if err != nil {
+			fmt.Printf("ArbiterClient:error message=%s, err=%s\n", scanner.Text(), err)
+			continue
+		}
+		if messageMap["action"] == "preempted" {
+			leaseId, _ := strconv.ParseUint(messageMap["leaseId"].(string), 10, 32)
+			client.mutex.Lock()
+			preempted := client.preempted[uint32(leaseId)]
+			delete(client.preempted, uint32(leaseId))
+			client.mutex.Unlock()
+			if preempted != nil {
+				go preempted()
+			}
+			continue
+		}
+		requestId, _ := messageMap["requestId"].(string)
+		client.mutex.Lock()
+		request, ok := client.pending[requestId]
+		delete(client.pending, requestId)
+		if leaseIdStr, isLease := messageMap["leaseId"].(string); ok && isLease {
+			leaseId, _ := strconv.ParseUint(leaseIdStr, 10, 32)
+			client.preempted[uint32(leaseId)] = request.preempted
+		}
+		client.mutex.Unlock()
+		if ok {
+			request.responseChan <- messageMap
+		}
+	}
+	client.mutex.Lock()
+	for requestId, request := range client.pending {
+		close(request.responseChan)
+		delete(client.pending, requestId)
+	}
+	client.mutex.Unlock()
+}
+
+// ServeArbiter runs an arbiter daemon on the Unix socket, using localArbiter for the arbitration. It returns on a listen error, or when the listener is closed.
+func ServeArbiter(socketPath string, localArbiter *LocalArbiter) error {
+	os.Remove(socketPath)
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+	return serveArbiterListener(listener, localArbiter)
+}
+
+func serveArbiterListener(listener net.Listener, localArbiter *LocalArbiter) error {
+	var acceptDelay time.Duration
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			if acceptDelay == 0 { // e.g. out of file descriptors, back off instead of spinning on the error
+				acceptDelay = 5 * time.Millisecond
+			} else if acceptDelay < time.Second {
+				acceptDelay *= 2
+			}
+			fmt.Printf("ServeArbiter:accept error=%s, retrying in %s\n", err, acceptDelay)
+			time.Sleep(acceptDelay)
+			continue
+		}
+		acceptDelay = 0
+		go serveArbiterClient(conn, localArbiter)
+	}
+}
+
+func serveArbiterClient(conn net.Conn, localArbiter *LocalArbiter) {
+	var writeMutex sync.Mutex
+	var leaseMutex sync.Mutex
+	leaseIds := make(map[uint32]bool)
+	writeMessage := func(message map[string]interface{}) {
+		data, _ := json.Marshal(message)
+		writeMutex.Lock()
+		conn.Write(append(data, '\n'))
+		writeMutex.Unlock()
+	}
+	defer func() {
+		conn.Close()
+		leaseMutex.Lock()
+		for leaseId := range leaseIds {
+			localArbiter.Release(leaseId)
+		}
+		leaseMutex.Unlock()
+	}()
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var messageMap map[string]string
+		err := json.Unmarshal(scanner.Bytes(), &messageMap)
+		if err != nil {
+			fmt.Printf("serveArbiterClient:error message=%s, err=%s\n", scanner.Text(), err)
+			continue
+		}
+		switch messageMap["action"] {
+			case "acquire":
+				priority, _ := strconv.Atoi(messageMap["priority"])
+				var leaseId uint32
+				var leaseIdMutex sync.Mutex
+				leaseIdMutex.Lock() // the preemption may be reported before the lease id is known
+				leaseId, errorData := localArbiter.Acquire(messageMap["vehicleGuid"], messageMap["resource"], priority, func() {
+					leaseIdMutex.Lock()
+					defer leaseIdMutex.Unlock()
+					leaseMutex.Lock()
+					delete(leaseIds, leaseId)
+					leaseMutex.Unlock()
+					writeMessage(map[string]interface{}{"action": "preempted", "leaseId": strconv.FormatUint(uint64(leaseId), 10)})
+				})
+				if errorData != nil {
+					leaseIdMutex.Unlock()
+					writeMessage(map[string]interface{}{"requestId": messageMap["requestId"], "error": map[string]string{"number": strconv.Itoa(int(errorData.Code)), "reason": errorData.Reason, "description": errorData.Description}})
+					continue
+				}
+				leaseMutex.Lock()
+				leaseIds[leaseId] = true
+				leaseMutex.Unlock()
+				writeMessage(map[string]interface{}{"requestId": messageMap["requestId"], "leaseId": strconv.FormatUint(uint64(leaseId), 10)})
+				leaseIdMutex.Unlock()
+			case "release":
+				leaseId, _ := strconv.ParseUint(messageMap["leaseId"], 10, 32)
+				leaseMutex.Lock()
+				isHeld := leaseIds[uint32(leaseId)]  // a client may only release its own leases
+				delete(leaseIds, uint32(leaseId))
+				leaseMutex.Unlock()
+				if !isHeld {
+					fmt.Printf("serveArbiterClient:release of a lease that is not held by the client, leaseId=%s\n", messageMap["leaseId"])
+					continue
+				}
+				localArbiter.Release(uint32(leaseId))
+			default:
+				fmt.Printf("serveArbiterClient:unknown action=%s\n", messageMap["action"])
+		}
+	}
+}
@@ -0,0 +1,267 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testArbiter(t *testing.T, testArbiter Arbiter) {
+	t.Helper()
+	preemptedChan := make(chan string, 2)
+	lowLease, err := testArbiter.Acquire("vin", "Row1.DriverSide.longitudinal", 1, func() { preemptedChan <- "low" })
+	if err != nil || lowLease == 0 {
+		t.Fatalf("Acquire of free resource: lease=%d, error=%v", lowLease, err)
+	}
+	if _, err := testArbiter.Acquire("vin", "Row1.DriverSide.longitudinal", 1, nil); err == nil || err.Code != 503 {
+		t.Errorf("Acquire with equal priority: error = %v, want code 503", err)
+	}
+	otherLease, err := testArbiter.Acquire("vin", "Row1.DriverSide.lumbar", 1, nil)
+	if err != nil {
+		t.Errorf("Acquire of other resource: error = %v", err)
+	}
+	highLease, err := testArbiter.Acquire("vin", "Row1.DriverSide.longitudinal", 2, func() { preemptedChan <- "high" })
+	if err != nil {
+		t.Fatalf("Acquire with higher priority: error = %v", err)
+	}
+	select {
+	case holder := <- preemptedChan:
+		if holder != "low" {
+			t.Errorf("preempted %s, want low", holder)
+		}
+	case <- time.After(2 * time.Second):
+		t.Fatalf("lower priority lease not preempted")
+	}
+	testArbiter.Release(lowLease) // releasing a preempted lease must not release the new holder
+	if _, err := testArbiter.Acquire("vin", "Row1.DriverSide.longitudinal", 1, nil); err == nil {
+		t.Errorf("Acquire succeeded after release of preempted lease")
+	}
+	testArbiter.Release(highLease)
+	testArbiter.Release(otherLease)
+	lease, err := testArbiter.Acquire("vin", "Row1.DriverSide.longitudinal", 0, nil)
+	if err != nil {
+		t.Errorf("Acquire after Release: error = %v", err)
+	}
+	testArbiter.Release(lease)
+}
+
+func TestLocalArbiter(t *testing.T) {
+	testArbiter(t, NewLocalArbiter())
+}
+
+func TestArbiterDaemon(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "arbiter.sock")
+	localArbiter := NewLocalArbiter()
+	go ServeArbiter(socketPath, localArbiter)
+	var client *ArbiterClient
+	var err *ErrorData
+	for i := 0; i < 100; i++ {
+		client, err = DialArbiter(socketPath)
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("DialArbiter: %v", err)
+	}
+	testArbiter(t, client)
+
+	if _, err := client.Acquire("vin", "Row1.PassengerSide.massage", 5, nil); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	client.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for { // the leases of a closed connection are released by the daemon
+		lease, err := localArbiter.Acquire("vin", "Row1.PassengerSide.massage", 0, nil)
+		if err == nil {
+			localArbiter.Release(lease)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("lease not released at client disconnect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestArbiterDaemonReleaseOfOtherClient(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "arbiter.sock")
+	localArbiter := NewLocalArbiter()
+	go ServeArbiter(socketPath, localArbiter)
+	var clients [2]*ArbiterClient
+	for i := range clients {
+		var err *ErrorData
+		for j := 0; j < 100; j++ {
+			clients[i], err = DialArbiter(socketPath)
+			if err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err != nil {
+			t.Fatalf("DialArbiter: %v", err)
+		}
+		defer clients[i].Close()
+	}
+	lease, err := clients[0].Acquire("vin", "Row1.DriverSide.massage", 1, nil)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	clients[1].Release(lease)
+	otherLease, err := clients[1].Acquire("vin", "Row1.DriverSide.climate", 1, nil)  // handled after the release on the connection
+	if err != nil {
+		t.Fatalf("Acquire of other resource: %v", err)
+	}
+	clients[1].Release(otherLease)
+	if _, err := clients[1].Acquire("vin", "Row1.DriverSide.massage", 1, nil); err == nil || err.Code != 503 {
+		t.Errorf("Acquire after the release of the lease of another client: error = %v, want code 503", err)
+	}
+	clients[0].Release(lease)
+}
+
+func TestArbiterClientPreemptedAtGrant(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	client := &ArbiterClient{conn: clientConn, pending: make(map[string]pendingAcquire), preempted: make(map[uint32]func())}
+	go client.receiveMessages()
+	defer client.Close()
+	go func() { // a daemon that preempts the lease right after it is granted
+		scanner := bufio.NewScanner(serverConn)
+		if scanner.Scan() {
+			var request map[string]string
+			json.Unmarshal(scanner.Bytes(), &request)
+			serverConn.Write([]byte(`{"requestId":"` + request["requestId"] + `", "leaseId":"7"}` + "\n" + `{"action":"preempted", "leaseId":"7"}` + "\n"))
+		}
+		io.Copy(io.Discard, serverConn)
+	}()
+	preemptedChan := make(chan bool, 1)
+	leaseId, err := client.Acquire("vin", "Row1.DriverSide.longitudinal", 1, func() { preemptedChan <- true })
+	if err != nil || leaseId != 7 {
+		t.Fatalf("Acquire: lease=%d, error=%v", leaseId, err)
+	}
+	select {
+	case <- preemptedChan:
+	case <- time.After(2 * time.Second):
+		t.Errorf("preemption that followed the grant was lost")
+	}
+}
+
+func TestServeArbiterListenerClosed(t *testing.T) {
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "arbiter.sock"))
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	doneChan := make(chan error, 1)
+	go func() { doneChan <- serveArbiterListener(listener, NewLocalArbiter()) }()
+	listener.Close()
+	select {
+	case err := <- doneChan:
+		if err != nil {
+			t.Errorf("serveArbiterListener of closed listener: %v", err)
+		}
+	case <- time.After(2 * time.Second):
+		t.Fatalf("serveArbiterListener did not return when the listener was closed")
+	}
+}
+
+func TestMoveSeatPreempted(t *testing.T) {
+	signals := fastSignals()
+	for i := 0; i < len(signals); i++ {
+		if strings.HasSuffix(signals[i].Path, "DriverSide.Position") {
+			signals[i].Rate = 10
+		}
+	}
+	_, vehicleId := startMockVehicle(t, signals)
+	localArbiter := NewLocalArbiter()
+	SetArbiter(localArbiter, 1)
+	defer SetArbiter(nil, 0)
+	eventChan := make(chan MoveSeatOutput, 10)
+	moveSeatOut := MoveSeat(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, LONGITUDINAL, BACKWARD, "", func(out MoveSeatOutput) {
+		eventChan <- out
+	})
+	if moveSeatOut.Status != ONGOING {
+		t.Fatalf("MoveSeat: status=%d, error=%v", moveSeatOut.Status, moveSeatOut.Error)
+	}
+	vehicleGuid := getVehicleConnection(vehicleId).vehicleGuid
+	if _, err := localArbiter.Acquire(vehicleGuid, "Row1.DriverSide.longitudinal", 1, nil); err == nil {
+		t.Errorf("resource of ongoing MoveSeat acquired with equal priority")
+	}
+	lease, err := localArbiter.Acquire(vehicleGuid, "Row1.DriverSide.longitudinal", 2, nil) // another client with higher priority
+	if err != nil {
+		t.Fatalf("Acquire with higher priority: %v", err)
+	}
+	defer localArbiter.Release(lease)
+	event := waitFor(t, eventChan, func(out MoveSeatOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || event.Error.Code != 503 {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+}
+
+func TestSeatClimateLease(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	localArbiter := NewLocalArbiter()
+	SetArbiter(localArbiter, 1)
+	defer SetArbiter(nil, 0)
+	eventChan := make(chan SeatClimateOutput, 10)
+	heatingOut := ActivateSeatHeating(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, 50, 3600, "", func(out SeatClimateOutput) {
+		eventChan <- out
+	})
+	if heatingOut.Status != ONGOING {
+		t.Fatalf("ActivateSeatHeating: status=%d, error=%v", heatingOut.Status, heatingOut.Error)
+	}
+	if out := ActivateSeatVentilation(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, 50, 3600, "", nil); out.Status != FAILED || out.Error.Code != 503 {
+		t.Errorf("ventilation of a heated seat: status=%d, error=%v", out.Status, out.Error)
+	}
+	vehicleGuid := getVehicleConnection(vehicleId).vehicleGuid
+	lease, err := localArbiter.Acquire(vehicleGuid, "Row1.DriverSide.climate", 2, nil)
+	if err != nil {
+		t.Fatalf("Acquire with higher priority: %v", err)
+	}
+	defer localArbiter.Release(lease)
+	event := waitFor(t, eventChan, func(out SeatClimateOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || event.Error.Code != 503 {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+}
+
+func TestMassageProgramLease(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	localArbiter := NewLocalArbiter()
+	SetArbiter(localArbiter, 1)
+	defer SetArbiter(nil, 0)
+	eventChan := make(chan MassageProgramOutput, 10)
+	steps := []MassageStep{{MassageType: ROLL, Intensity: 30, Duration: 1}, {MassageType: WAVE, Intensity: 60, Duration: 60}}
+	programOut := ActivateMassageProgram(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, steps, "", func(out MassageProgramOutput) {
+		eventChan <- out
+	})
+	if programOut.Status != ONGOING {
+		t.Fatalf("ActivateMassageProgram: status=%d, error=%v", programOut.Status, programOut.Error)
+	}
+	waitFor(t, eventChan, func(out MassageProgramOutput) bool { return out.StepIndex == 1 }) // the lease is kept between the steps
+	vehicleGuid := getVehicleConnection(vehicleId).vehicleGuid
+	if _, err := localArbiter.Acquire(vehicleGuid, "Row1.DriverSide.massage", 1, nil); err == nil {
+		t.Errorf("resource of ongoing massage program acquired with equal priority")
+	}
+	lease, err := localArbiter.Acquire(vehicleGuid, "Row1.DriverSide.massage", 2, nil)
+	if err != nil {
+		t.Fatalf("Acquire with higher priority: %v", err)
+	}
+	defer localArbiter.Release(lease)
+	event := waitFor(t, eventChan, func(out MassageProgramOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || event.Error.Code != 503 {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+}
@@ -0,0 +1,111 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"VISS-Go/vapi"
+)
+
+// ****************** VAPI backend ***************
+// Backend implements the vapi interfaces by the package level procedures, with VISS as the connectivity to the vehicle.
+type Backend struct{}
+
+var _ vapi.Vapi = Backend{}
+
+func NewBackend() vapi.Vapi {
+	return Backend{}
+}
+
+func (Backend) GetVehicle(vehicleGuid string) GetVehicleOutput {
+	return GetVehicle(vehicleGuid)
+}
+
+func (Backend) ReleaseVehicle(vehicleId VehicleHandle) GeneralOutput {
+	return ReleaseVehicle(vehicleId)
+}
+
+func (Backend) Connect(vehicleId VehicleHandle, protocol string, clientCredentials string) ConnectOutput {
+	return Connect(vehicleId, protocol, clientCredentials)
+}
+
+func (Backend) Disconnect(vehicleId VehicleHandle, protocol string) GeneralOutput {
+	return Disconnect(vehicleId, protocol)
+}
+
+func (Backend) SelectProtocol(vehicleId VehicleHandle, protocol string) GeneralOutput {
+	return SelectProtocol(vehicleId, protocol)
+}
+
+func (Backend) CancelService(vehicleId VehicleHandle, serviceId uint32) GeneralOutput {
+	return CancelService(vehicleId, serviceId)
+}
+
+func (Backend) ServiceInquiry(vehicleId VehicleHandle) ServiceInquiryOutput {
+	return ServiceInquiry(vehicleId)
+}
+
+func (Backend) GetStCredentials(vehicleId VehicleHandle, ltCredentials string, purpose string) GetStCredentialsOutput {
+	return GetStCredentials(vehicleId, ltCredentials, purpose)
+}
+
+func (Backend) Invoke(vehicleId VehicleHandle, serviceName string, procedureInput string, stCredentials string, callback func(InvokeOutput)) InvokeOutput {
+	return Invoke(vehicleId, serviceName, procedureInput, stCredentials, callback)
+}
+
+func (Backend) GetMetadata(vehicleId VehicleHandle, path string, stCredentials string) GetMetadataOutput {
+	return GetMetadata(vehicleId, path, stCredentials)
+}
+
+func (Backend) Get(vehicleId VehicleHandle, path string, filter string, stCredentials string) GetOutput {
+	return Get(vehicleId, path, filter, stCredentials)
+}
+
+func (Backend) Set(vehicleId VehicleHandle, path string, value string, stCredentials string) GeneralOutput {
+	return Set(vehicleId, path, value, stCredentials)
+}
+
+func (Backend) Subscribe(vehicleId VehicleHandle, path string, filter string, stCredentials string, callback func(SubscribeOutput)) SubscribeOutput {
+	return Subscribe(vehicleId, path, filter, stCredentials, callback)
+}
+
+func (Backend) Unsubscribe(vehicleId VehicleHandle, serviceId uint32) GeneralOutput {
+	return Unsubscribe(vehicleId, serviceId)
+}
+
+func (Backend) MoveSeat(vehicleId VehicleHandle, seatId MatrixId, movementType string, position Percentage, stCredentials string, callback func(MoveSeatOutput)) MoveSeatOutput {
+	return MoveSeat(vehicleId, seatId, movementType, position, stCredentials, callback)
+}
+
+func (Backend) ConfigureSeat(vehicleId VehicleHandle, seatId MatrixId, configuration []SeatConfig, stCredentials string, callback func(ConfigureSeatOutput)) ConfigureSeatOutput {
+	return ConfigureSeat(vehicleId, seatId, configuration, stCredentials, callback)
+}
+
+func (Backend) ActivateMassage(vehicleId VehicleHandle, seatId MatrixId, massageType string, intensity Percentage, duration uint32, stCredentials string, callback func(MassageOutput)) MassageOutput {
+	return ActivateMassage(vehicleId, seatId, massageType, intensity, duration, stCredentials, callback)
+}
+
+func (Backend) ActivateMassageProgram(vehicleId VehicleHandle, seatId MatrixId, steps []MassageStep, stCredentials string, callback func(MassageProgramOutput)) MassageProgramOutput {
+	return ActivateMassageProgram(vehicleId, seatId, steps, stCredentials, callback)
+}
+
+func (Backend) ActivateSeatHeating(vehicleId VehicleHandle, seatId MatrixId, level Percentage, duration uint32, stCredentials string, callback func(SeatClimateOutput)) SeatClimateOutput {
+	return ActivateSeatHeating(vehicleId, seatId, level, duration, stCredentials, callback)
+}
+
+func (Backend) ActivateSeatVentilation(vehicleId VehicleHandle, seatId MatrixId, level Percentage, duration uint32, stCredentials string, callback func(SeatClimateOutput)) SeatClimateOutput {
+	return ActivateSeatVentilation(vehicleId, seatId, level, duration, stCredentials, callback)
+}
+
+func (Backend) GetPropertiesSeating(vehicleId VehicleHandle) GetPropertiesSeatingOutput {
+	return GetPropertiesSeating(vehicleId)
+}
+
+func (Backend) HvacService1(vehicleId VehicleHandle) GeneralOutput {
+	return HvacService1(vehicleId)
+}
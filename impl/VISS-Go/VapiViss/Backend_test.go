@@ -0,0 +1,44 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"testing"
+
+	"VISS-Go/vapi"
+)
+
+func TestBackend(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	server.SetValue("Vehicle.Speed", "12")
+	var backend vapi.Vapi = NewBackend()
+	getOut := backend.Get(vehicleId, "Vehicle.Speed", "", "")
+	if getOut.Status != vapi.SUCCESSFUL || getOut.Data[0].Dp[0].Value != "12" {
+		t.Errorf("Get: %+v", getOut)
+	}
+	setOut := backend.Set(vehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType", vapi.PULSE, "")
+	if setOut.Status != vapi.SUCCESSFUL {
+		t.Errorf("Set: %v", setOut.Error)
+	}
+	propertiesOut := backend.GetPropertiesSeating(vehicleId)
+	if propertiesOut.Status != vapi.SUCCESSFUL || len(propertiesOut.Properties) == 0 {
+		t.Errorf("GetPropertiesSeating: %+v", propertiesOut)
+	}
+	errorData := CheckPreconditions(backend, vehicleId, []Precondition{{Path: "Vehicle.Speed", Operator: "gt", Value: "10"}}, "")
+	if errorData != nil {
+		t.Errorf("CheckPreconditions: %v", errorData)
+	}
+	errorData = CheckPreconditions(backend, vehicleId, []Precondition{{Path: "Vehicle.Speed", Operator: "eq", Value: "0", Description: "stationary"}}, "")
+	if errorData == nil || errorData.Code != 412 {
+		t.Errorf("CheckPreconditions of a violated precondition: %v", errorData)
+	}
+	if backend.CancelService(vehicleId, 1).Status != vapi.FAILED {
+		t.Errorf("CancelService of an unknown service did not fail")
+	}
+}
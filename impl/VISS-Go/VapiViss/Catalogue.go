@@ -0,0 +1,216 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"VISS-Go/vapi"
+	"gopkg.in/yaml.v3"
+)
+
+// ****************** Signal catalogue ***************
+/* A catalogue is a local copy of the VSS/HIM signal tree. When it is set, the paths of Get, Set, Subscribe, and GetMetadata, including the
+*  wildcard paths and the paths filter, and the values of Set are validated against it before the request is sent to the vehicle.
+*  A request that is not valid fails with the error 400 without a server round trip. The catalogue is loaded from one of the vss-tools exports
+*    .json  the JSON tree, which has the format of the HIM metadata tree
+*    .csv   the CSV table, with a header line that names the columns Signal, Type, DataType, Unit, Min, Max, Desc, and Allowed
+*    .yaml  the YAML map of nodes keyed by their paths
+*  or it is set from the tree that is read from a vehicle by GetMetadata. */
+
+var catalogueMutex sync.Mutex
+var catalogue *vapi.MetadataTree
+
+// LoadCatalogue loads the catalogue from a VSS export file, the format is selected by the file extension.
+func LoadCatalogue(fileName string) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	var tree *vapi.MetadataTree
+	switch strings.ToLower(filepath.Ext(fileName)) {
+		case ".json":
+			tree, err = vapi.ParseMetadata("", string(data))
+		case ".csv":
+			tree, err = parseCsvCatalogue(data)
+		case ".yaml", ".yml":
+			tree, err = parseYamlCatalogue(data)
+		default:
+			err = fmt.Errorf("unknown catalogue format %s", filepath.Ext(fileName))
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %s", fileName, err)
+	}
+	SetCatalogue(tree)
+	return nil
+}
+
+// SetCatalogue sets the catalogue that the requests are validated against, nil disables the validation.
+func SetCatalogue(tree *vapi.MetadataTree) {
+	catalogueMutex.Lock()
+	catalogue = tree
+	catalogueMutex.Unlock()
+}
+
+func getCatalogue() *vapi.MetadataTree {
+	catalogueMutex.Lock()
+	defer catalogueMutex.Unlock()
+	return catalogue
+}
+
+func parseCsvCatalogue(data []byte) (*vapi.MetadataTree, error) {
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("the header line is missing")
+	}
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns["Signal"]; !ok {
+		return nil, fmt.Errorf("the Signal column is missing")
+	}
+	getColumn := func(record []string, name string) string {
+		if index, ok := columns[name]; ok && index < len(record) {
+			return strings.TrimSpace(record[index])
+		}
+		return ""
+	}
+	var nodes []*vapi.MetadataNode
+	for _, record := range records[1:] {
+		node := &vapi.MetadataNode{Path: getColumn(record, "Signal"), Type: getColumn(record, "Type"), Datatype: getColumn(record, "DataType"),
+			Unit: getColumn(record, "Unit"), Description: getColumn(record, "Desc")}
+		node.Min = parseCatalogueNumber(getColumn(record, "Min"))
+		node.Max = parseCatalogueNumber(getColumn(record, "Max"))
+		if allowed := strings.Trim(getColumn(record, "Allowed"), "[]"); allowed != "" {  // a Python list, e.g. ['OFF', 'ON']
+			for _, value := range strings.Split(allowed, ",") {
+				node.Allowed = append(node.Allowed, strings.Trim(strings.TrimSpace(value), `'"`))
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return buildCatalogue(nodes)
+}
+
+func parseCatalogueNumber(value string) *float64 {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
+	return &number
+}
+
+func parseYamlCatalogue(data []byte) (*vapi.MetadataTree, error) {
+	var nodeMap map[string]struct {
+		Type string `yaml:"type"`
+		Datatype string `yaml:"datatype"`
+		Unit string `yaml:"unit"`
+		Min *float64 `yaml:"min"`
+		Max *float64 `yaml:"max"`
+		Allowed []string `yaml:"allowed"`
+		Description string `yaml:"description"`
+	}
+	err := yaml.Unmarshal(data, &nodeMap)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []*vapi.MetadataNode
+	for path, node := range nodeMap {
+		nodes = append(nodes, &vapi.MetadataNode{Path: path, Type: node.Type, Datatype: node.Datatype, Unit: node.Unit, Min: node.Min, Max: node.Max,
+			Allowed: node.Allowed, Description: node.Description})
+	}
+	return buildCatalogue(nodes)
+}
+
+// buildCatalogue inserts the nodes in path order, so that a branch is inserted before its children.
+func buildCatalogue(nodes []*vapi.MetadataNode) (*vapi.MetadataTree, error) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Path < nodes[j].Path })
+	var tree vapi.MetadataTree
+	for _, node := range nodes {
+		err := tree.Insert(node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &tree, nil
+}
+
+// validatePath returns an error object if the catalogue is set and has no node that matches the path, or one of the paths of a paths filter.
+func validatePath(path string, filter string) *ErrorData {
+	tree := getCatalogue()
+	if tree == nil {
+		return nil
+	}
+	if len(tree.Match(path)) == 0 {
+		return getErrorObject(400, "invalid_data", "unknown path " + path)
+	}
+	for _, relativePath := range getFilterPaths(filter) {
+		if len(tree.Match(path + "." + relativePath)) == 0 {
+			return getErrorObject(400, "invalid_data", "unknown path " + path + "." + relativePath)
+		}
+	}
+	return nil
+}
+
+// getFilterPaths returns the relative paths of the paths filter of a filter expression, which is a filter object or an array of them.
+func getFilterPaths(filter string) []string {
+	var filterList []map[string]interface{}
+	if json.Unmarshal([]byte(filter), &filterList) != nil {
+		var filterMap map[string]interface{}
+		if json.Unmarshal([]byte(filter), &filterMap) != nil {
+			return nil
+		}
+		filterList = append(filterList, filterMap)
+	}
+	var paths []string
+	for _, filterMap := range filterList {
+		if filterMap["variant"] != "paths" {
+			continue
+		}
+		switch parameter := filterMap["parameter"].(type) {
+			case string:
+				paths = append(paths, parameter)
+			case []interface{}:
+				for _, relativePath := range parameter {
+					if relativePath, ok := relativePath.(string); ok {
+						paths = append(paths, relativePath)
+					}
+				}
+		}
+	}
+	return paths
+}
+
+// validateSetValue returns an error object if the catalogue is set, and the path is not a leaf of it, or the value is not valid for the leaf.
+func validateSetValue(path string, value string) *ErrorData {
+	tree := getCatalogue()
+	if tree == nil {
+		return nil
+	}
+	node := tree.Lookup(path)
+	if node == nil {
+		return getErrorObject(400, "invalid_data", "unknown path " + path)
+	}
+	err := node.CheckValue(value)
+	if err != nil {
+		return getErrorObject(400, "invalid_data", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testCsvCatalogue = `"Signal","Type","DataType","Deprecated","Unit","Min","Max","Desc","Comment","Allowed","Default","Id"
+"Vehicle","branch","","","","","","High-level vehicle data.","","","",""
+"Vehicle.Speed","sensor","float","","km/h","","","Vehicle speed.","","","",""
+"Vehicle.Cabin","branch","","","","","","All in-cabin components.","","","",""
+"Vehicle.Cabin.Seat","branch","","","","","","All seats.","","","",""
+"Vehicle.Cabin.Seat.Row1","branch","","","","","","Seat row 1.","","","",""
+"Vehicle.Cabin.Seat.Row1.DriverSide","branch","","","","","","Driver side seat.","","","",""
+"Vehicle.Cabin.Seat.Row1.DriverSide.Position","actuator","uint16","","mm","0","","Seat position on vehicle x-axis.","","","",""
+"Vehicle.Cabin.Seat.Row1.DriverSide.Height","actuator","uint16","","mm","0","","Seat position on vehicle z-axis.","","","",""
+"Vehicle.Cabin.Seat.Row1.DriverSide.Heating","actuator","int8","","percent","-100","100","Seat cooling / heating.","","","",""
+"Vehicle.Cabin.Seat.Row1.DriverSide.Switch","branch","","","","","","Seat switches.","","","",""
+"Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage","branch","","","","","","Massage switches.","","","",""
+"Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType","actuator","string","","","","","The type of massage.","","['SHIATSU', 'ROLLING', 'WAVE']","",""
+`
+
+const testYamlCatalogue = `Vehicle:
+  type: branch
+  description: High-level vehicle data.
+Vehicle.Cabin:
+  type: branch
+  description: All in-cabin components.
+Vehicle.Cabin.Seat:
+  type: branch
+  description: All seats.
+Vehicle.Cabin.Seat.Row1:
+  type: branch
+  description: Seat row 1.
+Vehicle.Cabin.Seat.Row1.DriverSide:
+  type: branch
+  description: Driver side seat.
+Vehicle.Cabin.Seat.Row1.DriverSide.Heating:
+  type: actuator
+  datatype: int8
+  unit: percent
+  min: -100
+  max: 100
+  description: Seat cooling / heating.
+`
+
+func loadTestCatalogue(t *testing.T, fileName string, content string) {
+	t.Helper()
+	catalogueFile := filepath.Join(t.TempDir(), fileName)
+	os.WriteFile(catalogueFile, []byte(content), 0644)
+	err := LoadCatalogue(catalogueFile)
+	if err != nil {
+		t.Fatalf("LoadCatalogue: %s", err)
+	}
+	t.Cleanup(func() { SetCatalogue(nil) })
+}
+
+func TestCatalogueValidation(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	loadTestCatalogue(t, "vss.csv", testCsvCatalogue)
+	seatPath := "Vehicle.Cabin.Seat.Row1.DriverSide"
+
+	if out := Set(vehicleId, seatPath + ".Heating", "50", ""); out.Status != SUCCESSFUL {
+		t.Errorf("Set of a valid value: status=%d, error=%v", out.Status, out.Error)
+	}
+	for _, test := range []struct {
+		path string
+		value string
+	}{
+		{seatPath + ".Heating", "150"},
+		{seatPath + ".Heating", "warm"},
+		{seatPath + ".Position", "-1"},
+		{seatPath + ".Switch.Massage.MassageType", "KNEADING"},
+		{seatPath + ".Unknown", "1"},
+		{seatPath, "1"},
+	} {
+		out := Set(vehicleId, test.path, test.value, "")
+		if out.Status != FAILED || out.Error == nil || out.Error.Code != 400 {
+			t.Errorf("Set(%s, %s): status=%d, error=%v, want the error 400", test.path, test.value, out.Status, out.Error)
+		}
+	}
+	if value, _ := server.GetValue(seatPath + ".Heating"); value != "50" {
+		t.Errorf("Heating = %s, an invalid value was sent to the server", value)
+	}
+
+	if out := Get(vehicleId, seatPath, `{"variant":"paths","parameter":["Position","Height"]}`, ""); out.Status != SUCCESSFUL || len(out.Data) != 2 {
+		t.Errorf("Get with a paths filter: status=%d, data=%v, error=%v", out.Status, out.Data, out.Error)
+	}
+	if out := Get(vehicleId, seatPath, `{"variant":"paths","parameter":["Position","Width"]}`, ""); out.Status != FAILED || out.Error.Code != 400 {
+		t.Errorf("Get with an unknown path in the paths filter: status=%d, error=%v", out.Status, out.Error)
+	}
+	if out := Get(vehicleId, "Vehicle.Cabin.Seat.*.*.Width", "", ""); out.Status != FAILED || out.Error.Code != 400 {
+		t.Errorf("Get of an unknown wildcard path: status=%d, error=%v", out.Status, out.Error)
+	}
+	if out := Subscribe(vehicleId, "Vehicle.Acceleration", "", "", func(SubscribeOutput) {}); out.Status != FAILED || out.Error.Code != 400 {
+		t.Errorf("Subscribe to an unknown path: status=%d, error=%v", out.Status, out.Error)
+	}
+	if out := GetMetadata(vehicleId, "Vehicle.Body", ""); out.Status != FAILED || out.Error.Code != 400 {
+		t.Errorf("GetMetadata of an unknown path: status=%d, error=%v", out.Status, out.Error)
+	}
+	for _, path := range []string{"Vehicle.Cabin.Seat.*.*.Position", "Vehicle.Cabin.Seat.Row*.DriverSide", "Vehicle.*"} {
+		if errorData := validatePath(path, ""); errorData != nil {
+			t.Errorf("validatePath(%s) = %v", path, errorData)
+		}
+	}
+
+	SetCatalogue(nil)
+	if out := Set(vehicleId, seatPath + ".Unknown", "1", ""); out.Status != FAILED || out.Error.Code != 404 {
+		t.Errorf("Set of an unknown path without a catalogue: status=%d, error=%v, want the server error 404", out.Status, out.Error)
+	}
+}
+
+func TestLoadCatalogue(t *testing.T) {
+	heatingPath := "Vehicle.Cabin.Seat.Row1.DriverSide.Heating"
+	jsonCatalogue := `{"Vehicle":{"type":"branch","children":{"Cabin":{"type":"branch","children":{"Seat":{"type":"branch","children":{
+		"Row1":{"type":"branch","children":{"DriverSide":{"type":"branch","children":{"Heating":{"type":"actuator","datatype":"int8","min":-100,"max":100}}}}}}}}}}}}`
+	for fileName, content := range map[string]string{"vss.json": jsonCatalogue, "vss.yaml": testYamlCatalogue, "vss.csv": testCsvCatalogue} {
+		loadTestCatalogue(t, fileName, content)
+		heating := getCatalogue().Lookup(heatingPath)
+		if heating == nil || heating.Type != "actuator" || heating.Datatype != "int8" || *heating.Min != -100 || *heating.Max != 100 {
+			t.Errorf("%s: %s = %+v", fileName, heatingPath, heating)
+		}
+	}
+	loadTestCatalogue(t, "vss.csv", testCsvCatalogue)
+	if node := getCatalogue().Lookup("Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType"); node == nil || len(node.Allowed) != 3 || node.Allowed[2] != "WAVE" {
+		t.Errorf("the allowed values of MassageType are %+v", node)
+	}
+
+	catalogueFile := filepath.Join(t.TempDir(), "vss.csv")
+	os.WriteFile(catalogueFile, []byte("\"Signal\",\"Type\"\n\"Vehicle.Speed\",\"sensor\"\n"), 0644)
+	if err := LoadCatalogue(catalogueFile); err == nil {
+		t.Errorf("a catalogue without the Vehicle branch was loaded")
+	}
+	if err := LoadCatalogue(filepath.Join(t.TempDir(), "vss.vspec")); err == nil {
+		t.Errorf("a missing catalogue file was loaded")
+	}
+}
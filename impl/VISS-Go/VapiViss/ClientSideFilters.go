@@ -0,0 +1,265 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// ****************** Client side filters ***************
+/* SubscribeClientSide subscribes with the curvelog and change filters of VISS applied by the client, for servers that do not support them.
+*  The filters are removed from the filter expression, which is sent as a timebased subscription that samples the values, and the samples
+*  are filtered per path before the callback is called, so that the callback receives only the significant samples regardless of the server.
+*    change    {"variant":"change","parameter":{"logic-op":"ne","diff":"0"}}
+*              A sample is significant if the difference to the last significant value of the path compares to diff by logic-op, one of
+*              eq, ne, gt, gte, lt, and lte. The first sample of a path is significant, and a value that is not a number is significant if it changed.
+*    curvelog  {"variant":"curvelog","parameter":{"maxerr":"0.5","bufsize":"100"}}
+*              The samples of a path are buffered, and when bufsize samples are buffered, the callback receives the samples that are needed to
+*              reconstruct the curve by linear interpolation with an error of at most maxerr. The last sample is kept as the start of the next buffer.
+*              A value that is not a number is received as is.
+*  The period of the sampling is the period of a timebased filter of the expression, else CLIENT_SIDE_PERIOD milliseconds.
+*  A sample that has the timestamp of the previous sample of the path is a repetition of it and is not significant. */
+const CLIENT_SIDE_PERIOD = 1000
+
+type changeFilter struct {
+	logicOp string
+	diff float64
+	last map[string]DataPoint  // the last significant sample by path
+}
+
+type curvelogFilter struct {
+	maxErr float64
+	bufSize int
+	buffers map[string][]curvePoint  // by path
+	isContinued map[string]bool  // the first point of the buffer is the last point of the previous buffer, which is already received
+}
+
+type curvePoint struct {
+	dp DataPoint
+	x float64  // the time in seconds
+	y float64
+}
+
+func SubscribeClientSide(vehicleId VehicleHandle, path string, filter string, stCredentials string, callback func(SubscribeOutput)) SubscribeOutput {
+	serverFilter, sampleFilter, errorData := getClientSideFilters(filter)
+	if errorData != nil {
+		var out SubscribeOutput
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	lastTimestamps := make(map[string]string)
+	return Subscribe(vehicleId, path, serverFilter, stCredentials, func(out SubscribeOutput) {
+		if out.Status == FAILED {
+			callback(out)
+			return
+		}
+		var data []DataContainer
+		for _, dataContainer := range out.Data {
+			if len(dataContainer.Dp) == 0 || dataContainer.Dp[len(dataContainer.Dp)-1].Timestamp == lastTimestamps[dataContainer.Path] {
+				continue
+			}
+			sample := dataContainer.Dp[len(dataContainer.Dp)-1]
+			lastTimestamps[dataContainer.Path] = sample.Timestamp
+			if dataPoints := sampleFilter(dataContainer.Path, sample); len(dataPoints) > 0 {
+				data = append(data, DataContainer{Path: dataContainer.Path, Dp: dataPoints})
+			}
+		}
+		if len(data) > 0 {
+			out.Data = data
+			callback(out)
+		}
+	})
+}
+
+// getClientSideFilters returns the filter expression that is sent to the server, and the function that returns the significant data points of a sample.
+func getClientSideFilters(filter string) (string, func(string, DataPoint) []DataPoint, *ErrorData) {
+	var filterList []map[string]interface{}
+	if filter != "" && json.Unmarshal([]byte(filter), &filterList) != nil {
+		var filterMap map[string]interface{}
+		if json.Unmarshal([]byte(filter), &filterMap) != nil {
+			return "", nil, getErrorObject(400, "invalid_data", "invalid filter")
+		}
+		filterList = append(filterList, filterMap)
+	}
+	var serverList []map[string]interface{}
+	var sampleFilter func(string, DataPoint) []DataPoint
+	isTimebased := false
+	for _, filterMap := range filterList {
+		var errorData *ErrorData
+		switch filterMap["variant"] {
+			case "change", "curvelog":
+				if sampleFilter != nil {
+					return "", nil, getErrorObject(400, "invalid_data", "only one change or curvelog filter can be applied by the client")
+				}
+				parameter, _ := filterMap["parameter"].(map[string]interface{})
+				if filterMap["variant"] == "change" {
+					sampleFilter, errorData = newChangeFilter(parameter)
+				} else {
+					sampleFilter, errorData = newCurvelogFilter(parameter)
+				}
+				if errorData != nil {
+					return "", nil, errorData
+				}
+				continue
+			case "timebased":
+				isTimebased = true
+		}
+		serverList = append(serverList, filterMap)
+	}
+	if sampleFilter == nil {
+		return "", nil, getErrorObject(400, "invalid_data", "the filter has no change or curvelog filter")
+	}
+	if !isTimebased {
+		serverList = append(serverList, map[string]interface{}{"variant": "timebased", "parameter": map[string]interface{}{"period": strconv.Itoa(CLIENT_SIDE_PERIOD)}})
+	}
+	var serverData []byte
+	if len(serverList) == 1 {
+		serverData, _ = json.Marshal(serverList[0])
+	} else {
+		serverData, _ = json.Marshal(serverList)
+	}
+	return string(serverData), sampleFilter, nil
+}
+
+// getFilterNumber returns a number of a filter parameter, which is a string in VISS, but may also be a JSON number.
+func getFilterNumber(parameter map[string]interface{}, name string) (float64, *ErrorData) {
+	value, ok := parameter[name]
+	if !ok {
+		return 0, getErrorObject(400, "invalid_data", "the filter parameter " + name + " is missing")
+	}
+	number, err := strconv.ParseFloat(fmt.Sprint(value), 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, getErrorObject(400, "invalid_data", "the filter parameter " + name + " is not a number")
+	}
+	return number, nil
+}
+
+func newChangeFilter(parameter map[string]interface{}) (func(string, DataPoint) []DataPoint, *ErrorData) {
+	filter := &changeFilter{last: make(map[string]DataPoint)}
+	filter.logicOp, _ = parameter["logic-op"].(string)
+	switch filter.logicOp {
+		case "eq", "ne", "gt", "gte", "lt", "lte":
+		default:
+			return nil, getErrorObject(400, "invalid_data", "invalid logic-op of the change filter: " + filter.logicOp)
+	}
+	var errorData *ErrorData
+	filter.diff, errorData = getFilterNumber(parameter, "diff")
+	if errorData != nil {
+		return nil, errorData
+	}
+	return filter.apply, nil
+}
+
+func (filter *changeFilter) apply(path string, sample DataPoint) []DataPoint {
+	last, ok := filter.last[path]
+	if ok && !filter.isSignificant(last.Value, sample.Value) {
+		return nil
+	}
+	filter.last[path] = sample
+	return []DataPoint{sample}
+}
+
+func (filter *changeFilter) isSignificant(lastValue string, value string) bool {
+	lastNumber, err1 := strconv.ParseFloat(lastValue, 64)
+	number, err2 := strconv.ParseFloat(value, 64)
+	if err1 != nil || err2 != nil {
+		return value != lastValue
+	}
+	difference := number - lastNumber
+	switch filter.logicOp {
+		case "eq":
+			return difference == filter.diff
+		case "ne":
+			return difference != filter.diff
+		case "gt":
+			return difference > filter.diff
+		case "gte":
+			return difference >= filter.diff
+		case "lt":
+			return difference < filter.diff
+		default:
+			return difference <= filter.diff
+	}
+}
+
+func newCurvelogFilter(parameter map[string]interface{}) (func(string, DataPoint) []DataPoint, *ErrorData) {
+	filter := &curvelogFilter{buffers: make(map[string][]curvePoint), isContinued: make(map[string]bool)}
+	var errorData *ErrorData
+	filter.maxErr, errorData = getFilterNumber(parameter, "maxerr")
+	if errorData == nil && filter.maxErr < 0 {
+		errorData = getErrorObject(400, "invalid_data", "the maxerr of the curvelog filter is negative")
+	}
+	if errorData != nil {
+		return nil, errorData
+	}
+	bufSize, errorData := getFilterNumber(parameter, "bufsize")
+	if errorData == nil && (bufSize < 2 || bufSize != math.Trunc(bufSize)) {
+		errorData = getErrorObject(400, "invalid_data", "the bufsize of the curvelog filter must be an integer of at least 2")
+	}
+	if errorData != nil {
+		return nil, errorData
+	}
+	filter.bufSize = int(bufSize)
+	return filter.apply, nil
+}
+
+func (filter *curvelogFilter) apply(path string, sample DataPoint) []DataPoint {
+	y, err := strconv.ParseFloat(sample.Value, 64)
+	if err != nil {
+		return []DataPoint{sample}
+	}
+	sampleTime, err := time.Parse(time.RFC3339Nano, sample.Timestamp)
+	if err != nil {
+		sampleTime = time.Now()
+	}
+	buffer := append(filter.buffers[path], curvePoint{dp: sample, x: float64(sampleTime.UnixNano()) / 1e9, y: y})
+	if len(buffer) < filter.bufSize {
+		filter.buffers[path] = buffer
+		return nil
+	}
+	points := reduceCurve(buffer, filter.maxErr)
+	if filter.isContinued[path] {
+		points = points[1:]
+	}
+	filter.buffers[path] = []curvePoint{buffer[len(buffer)-1]}
+	filter.isContinued[path] = true
+	dataPoints := make([]DataPoint, len(points))
+	for i, point := range points {
+		dataPoints[i] = point.dp
+	}
+	return dataPoints
+}
+
+// reduceCurve returns the points that reconstruct the curve by linear interpolation between them with an error of at most maxErr (Ramer-Douglas-Peucker).
+func reduceCurve(points []curvePoint, maxErr float64) []curvePoint {
+	if len(points) <= 2 {
+		return append([]curvePoint(nil), points...)
+	}
+	first, last := points[0], points[len(points)-1]
+	maxIndex, maxDistance := 0, -1.0
+	for i := 1; i < len(points) - 1; i++ {
+		interpolated := first.y
+		if last.x != first.x {
+			interpolated += (last.y - first.y) * (points[i].x - first.x) / (last.x - first.x)
+		}
+		if distance := math.Abs(points[i].y - interpolated); distance > maxDistance {
+			maxIndex, maxDistance = i, distance
+		}
+	}
+	if maxDistance <= maxErr {
+		return []curvePoint{first, last}
+	}
+	head := reduceCurve(points[:maxIndex+1], maxErr)
+	return append(head[:len(head)-1], reduceCurve(points[maxIndex:], maxErr)...)
+}
@@ -0,0 +1,131 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestGetClientSideFilters(t *testing.T) {
+	tests := []struct {
+		filter string
+		serverFilter string
+	}{
+		{`{"variant":"change","parameter":{"logic-op":"ne","diff":"0"}}`, `{"parameter":{"period":"1000"},"variant":"timebased"}`},
+		{`[{"variant":"paths","parameter":["Latitude"]},{"variant":"curvelog","parameter":{"maxerr":0.5,"bufsize":"10"}},{"variant":"timebased","parameter":{"period":"20"}}]`,
+			`[{"parameter":["Latitude"],"variant":"paths"},{"parameter":{"period":"20"},"variant":"timebased"}]`},
+	}
+	for _, test := range tests {
+		serverFilter, sampleFilter, errorData := getClientSideFilters(test.filter)
+		if errorData != nil || sampleFilter == nil || serverFilter != test.serverFilter {
+			t.Errorf("getClientSideFilters(%s) = %s, %v, want %s", test.filter, serverFilter, errorData, test.serverFilter)
+		}
+	}
+	for _, filter := range []string{"", `{"variant":"timebased","parameter":{"period":"20"}}`, `{"variant":"change","parameter":{"logic-op":"gg","diff":"0"}}`,
+		`{"variant":"change","parameter":{"logic-op":"ne"}}`, `{"variant":"curvelog","parameter":{"maxerr":"-1","bufsize":"10"}}`,
+		`{"variant":"curvelog","parameter":{"maxerr":"1","bufsize":"1"}}`, `{"variant":"curvelog","parameter":{"maxerr":"1","bufsize":"2.5"}}`,
+		`[{"variant":"change","parameter":{"logic-op":"ne","diff":"0"}},{"variant":"curvelog","parameter":{"maxerr":"1","bufsize":"10"}}]`, `{"variant":`} {
+		if _, _, errorData := getClientSideFilters(filter); errorData == nil || errorData.Code != 400 {
+			t.Errorf("getClientSideFilters(%s) did not fail with the error 400", filter)
+		}
+	}
+}
+
+func applySamples(sampleFilter func(string, DataPoint) []DataPoint, values []string) []string {
+	var significant []string
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, value := range values {
+		for _, dataPoint := range sampleFilter("Vehicle.Speed", DataPoint{Value: value, Timestamp: start.Add(time.Duration(i) * time.Second).Format(time.RFC3339)}) {
+			significant = append(significant, dataPoint.Value)
+		}
+	}
+	return significant
+}
+
+func TestChangeFilter(t *testing.T) {
+	tests := []struct {
+		logicOp string
+		diff string
+		values []string
+		significant []string
+	}{
+		{"ne", "0", []string{"1", "1", "2", "2", "1"}, []string{"1", "2", "1"}},
+		{"gt", "5", []string{"0", "3", "6", "10", "20", "0"}, []string{"0", "6", "20"}},
+		{"lte", "-5", []string{"20", "18", "15", "16", "2"}, []string{"20", "15", "2"}},
+		{"gt", "0", []string{"ON", "ON", "OFF"}, []string{"ON", "OFF"}},
+	}
+	for _, test := range tests {
+		sampleFilter, errorData := newChangeFilter(map[string]interface{}{"logic-op": test.logicOp, "diff": test.diff})
+		if errorData != nil {
+			t.Fatalf("newChangeFilter: %v", errorData)
+		}
+		if significant := applySamples(sampleFilter, test.values); fmt.Sprint(significant) != fmt.Sprint(test.significant) {
+			t.Errorf("%s %s of %v = %v, want %v", test.logicOp, test.diff, test.values, significant, test.significant)
+		}
+	}
+}
+
+func TestCurvelogFilter(t *testing.T) {
+	sampleFilter, errorData := newCurvelogFilter(map[string]interface{}{"maxerr": "0.5", "bufsize": "5"})
+	if errorData != nil {
+		t.Fatalf("newCurvelogFilter: %v", errorData)
+	}
+	// a ramp, a peak, and a ramp, where the second buffer starts with the last sample of the first
+	values := []string{"0", "1", "2", "3", "4", "5", "10", "7", "8"}
+	if significant := applySamples(sampleFilter, values); fmt.Sprint(significant) != "[0 4 5 10 7 8]" {
+		t.Errorf("the curvelog of %v = %v, want [0 4 5 10 7 8]", values, significant)
+	}
+	if significant := applySamples(sampleFilter, []string{"n/a"}); fmt.Sprint(significant) != "[n/a]" {
+		t.Errorf("the curvelog of a value that is not a number = %v", significant)
+	}
+
+	points := []curvePoint{{x: 0, y: 0}, {x: 1, y: 1.2}, {x: 2, y: 2}, {x: 3, y: 1.1}, {x: 4, y: 0}}
+	reduced := reduceCurve(points, 0.5)
+	if len(reduced) != 3 || reduced[0].x != 0 || reduced[1].x != 2 || reduced[2].x != 4 {
+		t.Errorf("reduceCurve returned %+v", reduced)
+	}
+	if points[1].y != 1.2 || points[2].y != 2 || points[3].y != 1.1 {
+		t.Errorf("reduceCurve changed its input %+v", points)
+	}
+}
+
+func TestSubscribeClientSide(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	eventChan := make(chan SubscribeOutput, 100)
+	subscribeOut := SubscribeClientSide(vehicleId, "Vehicle.Speed", `[{"variant":"change","parameter":{"logic-op":"ne","diff":"0"}},{"variant":"timebased","parameter":{"period":"20"}}]`,
+		"", func(out SubscribeOutput) { eventChan <- out })
+	if subscribeOut.Status != ONGOING {
+		t.Fatalf("SubscribeClientSide: %v", subscribeOut.Error)
+	}
+	for _, value := range []string{"10", "10", "20"} {
+		time.Sleep(100 * time.Millisecond)
+		server.SetValue("Vehicle.Speed", value)
+	}
+	var values []string
+	waitFor(t, eventChan, func(out SubscribeOutput) bool {
+		if out.ServiceId != subscribeOut.ServiceId || len(out.Data) != 1 || len(out.Data[0].Dp) != 1 {
+			t.Errorf("unexpected event %+v", out)
+			return true
+		}
+		values = append(values, out.Data[0].Dp[0].Value)
+		return out.Data[0].Dp[0].Value == "20"
+	})
+	if fmt.Sprint(values) != "[0 10 20]" {
+		t.Errorf("the callback received %v, want [0 10 20]", values)
+	}
+	if out := Unsubscribe(vehicleId, subscribeOut.ServiceId); out.Status != SUCCESSFUL || server.Subscriptions() != 0 {
+		t.Errorf("Unsubscribe: status=%d, server subscriptions=%d", out.Status, server.Subscriptions())
+	}
+
+	if out := SubscribeClientSide(vehicleId, "Vehicle.Speed", `{"variant":"timebased","parameter":{"period":"20"}}`, "", func(SubscribeOutput) {}); out.Status != FAILED {
+		t.Errorf("SubscribeClientSide without a change or curvelog filter: status=%d", out.Status)
+	}
+}
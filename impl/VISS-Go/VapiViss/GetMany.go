@@ -0,0 +1,198 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// ****************** Multi-path get ***************
+/* GetMany reads several paths in one call, where a path may contain wildcard segments, e.g. Vehicle.Cabin.Seat.*.*.Position.
+*  A path is split at its first wildcard segment into the branch and a relative path, and the paths of a branch are read by one Get
+*  with a paths filter, so that the wildcards are expanded by the server as specified by VISS. A path without wildcards is read by a Get of the path,
+*  which returns all leaves below it if it is a branch. The data points of a path from all the Gets are merged into the Data map, keyed by path. */
+type GetManyOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Data map[string][]DataPoint
+}
+
+// DataNode is a node of the tree of a GetManyOutput, where the leaves have the data points.
+type DataNode struct {
+	Name string
+	Path string
+	Dp []DataPoint
+	Children []*DataNode  // ordered by name
+}
+
+type getManyRequest struct {
+	path string
+	relativePaths []string  // of a paths filter, none if all leaves of path are read
+}
+
+func GetMany(vehicleId VehicleHandle, paths []string, filter string, stCredentials string) GetManyOutput {
+	var out GetManyOutput
+	if getVehicleConnection(vehicleId) == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
+	if len(paths) == 0 {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "missing paths")
+		return out
+	}
+	if len(getFilterPaths(filter)) > 0 {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "a paths filter can not be combined with the paths")
+		return out
+	}
+	requests, errorData := getManyRequests(paths)
+	if errorData != nil {
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	out.Data = make(map[string][]DataPoint)
+	for _, request := range requests {
+		requestFilter := filter
+		if len(request.relativePaths) > 0 {
+			requestFilter, errorData = addPathsFilter(filter, request.relativePaths)
+			if errorData != nil {
+				out.Status = FAILED
+				out.Error = errorData
+				out.Data = nil
+				return out
+			}
+		}
+		getOut := Get(vehicleId, request.path, requestFilter, stCredentials)
+		if getOut.Status == FAILED {
+			out.Status = FAILED
+			out.Error = getOut.Error
+			out.Data = nil
+			return out
+		}
+		for _, dataContainer := range getOut.Data {
+			out.Data[dataContainer.Path] = mergeDataPoints(out.Data[dataContainer.Path], dataContainer.Dp)
+		}
+	}
+	out.Status = SUCCESSFUL
+	return out
+}
+
+// getManyRequests groups the paths by the branch before their first wildcard segment, a Get of the branch itself reads all the paths below it.
+func getManyRequests(paths []string) ([]getManyRequest, *ErrorData) {
+	var requests []getManyRequest
+	requestIndex := make(map[string]int)
+	for _, path := range paths {
+		branchPath, relativePath := path, ""
+		segments := strings.Split(path, ".")
+		for i, segment := range segments {
+			if strings.Contains(segment, "*") {
+				if i == 0 {
+					return nil, getErrorObject(400, "invalid_data", "the root of the path " + path + " can not be a wildcard")
+				}
+				branchPath, relativePath = strings.Join(segments[:i], "."), strings.Join(segments[i:], ".")
+				break
+			}
+		}
+		index, ok := requestIndex[branchPath]
+		if !ok {
+			requestIndex[branchPath] = len(requests)
+			requests = append(requests, getManyRequest{path: branchPath, relativePaths: []string{relativePath}})
+			continue
+		}
+		requests[index].relativePaths = append(requests[index].relativePaths, relativePath)
+	}
+	for i := range requests {
+		for _, relativePath := range requests[i].relativePaths {
+			if relativePath == "" {
+				requests[i].relativePaths = nil
+				break
+			}
+		}
+	}
+	return requests, nil
+}
+
+// addPathsFilter returns the filter expression with a paths filter of the relative paths added, as an array of filters if there was a filter.
+func addPathsFilter(filter string, relativePaths []string) (string, *ErrorData) {
+	pathsFilter := map[string]interface{}{"variant": "paths", "parameter": relativePaths}
+	if filter == "" {
+		filterData, _ := json.Marshal(pathsFilter)
+		return string(filterData), nil
+	}
+	var filterExpression interface{}
+	if json.Unmarshal([]byte(filter), &filterExpression) != nil {
+		return "", getErrorObject(400, "invalid_data", "invalid filter")
+	}
+	filterList, ok := filterExpression.([]interface{})
+	if !ok {
+		filterList = []interface{}{filterExpression}
+	}
+	filterData, _ := json.Marshal(append(filterList, pathsFilter))
+	return string(filterData), nil
+}
+
+// mergeDataPoints adds the data points that are not already in the list, and orders the list by timestamp.
+func mergeDataPoints(dataPoints []DataPoint, newDataPoints []DataPoint) []DataPoint {
+	for _, dataPoint := range newDataPoints {
+		isNew := true
+		for _, existing := range dataPoints {
+			if existing == dataPoint {
+				isNew = false
+				break
+			}
+		}
+		if isNew {
+			dataPoints = append(dataPoints, dataPoint)
+		}
+	}
+	sort.SliceStable(dataPoints, func(i, j int) bool { return dataPoints[i].Timestamp < dataPoints[j].Timestamp })
+	return dataPoints
+}
+
+// Paths returns the paths of the data in order.
+func (out GetManyOutput) Paths() []string {
+	paths := make([]string, 0, len(out.Data))
+	for path := range out.Data {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Tree returns the data as a tree of the branches of the paths, the roots are the top branches, e.g. Vehicle.
+func (out GetManyOutput) Tree() []*DataNode {
+	var roots []*DataNode
+	for _, path := range out.Paths() {
+		nodes := &roots
+		segments := strings.Split(path, ".")
+		for i, name := range segments {
+			var node *DataNode
+			for _, child := range *nodes {
+				if child.Name == name {
+					node = child
+					break
+				}
+			}
+			if node == nil {
+				node = &DataNode{Name: name, Path: strings.Join(segments[:i+1], ".")}
+				*nodes = append(*nodes, node)
+			}
+			if i == len(segments) - 1 {
+				node.Dp = out.Data[path]
+			}
+			nodes = &node.Children
+		}
+	}
+	return roots
+}
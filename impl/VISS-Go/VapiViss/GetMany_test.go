@@ -0,0 +1,90 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetMany(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	server.SetValue("Vehicle.Cabin.Seat.Row1.PassengerSide.Position", "300")
+	out := GetMany(vehicleId, []string{"Vehicle.Cabin.Seat.*.*.Position", "Vehicle.Speed", "Vehicle.CurrentLocation", "Vehicle.Cabin.Seat.Row1.*.Height"}, "", "")
+	if out.Status != SUCCESSFUL {
+		t.Fatalf("GetMany: status=%d, error=%v", out.Status, out.Error)
+	}
+	want := "Vehicle.Cabin.Seat.Row1.DriverSide.Height Vehicle.Cabin.Seat.Row1.DriverSide.Position Vehicle.Cabin.Seat.Row1.PassengerSide.Height " +
+		"Vehicle.Cabin.Seat.Row1.PassengerSide.Position Vehicle.CurrentLocation.Latitude Vehicle.CurrentLocation.Longitude Vehicle.Speed"
+	if paths := strings.Join(out.Paths(), " "); paths != want {
+		t.Errorf("GetMany paths = %s", paths)
+	}
+	if dp := out.Data["Vehicle.Cabin.Seat.Row1.PassengerSide.Position"]; len(dp) != 1 || dp[0].Value != "300" {
+		t.Errorf("PassengerSide.Position = %v", dp)
+	}
+
+	roots := out.Tree()
+	if len(roots) != 1 || roots[0].Path != "Vehicle" || len(roots[0].Children) != 3 {
+		t.Fatalf("Tree() = %+v", roots)
+	}
+	row := roots[0].Children[0].Children[0].Children[0]
+	if row.Path != "Vehicle.Cabin.Seat.Row1" || len(row.Children) != 2 || len(row.Children[1].Children) != 2 {
+		t.Errorf("the row node of the tree is %+v", row)
+	}
+	if leaf := row.Children[1].Children[1]; leaf.Path != "Vehicle.Cabin.Seat.Row1.PassengerSide.Position" || len(leaf.Dp) != 1 || leaf.Dp[0].Value != "300" {
+		t.Errorf("the PassengerSide.Position leaf of the tree is %+v", leaf)
+	}
+
+	for _, test := range []struct {
+		paths []string
+		filter string
+		code int32
+	}{
+		{nil, "", 400},
+		{[]string{"*.Speed"}, "", 400},
+		{[]string{"Vehicle.Speed"}, `{"variant":"paths","parameter":"Speed"}`, 400},
+		{[]string{"Vehicle.Speed", "Vehicle.Cabin.*.Width"}, "", 404},
+	} {
+		out := GetMany(vehicleId, test.paths, test.filter, "")
+		if out.Status != FAILED || out.Error == nil || out.Error.Code != test.code || out.Data != nil {
+			t.Errorf("GetMany(%v, %s): status=%d, error=%v, want the error %d", test.paths, test.filter, out.Status, out.Error, test.code)
+		}
+	}
+}
+
+func TestGetManyRequests(t *testing.T) {
+	requests, errorData := getManyRequests([]string{"Vehicle.Cabin.Seat.*.*.Position", "Vehicle.Speed", "Vehicle.Cabin.Seat.Row1.*", "Vehicle.Cabin.Seat.*.*.Height",
+		"Vehicle.Body.*.Width", "Vehicle.Body"})
+	if errorData != nil || len(requests) != 4 {
+		t.Fatalf("getManyRequests: %+v, error=%v", requests, errorData)
+	}
+	if requests[0].path != "Vehicle.Cabin.Seat" || strings.Join(requests[0].relativePaths, " ") != "*.*.Position *.*.Height" {
+		t.Errorf("the first request is %+v", requests[0])
+	}
+	if requests[3].path != "Vehicle.Body" || requests[3].relativePaths != nil {
+		t.Errorf("the request of Vehicle.Body is %+v, want all its leaves", requests[3])
+	}
+
+	for _, test := range []struct {
+		filter string
+		expected string
+	}{
+		{"", `{"parameter":["*.Position"],"variant":"paths"}`},
+		{`{"variant":"history","parameter":"PT1H"}`, `[{"parameter":"PT1H","variant":"history"},{"parameter":["*.Position"],"variant":"paths"}]`},
+		{`[{"variant":"history","parameter":"PT1H"}]`, `[{"parameter":"PT1H","variant":"history"},{"parameter":["*.Position"],"variant":"paths"}]`},
+	} {
+		filter, errorData := addPathsFilter(test.filter, []string{"*.Position"})
+		if errorData != nil || filter != test.expected {
+			t.Errorf("addPathsFilter(%s) = %s, error=%v, want %s", test.filter, filter, errorData, test.expected)
+		}
+	}
+	if _, errorData := addPathsFilter("{", []string{"*"}); errorData == nil || errorData.Code != 400 {
+		t.Errorf("an invalid filter was accepted")
+	}
+}
@@ -0,0 +1,194 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ****************** Historical data ***************
+/* GetHistory reads the data points of a path that the server has recorded within a period back from now, by a Get with the VISS history filter.
+*  GetHistoryWindow reads the data points within a time window, where the period of the filter is from the start of the window until now,
+*  and the data points after the end of the window are removed by the client. The data points of each path are returned as a series,
+*  ordered by time, where the timestamp is parsed, and the value is also parsed as a number if it is numeric.
+*  The series can be resampled to a fixed interval, and aggregated to the min, max, and average of its numeric values. */
+type HistoryPoint struct {
+	Time time.Time
+	Value string
+	Number float64  // the value as a number, if IsNumber
+	IsNumber bool
+}
+
+type HistorySeries struct {
+	Path string
+	Points []HistoryPoint  // ordered by time
+}
+
+type HistoryOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Series []HistorySeries  // ordered by path
+}
+
+type HistoryAggregate struct {
+	Start time.Time  // the start of the interval, or of the series
+	Count int  // of the numeric values
+	Min float64
+	Max float64
+	Avg float64
+}
+
+func GetHistory(vehicleId VehicleHandle, path string, period time.Duration, stCredentials string) HistoryOutput {
+	now := time.Now()
+	return GetHistoryWindow(vehicleId, path, now.Add(-period), now, stCredentials)
+}
+
+func GetHistoryWindow(vehicleId VehicleHandle, path string, from time.Time, to time.Time, stCredentials string) HistoryOutput {
+	var out HistoryOutput
+	if !from.Before(to) {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "the start of the window must be before its end")
+		return out
+	}
+	period := time.Since(from)
+	if period <= 0 {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "the window must start in the past")
+		return out
+	}
+	filter := `{"variant":"history","parameter":"` + formatIsoDuration(period) + `"}`
+	getOut := Get(vehicleId, path, filter, stCredentials)
+	if getOut.Status == FAILED {
+		out.Status = FAILED
+		out.Error = getOut.Error
+		return out
+	}
+	for _, dataContainer := range getOut.Data {
+		series := HistorySeries{Path: dataContainer.Path}
+		for _, dataPoint := range dataContainer.Dp {
+			point, err := parseHistoryPoint(dataPoint)
+			if err != nil {
+				out.Status = FAILED
+				out.Error = getErrorObject(502, "bad_gateway", "invalid data point of " + dataContainer.Path + ": " + err.Error())
+				out.Series = nil
+				return out
+			}
+			if point.Time.Before(from) || point.Time.After(to) {
+				continue
+			}
+			series.Points = append(series.Points, point)
+		}
+		sort.SliceStable(series.Points, func(i, j int) bool { return series.Points[i].Time.Before(series.Points[j].Time) })
+		out.Series = append(out.Series, series)
+	}
+	sort.Slice(out.Series, func(i, j int) bool { return out.Series[i].Path < out.Series[j].Path })
+	out.Status = SUCCESSFUL
+	return out
+}
+
+func parseHistoryPoint(dataPoint DataPoint) (HistoryPoint, error) {
+	var point HistoryPoint
+	var err error
+	point.Time, err = time.Parse(time.RFC3339Nano, dataPoint.Timestamp)
+	if err != nil {
+		return point, err
+	}
+	point.Value = dataPoint.Value
+	number, err := strconv.ParseFloat(dataPoint.Value, 64)
+	if err == nil && !math.IsNaN(number) {
+		point.Number = number
+		point.IsNumber = true
+	}
+	return point, nil
+}
+
+// formatIsoDuration returns the period as an ISO 8601 duration in whole seconds, rounded up so that the period covers the window, e.g. P1DT2H3M4S.
+func formatIsoDuration(period time.Duration) string {
+	seconds := int64(math.Ceil(period.Seconds()))
+	days, seconds := seconds / 86400, seconds % 86400
+	isoDuration := "P"
+	if days > 0 {
+		isoDuration += fmt.Sprintf("%dD", days)
+	}
+	if seconds > 0 || days == 0 {
+		isoDuration += fmt.Sprintf("T%dH%dM%dS", seconds / 3600, seconds % 3600 / 60, seconds % 60)
+	}
+	return isoDuration
+}
+
+/* Resample returns the series with one point per interval, from the time of the first point to the time of the last point,
+*  where the point at a time has the value of the latest point at or before that time. */
+func (series HistorySeries) Resample(interval time.Duration) HistorySeries {
+	resampled := HistorySeries{Path: series.Path}
+	if len(series.Points) == 0 || interval <= 0 {
+		return resampled
+	}
+	last := series.Points[len(series.Points)-1].Time
+	index := 0
+	for sampleTime := series.Points[0].Time; !sampleTime.After(last); sampleTime = sampleTime.Add(interval) {
+		for index + 1 < len(series.Points) && !series.Points[index+1].Time.After(sampleTime) {
+			index++
+		}
+		point := series.Points[index]
+		point.Time = sampleTime
+		resampled.Points = append(resampled.Points, point)
+	}
+	return resampled
+}
+
+// Aggregate returns the min, max, and average of the numeric values of the series, the count is zero if it has none.
+func (series HistorySeries) Aggregate() HistoryAggregate {
+	var aggregate HistoryAggregate
+	if len(series.Points) > 0 {
+		aggregate.Start = series.Points[0].Time
+	}
+	sum := 0.0
+	for _, point := range series.Points {
+		if !point.IsNumber {
+			continue
+		}
+		if aggregate.Count == 0 || point.Number < aggregate.Min {
+			aggregate.Min = point.Number
+		}
+		if aggregate.Count == 0 || point.Number > aggregate.Max {
+			aggregate.Max = point.Number
+		}
+		sum += point.Number
+		aggregate.Count++
+	}
+	if aggregate.Count > 0 {
+		aggregate.Avg = sum / float64(aggregate.Count)
+	}
+	return aggregate
+}
+
+// AggregateBy returns the aggregates of the consecutive intervals from the time of the first point, an interval without points is left out.
+func (series HistorySeries) AggregateBy(interval time.Duration) []HistoryAggregate {
+	var aggregates []HistoryAggregate
+	if len(series.Points) == 0 || interval <= 0 {
+		return aggregates
+	}
+	first := series.Points[0].Time
+	for start := 0; start < len(series.Points); {
+		intervalStart := first.Add(series.Points[start].Time.Sub(first) / interval * interval)
+		end := start
+		for end < len(series.Points) && series.Points[end].Time.Before(intervalStart.Add(interval)) {
+			end++
+		}
+		aggregate := HistorySeries{Points: series.Points[start:end]}.Aggregate()
+		aggregate.Start = intervalStart
+		aggregates = append(aggregates, aggregate)
+		start = end
+	}
+	return aggregates
+}
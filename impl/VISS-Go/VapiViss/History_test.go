@@ -0,0 +1,97 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetHistory(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	for _, value := range []string{"10", "20", "30"} {
+		time.Sleep(5 * time.Millisecond)
+		server.SetValue("Vehicle.Speed", value)
+	}
+	out := GetHistory(vehicleId, "Vehicle.Speed", time.Minute, "")
+	if out.Status != SUCCESSFUL || len(out.Series) != 1 || len(out.Series[0].Points) != 4 {
+		t.Fatalf("GetHistory: status=%d, series=%+v, error=%v", out.Status, out.Series, out.Error)
+	}
+	points := out.Series[0].Points
+	for i, expected := range []float64{0, 10, 20, 30} {
+		if !points[i].IsNumber || points[i].Number != expected {
+			t.Errorf("point %d is %+v, want %v", i, points[i], expected)
+		}
+	}
+
+	out = GetHistoryWindow(vehicleId, "Vehicle.Speed", points[1].Time, points[2].Time, "")
+	if out.Status != SUCCESSFUL || len(out.Series[0].Points) != 2 || out.Series[0].Points[0].Value != "10" || out.Series[0].Points[1].Value != "20" {
+		t.Errorf("GetHistoryWindow: status=%d, series=%+v", out.Status, out.Series)
+	}
+
+	out = GetHistory(vehicleId, "Vehicle.Cabin.Seat.Row1.*.Position", time.Minute, "")
+	if out.Status != SUCCESSFUL || len(out.Series) != 2 || out.Series[0].Path != "Vehicle.Cabin.Seat.Row1.DriverSide.Position" {
+		t.Errorf("GetHistory of a wildcard path: status=%d, series=%+v", out.Status, out.Series)
+	}
+
+	now := time.Now()
+	for _, window := range [][2]time.Time{{now, now.Add(-time.Second)}, {now.Add(time.Minute), now.Add(2 * time.Minute)}} {
+		if out := GetHistoryWindow(vehicleId, "Vehicle.Speed", window[0], window[1], ""); out.Status != FAILED || out.Error.Code != 400 {
+			t.Errorf("GetHistoryWindow(%v): status=%d, want the error 400", window, out.Status)
+		}
+	}
+	if out := GetHistory(vehicleId, "Vehicle.Unknown", time.Minute, ""); out.Status != FAILED || out.Error.Code != 404 {
+		t.Errorf("GetHistory of an unknown path: status=%d", out.Status)
+	}
+}
+
+func TestFormatIsoDuration(t *testing.T) {
+	tests := map[time.Duration]string{90 * time.Second: "PT0H1M30S", 500 * time.Millisecond: "PT0H0M1S", 26 * time.Hour: "P1DT2H0M0S", 48 * time.Hour: "P2D"}
+	for period, expected := range tests {
+		if isoDuration := formatIsoDuration(period); isoDuration != expected {
+			t.Errorf("formatIsoDuration(%v) = %s, want %s", period, isoDuration, expected)
+		}
+	}
+}
+
+func TestHistorySeries(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	point := func(seconds int, value string, number float64, isNumber bool) HistoryPoint {
+		return HistoryPoint{Time: start.Add(time.Duration(seconds) * time.Second), Value: value, Number: number, IsNumber: isNumber}
+	}
+	series := HistorySeries{Path: "Vehicle.Speed", Points: []HistoryPoint{point(0, "10", 10, true), point(3, "40", 40, true), point(4, "n/a", 0, false),
+		point(7, "20", 20, true)}}
+
+	resampled := series.Resample(2 * time.Second)
+	expected := []string{"10", "10", "n/a", "n/a"}
+	if len(resampled.Points) != len(expected) {
+		t.Fatalf("Resample returned %d points, want %d", len(resampled.Points), len(expected))
+	}
+	for i, point := range resampled.Points {
+		if point.Value != expected[i] || !point.Time.Equal(start.Add(time.Duration(2*i) * time.Second)) {
+			t.Errorf("resampled point %d is %s at %v, want %s", i, point.Value, point.Time, expected[i])
+		}
+	}
+
+	aggregate := series.Aggregate()
+	if aggregate.Count != 3 || aggregate.Min != 10 || aggregate.Max != 40 || aggregate.Avg != 70.0 / 3 || !aggregate.Start.Equal(start) {
+		t.Errorf("Aggregate returned %+v", aggregate)
+	}
+	aggregates := series.AggregateBy(5 * time.Second)
+	if len(aggregates) != 2 || aggregates[0].Count != 2 || aggregates[0].Avg != 25 || aggregates[1].Count != 1 || aggregates[1].Max != 20 ||
+		!aggregates[1].Start.Equal(start.Add(5 * time.Second)) {
+		t.Errorf("AggregateBy returned %+v", aggregates)
+	}
+	if aggregates := series.AggregateBy(time.Second); len(aggregates) != 4 {
+		t.Errorf("AggregateBy returned %d aggregates, want 4 as an interval without points is left out", len(aggregates))
+	}
+	if aggregate := (HistorySeries{}).Aggregate(); aggregate.Count != 0 {
+		t.Errorf("Aggregate of an empty series returned %+v", aggregate)
+	}
+}
@@ -0,0 +1,151 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"VISS-Go/vapi"
+)
+
+// ****************** Service preconditions ***************
+/* A precondition is a condition on a vehicle signal that must hold for an actuating service to be executed.
+*  The preconditions of a service are evaluated by Get before the service is started, and are monitored by subscriptions during its execution.
+*  A violation terminates the service with the status FAILED. Paths may contain RowX and ColumnY, which are replaced by the seat of the service. */
+type Precondition struct {
+	Path string
+	Operator string  // one of the VISS logic-op values eq, ne, gt, gte, lt, lte
+	Value string
+	Description string
+}
+
+var preconditionMutex sync.Mutex
+var servicePreconditions = map[string][]Precondition{
+	"MoveSeat": {{"Vehicle.Speed", "eq", "0", "Seat movement requires a stationary vehicle"}},
+	"ConfigureSeat": {{"Vehicle.Speed", "eq", "0", "Seat movement requires a stationary vehicle"}},
+	"ActivateMassage": {{"Vehicle.Cabin.Seat.RowX.ColumnY.IsOccupied", "eq", "true", "Massage requires an occupied seat"}},
+	"ActivateSeatHeating": {{"Vehicle.Cabin.Seat.RowX.ColumnY.IsOccupied", "eq", "true", "Seat heating requires an occupied seat"}},
+	"ActivateSeatVentilation": {{"Vehicle.Cabin.Seat.RowX.ColumnY.IsOccupied", "eq", "true", "Seat ventilation requires an occupied seat"}},
+}
+
+// SetPreconditions replaces the preconditions of the named service. An empty list disables the precondition checks for the service.
+func SetPreconditions(serviceName string, preconditions []Precondition) {
+	preconditionMutex.Lock()
+	servicePreconditions[serviceName] = preconditions
+	preconditionMutex.Unlock()
+}
+
+func GetPreconditions(serviceName string) []Precondition {
+	preconditionMutex.Lock()
+	defer preconditionMutex.Unlock()
+	return append([]Precondition(nil), servicePreconditions[serviceName]...)
+}
+
+func getSeatPreconditions(serviceName string, seatId MatrixId) []Precondition {
+	preconditions := GetPreconditions(serviceName)
+	for i := 0; i < len(preconditions); i++ {
+		if strings.Contains(preconditions[i].Path, ".RowX.") && strings.Contains(preconditions[i].Path, ".ColumnY.") {
+			preconditions[i].Path = getSeatPositionedPath(preconditions[i].Path, seatId)
+		}
+	}
+	return preconditions
+}
+
+// CheckPreconditions reads the paths of the preconditions by the backend, and returns the first one that does not hold as an error, or nil if all hold.
+func CheckPreconditions(backend vapi.Data, vehicleId VehicleHandle, preconditions []Precondition, stCredentials string) *ErrorData {
+	for i := 0; i < len(preconditions); i++ {
+		getOut := backend.Get(vehicleId, preconditions[i].Path, "", stCredentials)
+		if getOut.Status == FAILED {
+			return getOut.Error
+		}
+		errorData := evaluatePrecondition(preconditions[i], getOut.Data)
+		if errorData != nil {
+			return errorData
+		}
+	}
+	return nil
+}
+
+func checkPreconditions(vehicleId VehicleHandle, preconditions []Precondition, stCredentials string) *ErrorData {
+	return CheckPreconditions(Backend{}, vehicleId, preconditions, stCredentials)
+}
+
+func evaluatePrecondition(precondition Precondition, data []DataContainer) *ErrorData {
+	if len(data) == 0 || len(data[0].Dp) == 0 {
+		return getPreconditionError(precondition, "no value available")
+	}
+	value := data[0].Dp[0].Value
+	if !comparePreconditionValue(value, precondition.Operator, precondition.Value) {
+		return getPreconditionError(precondition, "value=" + value)
+	}
+	return nil
+}
+
+func comparePreconditionValue(value string, operator string, reference string) bool {
+	floatValue, err1 := strconv.ParseFloat(value, 64)
+	floatReference, err2 := strconv.ParseFloat(reference, 64)
+	if err1 != nil || err2 != nil {
+		switch operator {
+			case "eq": return value == reference
+			case "ne": return value != reference
+		}
+		return false // not comparable
+	}
+	switch operator {
+		case "eq": return floatValue == floatReference
+		case "ne": return floatValue != floatReference
+		case "gt": return floatValue > floatReference
+		case "gte": return floatValue >= floatReference
+		case "lt": return floatValue < floatReference
+		case "lte": return floatValue <= floatReference
+	}
+	return false
+}
+
+func getPreconditionError(precondition Precondition, detail string) *ErrorData {
+	return getErrorObject(412, "precondition_failed", precondition.Description + " (" + precondition.Path + " " + precondition.Operator + " " + precondition.Value + ", " + detail + ")")
+}
+
+/* startPreconditionMonitor subscribes to the precondition paths, and sends the first detected violation on violationChan.
+*  The returned service ids must be released by stopPreconditionMonitor when the monitored service terminates. */
+func startPreconditionMonitor(vehicleId VehicleHandle, preconditions []Precondition, stCredentials string, violationChan chan *ErrorData) ([]uint32, *ErrorData) {
+	var serviceIds []uint32
+	filter := `{"variant":"timebased","parameter":{"period":"1000"}}`
+	for i := 0; i < len(preconditions); i++ {
+		precondition := preconditions[i]
+		subscribeOut := Subscribe(vehicleId, precondition.Path, filter, stCredentials, func(subscribeOut SubscribeOutput) {
+			var errorData *ErrorData
+			if subscribeOut.Status == FAILED {
+				errorData = getPreconditionError(precondition, "monitoring failed")
+			} else {
+				errorData = evaluatePrecondition(precondition, subscribeOut.Data)
+			}
+			if errorData != nil {
+				select {
+					case violationChan <- errorData:
+					default: // a violation is already pending
+				}
+			}
+		})
+		if subscribeOut.Status == FAILED {
+			stopPreconditionMonitor(vehicleId, serviceIds)
+			return nil, subscribeOut.Error
+		}
+		serviceIds = append(serviceIds, subscribeOut.ServiceId)
+	}
+	return serviceIds, nil
+}
+
+func stopPreconditionMonitor(vehicleId VehicleHandle, serviceIds []uint32) {
+	for i := 0; i < len(serviceIds); i++ {
+		CancelService(vehicleId, serviceIds[i])
+	}
+}
@@ -0,0 +1,131 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComparePreconditionValue(t *testing.T) {
+	tests := []struct {
+		value string
+		operator string
+		reference string
+		want bool
+	}{
+		{"0", "eq", "0", true},
+		{"0.0", "eq", "0", true},
+		{"12", "eq", "0", false},
+		{"12", "ne", "0", true},
+		{"12", "gt", "10", true},
+		{"10", "gte", "10", true},
+		{"9.5", "lt", "10", true},
+		{"10", "lte", "9", false},
+		{"true", "eq", "true", true},
+		{"false", "ne", "true", true},
+		{"true", "gt", "false", false},
+		{"1", "unknown", "1", false},
+	}
+	for _, test := range tests {
+		if got := comparePreconditionValue(test.value, test.operator, test.reference); got != test.want {
+			t.Errorf("comparePreconditionValue(%s %s %s) = %t, want %t", test.value, test.operator, test.reference, got, test.want)
+		}
+	}
+}
+
+func TestGetSeatPreconditions(t *testing.T) {
+	preconditions := getSeatPreconditions("ActivateMassage", MatrixId{RowName: "Row1", ColumnName: "PassengerSide"})
+	if len(preconditions) != 1 || preconditions[0].Path != "Vehicle.Cabin.Seat.Row1.PassengerSide.IsOccupied" {
+		t.Errorf("getSeatPreconditions = %+v", preconditions)
+	}
+	if GetPreconditions("ActivateMassage")[0].Path != "Vehicle.Cabin.Seat.RowX.ColumnY.IsOccupied" {
+		t.Errorf("getSeatPreconditions modified the registered preconditions")
+	}
+}
+
+func TestPreconditionsBeforeExecution(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	seatId := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	server.SetValue("Vehicle.Speed", "30")
+	server.SetValue("Vehicle.Cabin.Seat.Row1.DriverSide.IsOccupied", "false")
+	moveSeatOut := MoveSeat(vehicleId, seatId, LONGITUDINAL, 10, "", nil)
+	configureSeatOut := ConfigureSeat(vehicleId, seatId, []SeatConfig{{MovementType: LONGITUDINAL, Position: 10}}, "", nil)
+	massageOut := ActivateMassage(vehicleId, seatId, ROLL, 10, 1, "", nil)
+	heatingOut := ActivateSeatHeating(vehicleId, seatId, 10, 1, "", nil)
+	ventilationOut := ActivateSeatVentilation(vehicleId, seatId, 10, 1, "", nil)
+	for name, err := range map[string]*ErrorData{"MoveSeat": moveSeatOut.Error, "ConfigureSeat": configureSeatOut.Error, "ActivateMassage": massageOut.Error,
+			"ActivateSeatHeating": heatingOut.Error, "ActivateSeatVentilation": ventilationOut.Error} {
+		if err == nil || err.Code != 412 || err.Reason != "precondition_failed" {
+			t.Errorf("%s: error = %v, want precondition_failed", name, err)
+		}
+	}
+	if !strings.Contains(moveSeatOut.Error.Description, "value=30") {
+		t.Errorf("description does not tell the violating value: %s", moveSeatOut.Error.Description)
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Position"); value != "0" {
+		t.Errorf("seat moved despite violated precondition")
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Heating"); value != "0" {
+		t.Errorf("seat heated despite violated precondition")
+	}
+
+	SetPreconditions("MoveSeat", nil)
+	defer SetPreconditions("MoveSeat", []Precondition{{"Vehicle.Speed", "eq", "0", "Seat movement requires a stationary vehicle"}})
+	moveSeatOut = MoveSeat(vehicleId, seatId, LONGITUDINAL, 10, "", nil)
+	if moveSeatOut.Status != ONGOING {
+		t.Errorf("MoveSeat without preconditions: status=%d, error=%v", moveSeatOut.Status, moveSeatOut.Error)
+	} else {
+		CancelService(vehicleId, moveSeatOut.ServiceId)
+	}
+}
+
+func TestPreconditionViolatedDuringExecution(t *testing.T) {
+	signals := fastSignals()
+	for i := 0; i < len(signals); i++ {
+		if strings.HasSuffix(signals[i].Path, "DriverSide.Position") {
+			signals[i].Rate = 10
+		}
+	}
+	server, vehicleId := startMockVehicle(t, signals)
+	eventChan := make(chan MoveSeatOutput, 10)
+	moveSeatOut := MoveSeat(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, LONGITUDINAL, BACKWARD, "", func(out MoveSeatOutput) {
+		eventChan <- out
+	})
+	if moveSeatOut.Status != ONGOING {
+		t.Fatalf("MoveSeat: status=%d, error=%v", moveSeatOut.Status, moveSeatOut.Error)
+	}
+	server.SetValue("Vehicle.Speed", "5")
+	event := waitFor(t, eventChan, func(out MoveSeatOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || event.Error.Code != 412 {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Position"); value == "300" {
+		t.Errorf("seat movement not stopped at violation")
+	}
+}
+
+func TestSeatClimatePreconditionViolated(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	eventChan := make(chan SeatClimateOutput, 10)
+	heatingOut := ActivateSeatHeating(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, 50, 3600, "", func(out SeatClimateOutput) {
+		eventChan <- out
+	})
+	if heatingOut.Status != ONGOING {
+		t.Fatalf("ActivateSeatHeating: status=%d, error=%v", heatingOut.Status, heatingOut.Error)
+	}
+	server.SetValue("Vehicle.Cabin.Seat.Row1.DriverSide.IsOccupied", "false")
+	event := waitFor(t, eventChan, func(out SeatClimateOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || event.Error.Code != 412 {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Heating"); value != "0" {
+		t.Errorf("heating = %s after violation, want 0", value)
+	}
+}
@@ -0,0 +1,283 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ****************** Session recording and replay ***************
+/* A recording is a JSON-lines file with one entry per VISS message that is sent or received on a vehicle connection:
+*  {"ts":"2025-01-01T12:00:00.000000001Z", "direction":"client"|"server", "protocol":"VISSv3.0-ws", "message":"{...}"}
+*  A recording is replayed by a vehicle registered with RegisterReplayVehicle, which is connected with the protocol REPLAY_PROTOCOL.
+*  The replay expects the client messages in the recorded order. The server messages that follow a matching client message
+*  are played back with the recorded timing, with the recorded request ids replaced by the live ones.
+*  A client message that does not match the next recorded client message is answered with an error. */
+const REPLAY_PROTOCOL = "VISSv3.0-replay"
+
+type recordingEntry struct {
+	Ts string `json:"ts"`
+	Direction string `json:"direction"`
+	Protocol string `json:"protocol"`
+	Message string `json:"message"`
+}
+
+type sessionRecorder struct {
+	mutex sync.Mutex
+	file *os.File
+	encoder *json.Encoder
+}
+
+var recorderMutex sync.Mutex
+
+func StartRecording(vehicleId VehicleHandle, fileName string) GeneralOutput {
+	var out GeneralOutput
+	vehConn := getVehicleConnection(vehicleId)
+	if vehConn == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "unknown vehicle")
+		return out
+	}
+	recorderMutex.Lock()
+	defer recorderMutex.Unlock()
+	if vehConn.recorder != nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Recording already started")
+		return out
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(500, "internal_error", "Recording file could not be created: " + err.Error())
+		return out
+	}
+	vehConn.recorder = &sessionRecorder{file: file, encoder: json.NewEncoder(file)}
+	out.Status = SUCCESSFUL
+	return out
+}
+
+func StopRecording(vehicleId VehicleHandle) GeneralOutput {
+	var out GeneralOutput
+	vehConn := getVehicleConnection(vehicleId)
+	if vehConn == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "unknown vehicle")
+		return out
+	}
+	recorderMutex.Lock()
+	recorder := vehConn.recorder
+	vehConn.recorder = nil
+	recorderMutex.Unlock()
+	if recorder == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Recording not started")
+		return out
+	}
+	recorder.mutex.Lock()
+	err := recorder.file.Close()
+	recorder.mutex.Unlock()
+	if err != nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(500, "internal_error", "Recording file could not be written: " + err.Error())
+		return out
+	}
+	out.Status = SUCCESSFUL
+	return out
+}
+
+func recordMessage(vehicle *VehicleConnection, direction string, protocol string, message []byte) {
+	recorderMutex.Lock()
+	recorder := vehicle.recorder
+	recorderMutex.Unlock()
+	if recorder == nil {
+		return
+	}
+	entry := recordingEntry{time.Now().UTC().Format(time.RFC3339Nano), direction, protocol, string(message)}
+	recorder.mutex.Lock()
+	err := recorder.encoder.Encode(entry)
+	recorder.mutex.Unlock()
+	if err != nil {
+		fmt.Printf("recordMessage: error=%s\n", err)
+	}
+}
+
+// RegisterReplayVehicle makes a vehicle known to GetVehicle that replays the recording file when it is connected with REPLAY_PROTOCOL.
+func RegisterReplayVehicle(vehicleGuid string, recordingFile string) {
+	RegisterVehicle(vehicleGuid, recordingFile, []ConnectivityData{{"", REPLAY_PROTOCOL}})
+}
+
+func readRecording(fileName string) ([]recordingEntry, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var entries []recordingEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry recordingEntry
+		err = json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+type replaySession struct {
+	entries []recordingEntry
+	clientChan chan []byte
+	serverChan chan []byte
+	closeChan chan struct{}
+	closeOnce sync.Once
+}
+
+func dialReplay(recordingFile string) (Transport, error) {
+	entries, err := readRecording(recordingFile)
+	if err != nil {
+		return nil, err
+	}
+	var session replaySession
+	session.entries = entries
+	session.clientChan = make(chan []byte, 10)
+	session.serverChan = make(chan []byte)
+	session.closeChan = make(chan struct{})
+	go session.play()
+	return &session, nil
+}
+
+func (session *replaySession) Close() {
+	session.closeOnce.Do(func() {
+		close(session.closeChan)
+	})
+}
+
+func (session *replaySession) Send(message []byte) {
+	select {
+		case session.clientChan <- message:
+		case <- session.closeChan:
+	}
+}
+
+func (session *replaySession) Receive() <-chan []byte {
+	return session.serverChan
+}
+
+func (session *replaySession) play() {
+	defer close(session.serverChan)
+	requestIds := make(map[string]string) // recorded requestId -> live requestId
+	var previousTs time.Time
+	for i := 0; i < len(session.entries); i++ {
+		entry := session.entries[i]
+		ts, _ := time.Parse(time.RFC3339Nano, entry.Ts)
+		switch entry.Direction {
+			case "client":
+				if !session.awaitClientMessage(entry, requestIds) {
+					return
+				}
+			case "server":
+				if !previousTs.IsZero() && ts.After(previousTs) {
+					select {
+						case <- time.After(ts.Sub(previousTs)):
+						case <- session.closeChan:
+							return
+					}
+				}
+				if !session.sendServerMessage(replaceRequestId([]byte(entry.Message), requestIds)) {
+					return
+				}
+		}
+		previousTs = ts
+	}
+	for { // end of recording, no more matches
+		select {
+			case message := <- session.clientChan:
+				if !session.sendServerMessage(getReplayErrorMessage(message)) {
+					return
+				}
+			case <- session.closeChan:
+				return
+		}
+	}
+}
+
+func (session *replaySession) awaitClientMessage(entry recordingEntry, requestIds map[string]string) bool {
+	recordedMessage, recordedRequestId := normalizeReplayMessage([]byte(entry.Message))
+	for {
+		select {
+			case message := <- session.clientChan:
+				liveMessage, liveRequestId := normalizeReplayMessage(message)
+				if liveMessage != "" && liveMessage == recordedMessage {
+					if recordedRequestId != "" {
+						requestIds[recordedRequestId] = liveRequestId
+					}
+					return true
+				}
+				if !session.sendServerMessage(getReplayErrorMessage(message)) {
+					return false
+				}
+			case <- session.closeChan:
+				return false
+		}
+	}
+}
+
+func (session *replaySession) sendServerMessage(message []byte) bool {
+	select {
+		case session.serverChan <- message:
+			return true
+		case <- session.closeChan:
+			return false
+	}
+}
+
+func normalizeReplayMessage(message []byte) (string, string) { // the message without requestId, and the requestId
+	var messageMap map[string]interface{}
+	if json.Unmarshal(message, &messageMap) != nil {
+		return "", ""
+	}
+	requestId, _ := messageMap["requestId"].(string)
+	delete(messageMap, "requestId")
+	normalized, _ := json.Marshal(messageMap) // map keys are marshalled in sorted order
+	return string(normalized), requestId
+}
+
+func replaceRequestId(message []byte, requestIds map[string]string) []byte {
+	var messageMap map[string]interface{}
+	if json.Unmarshal(message, &messageMap) != nil {
+		return message
+	}
+	requestId, _ := messageMap["requestId"].(string)
+	liveRequestId, ok := requestIds[requestId]
+	if !ok {
+		return message
+	}
+	messageMap["requestId"] = liveRequestId
+	replaced, _ := json.Marshal(messageMap)
+	return replaced
+}
+
+func getReplayErrorMessage(clientMessage []byte) []byte {
+	var messageMap map[string]interface{}
+	json.Unmarshal(clientMessage, &messageMap)
+	errorMessage := map[string]interface{}{"action": messageMap["action"], "requestId": messageMap["requestId"], "ts": time.Now().UTC().Format(time.RFC3339),
+		"error": map[string]string{"number": "404", "reason": "not_found", "description": "The message does not match the recording"}}
+	data, _ := json.Marshal(errorMessage)
+	return data
+}
@@ -0,0 +1,134 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type sessionResult struct {
+	getValue string
+	setStatus ProcedureStatus
+	eventValue string
+	unsubscribeStatus ProcedureStatus
+}
+
+func runRecordedSession(t *testing.T, vehicleId VehicleHandle) sessionResult {
+	t.Helper()
+	var result sessionResult
+	result.setStatus = Set(vehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType", ROLL, "").Status
+	getOut := Get(vehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType", "", "")
+	if getOut.Status != SUCCESSFUL || len(getOut.Data) == 0 || len(getOut.Data[0].Dp) == 0 {
+		t.Fatalf("Get: status=%d, error=%v", getOut.Status, getOut.Error)
+	}
+	result.getValue = getOut.Data[0].Dp[0].Value
+	eventChan := make(chan SubscribeOutput, 10)
+	subscribeOut := Subscribe(vehicleId, "Vehicle.Speed", `{"variant":"timebased","parameter":{"period":"100"}}`, "", func(out SubscribeOutput) {
+		eventChan <- out
+	})
+	if subscribeOut.Status != ONGOING {
+		t.Fatalf("Subscribe: status=%d, error=%v", subscribeOut.Status, subscribeOut.Error)
+	}
+	event := waitFor(t, eventChan, func(out SubscribeOutput) bool { return true })
+	if event.Status != SUCCESSFUL || len(event.Data) == 0 || len(event.Data[0].Dp) == 0 {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+	result.eventValue = event.Data[0].Dp[0].Value
+	result.unsubscribeStatus = Unsubscribe(vehicleId, subscribeOut.ServiceId).Status
+	return result
+}
+
+func connectReplayVehicle(t *testing.T, recordingFile string) VehicleHandle {
+	t.Helper()
+	vehicleGuid := "replayVin-" + t.Name()
+	RegisterReplayVehicle(vehicleGuid, recordingFile)
+	getVehicleOut := GetVehicle(vehicleGuid)
+	if getVehicleOut.Status != SUCCESSFUL {
+		t.Fatalf("GetVehicle: %v", getVehicleOut.Error)
+	}
+	connectOut := Connect(getVehicleOut.VehicleId, REPLAY_PROTOCOL, "")
+	if connectOut.Status != SUCCESSFUL {
+		t.Fatalf("Connect: %v", connectOut.Error)
+	}
+	t.Cleanup(func() {
+		Disconnect(getVehicleOut.VehicleId, REPLAY_PROTOCOL)
+		ReleaseVehicle(getVehicleOut.VehicleId)
+	})
+	return getVehicleOut.VehicleId
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	server.SetValue("Vehicle.Speed", "25")
+	recordingFile := filepath.Join(t.TempDir(), "session.jsonl")
+	startOut := StartRecording(vehicleId, recordingFile)
+	if startOut.Status != SUCCESSFUL {
+		t.Fatalf("StartRecording: %v", startOut.Error)
+	}
+	if StartRecording(vehicleId, recordingFile).Status != FAILED {
+		t.Errorf("second StartRecording did not fail")
+	}
+	recorded := runRecordedSession(t, vehicleId)
+	stopOut := StopRecording(vehicleId)
+	if stopOut.Status != SUCCESSFUL {
+		t.Fatalf("StopRecording: %v", stopOut.Error)
+	}
+	if StopRecording(vehicleId).Status != FAILED {
+		t.Errorf("second StopRecording did not fail")
+	}
+	data, err := os.ReadFile(recordingFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %s", err)
+	}
+	if !strings.Contains(string(data), `"direction":"client"`) || !strings.Contains(string(data), `"direction":"server"`) {
+		t.Fatalf("recording lacks client or server messages: %s", data)
+	}
+
+	server.SetValue("Vehicle.Speed", "50") // the replay must not depend on the server
+	replayVehicleId := connectReplayVehicle(t, recordingFile)
+	replayed := runRecordedSession(t, replayVehicleId)
+	if replayed != recorded {
+		t.Errorf("replayed=%+v, recorded=%+v", replayed, recorded)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	recordingFile := filepath.Join(t.TempDir(), "session.jsonl")
+	StartRecording(vehicleId, recordingFile)
+	Get(vehicleId, "Vehicle.Speed", "", "")
+	StopRecording(vehicleId)
+
+	replayVehicleId := connectReplayVehicle(t, recordingFile)
+	getOut := Get(replayVehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide.Position", "", "")
+	if getOut.Status != FAILED || getOut.Error == nil || getOut.Error.Code != 404 {
+		t.Errorf("unmatched Get: status=%d, error=%v", getOut.Status, getOut.Error)
+	}
+	getOut = Get(replayVehicleId, "Vehicle.Speed", "", "")
+	if getOut.Status != SUCCESSFUL {
+		t.Errorf("matched Get: status=%d, error=%v", getOut.Status, getOut.Error)
+	}
+	getOut = Get(replayVehicleId, "Vehicle.Speed", "", "")
+	if getOut.Status != FAILED {
+		t.Errorf("Get after end of recording: status=%d", getOut.Status)
+	}
+}
+
+func TestReplayMissingRecording(t *testing.T) {
+	vehicleGuid := "replayVin-" + t.Name()
+	RegisterReplayVehicle(vehicleGuid, filepath.Join(t.TempDir(), "missing.jsonl"))
+	getVehicleOut := GetVehicle(vehicleGuid)
+	defer ReleaseVehicle(getVehicleOut.VehicleId)
+	if Connect(getVehicleOut.VehicleId, REPLAY_PROTOCOL, "").Status != FAILED {
+		t.Errorf("Connect with a missing recording did not fail")
+	}
+}
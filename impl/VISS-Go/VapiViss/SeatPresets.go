@@ -0,0 +1,184 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// ****************** Seat presets ***************
+/* A seat preset is the set of movement positions of a seat, saved under a name and persisted in a local store.
+*  The store is a JSON file with the layout {"pseudoVin": {"RowName.ColumnName": {"presetName": [SeatConfig, ...]}}} */
+type seatPresetStore map[string]map[string]map[string][]SeatConfig
+
+var seatPresetFile = "seatPresets.json"
+var seatPresetMutex sync.Mutex
+
+type SeatPresetOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Preset []SeatConfig
+}
+
+func SetSeatPresetStore(fileName string) {
+	seatPresetMutex.Lock()
+	seatPresetFile = fileName
+	seatPresetMutex.Unlock()
+}
+
+func SaveSeatPreset(vehicleId VehicleHandle, seatId MatrixId, name string, stCredentials string) SeatPresetOutput {
+	var out SeatPresetOutput
+	vehConn := getVehicleConnection(vehicleId)
+	if vehConn == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
+	if name == "" {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "missing preset name")
+		return out
+	}
+	movementSupport, errorData := getSeatMovementSupport(vehicleId, seatId)
+	if errorData != nil {
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	if len(movementSupport) == 0 {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Movement not supported for this seat")
+		return out
+	}
+	for i := 0; i < len(movementSupport); i++ {
+		actuatorPath, A, B := getSeatActuatorData(movementSupport[i].Name, seatId)
+		if actuatorPath == "" {
+			continue // supported by the seat, but not by MoveSeat
+		}
+		getOut := Get(vehicleId, actuatorPath, "", stCredentials)
+		if getOut.Status == FAILED {
+			out.Status = FAILED
+			out.Error = getOut.Error
+			return out
+		}
+		if len(getOut.Data) == 0 || len(getOut.Data[0].Dp) == 0 {
+			out.Status = FAILED
+			out.Error = getErrorObject(502, "bad_gateway", "Server returned no data for " + actuatorPath)
+			return out
+		}
+		currPos, err := strconv.ParseFloat(getOut.Data[0].Dp[0].Value, 32)
+		if err != nil {
+			out.Status = FAILED
+			out.Error = getErrorObject(502, "bad_gateway", "Server returned invalid data for " + actuatorPath)
+			return out
+		}
+		out.Preset = append(out.Preset, SeatConfig{MovementType: movementSupport[i].Name, Position: (Percentage(currPos)-B)/A})
+	}
+	seatPresetMutex.Lock()
+	defer seatPresetMutex.Unlock()
+	store, ok := readSeatPresetStore()
+	if !ok {
+		out.Status = FAILED
+		out.Error = getErrorObject(500, "internal_error", "Seat preset store could not be read")
+		return out
+	}
+	seatKey := createSeatPresetKey(seatId)
+	if store[vehConn.vehicleGuid] == nil {
+		store[vehConn.vehicleGuid] = make(map[string]map[string][]SeatConfig)
+	}
+	if store[vehConn.vehicleGuid][seatKey] == nil {
+		store[vehConn.vehicleGuid][seatKey] = make(map[string][]SeatConfig)
+	}
+	store[vehConn.vehicleGuid][seatKey][name] = out.Preset
+	if !writeSeatPresetStore(store) {
+		out.Status = FAILED
+		out.Error = getErrorObject(500, "internal_error", "Seat preset store could not be written")
+		return out
+	}
+	out.Status = SUCCESSFUL
+	return out
+}
+
+func RecallSeatPreset(vehicleId VehicleHandle, seatId MatrixId, name string, stCredentials string, callback func(ConfigureSeatOutput)) ConfigureSeatOutput {
+	var out ConfigureSeatOutput
+	vehConn := getVehicleConnection(vehicleId)
+	if vehConn == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
+	seatPresetMutex.Lock()
+	store, ok := readSeatPresetStore()
+	seatPresetMutex.Unlock()
+	if !ok {
+		out.Status = FAILED
+		out.Error = getErrorObject(500, "internal_error", "Seat preset store could not be read")
+		return out
+	}
+	preset, ok := store[vehConn.vehicleGuid][createSeatPresetKey(seatId)][name]
+	if !ok {
+		out.Status = FAILED
+		out.Error = getErrorObject(404, "not_found", "unknown seat preset")
+		return out
+	}
+	return ConfigureSeat(vehicleId, seatId, preset, stCredentials, callback)
+}
+
+func createSeatPresetKey(seatId MatrixId) string {
+	return seatId.RowName + "." + seatId.ColumnName
+}
+
+func getSeatMovementSupport(vehicleId VehicleHandle, seatId MatrixId) ([]SupportData, *ErrorData) {
+	propertiesOut := GetPropertiesSeating(vehicleId)
+	if propertiesOut.Status == FAILED {
+		return nil, propertiesOut.Error
+	}
+	for i := 0; i < len(propertiesOut.Properties); i++ {
+		if propertiesOut.Properties[i].RowName == seatId.RowName {
+			for j := 0; j < len(propertiesOut.Properties[i].Column); j++ {
+				if propertiesOut.Properties[i].Column[j].Name == seatId.ColumnName {
+					return propertiesOut.Properties[i].Column[j].MovementSupport, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+func readSeatPresetStore() (seatPresetStore, bool) { // caller must hold seatPresetMutex
+	store := make(seatPresetStore)
+	data, err := os.ReadFile(seatPresetFile)
+	if os.IsNotExist(err) {
+		return store, true
+	}
+	if err != nil {
+		return nil, false
+	}
+	err = json.Unmarshal(data, &store)
+	if err != nil {
+		return nil, false
+	}
+	return store, true
+}
+
+func writeSeatPresetStore(store seatPresetStore) bool { // caller must hold seatPresetMutex
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return false
+	}
+	tmpFile := seatPresetFile + ".tmp"
+	err = os.WriteFile(tmpFile, data, 0644)
+	if err != nil {
+		return false
+	}
+	return os.Rename(tmpFile, seatPresetFile) == nil
+}
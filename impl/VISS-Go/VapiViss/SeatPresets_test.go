@@ -0,0 +1,80 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSeatPresets(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	SetSeatPresetStore(filepath.Join(t.TempDir(), "presets.json"))
+	defer SetSeatPresetStore("seatPresets.json")
+	seatId := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	server.SetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Position", "60")
+	server.SetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Backrest.Lumbar.Support", "25")
+
+	saveOut := SaveSeatPreset(vehicleId, seatId, "alice", "")
+	if saveOut.Status != SUCCESSFUL {
+		t.Fatalf("SaveSeatPreset: %v", saveOut.Error)
+	}
+	want := []SeatConfig{{MovementType: LONGITUDINAL, Position: 20}, {MovementType: LUMBAR, Position: 25}}
+	if len(saveOut.Preset) != len(want) || saveOut.Preset[0] != want[0] || saveOut.Preset[1] != want[1] {
+		t.Errorf("Preset = %v, want %v", saveOut.Preset, want)
+	}
+
+	server.SetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Position", "0")
+	server.SetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Backrest.Lumbar.Support", "90")
+	eventChan := make(chan ConfigureSeatOutput, 10)
+	recallOut := RecallSeatPreset(vehicleId, seatId, "alice", "", func(out ConfigureSeatOutput) {
+		eventChan <- out
+	})
+	if recallOut.Status != ONGOING {
+		t.Fatalf("RecallSeatPreset: status=%d, error=%v", recallOut.Status, recallOut.Error)
+	}
+	event := waitFor(t, eventChan, func(out ConfigureSeatOutput) bool { return out.Status != ONGOING })
+	if event.Status != SUCCESSFUL {
+		t.Errorf("final callback: %+v", event)
+	}
+	for path, value := range map[string]string{"Position": "60", "Backrest.Lumbar.Support": "25"} {
+		if got, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide." + path); got != value {
+			t.Errorf("%s = %s, want %s", path, got, value)
+		}
+	}
+}
+
+func TestSeatPresetErrors(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	storeFile := filepath.Join(t.TempDir(), "presets.json")
+	SetSeatPresetStore(storeFile)
+	defer SetSeatPresetStore("seatPresets.json")
+	out1 := SaveSeatPreset(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, "", "")
+	out2 := SaveSeatPreset(vehicleId, MatrixId{RowName: "Row2", ColumnName: "DriverSide"}, "bob", "")
+	out3 := RecallSeatPreset(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, "unknown", "", nil)
+	os.WriteFile(storeFile, []byte("not json"), 0644)
+	out4 := RecallSeatPreset(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, "bob", "", nil)
+	tests := []struct {
+		name string
+		code int32
+		status ProcedureStatus
+		err *ErrorData
+	}{
+		{"missing name", 400, out1.Status, out1.Error},
+		{"seat without movement", 400, out2.Status, out2.Error},
+		{"unknown preset", 404, out3.Status, out3.Error},
+		{"corrupt store", 500, out4.Status, out4.Error},
+	}
+	for _, test := range tests {
+		if test.status != FAILED || test.err == nil || test.err.Code != test.code {
+			t.Errorf("%s: status=%d, error=%v, want code %d", test.name, test.status, test.err, test.code)
+		}
+	}
+}
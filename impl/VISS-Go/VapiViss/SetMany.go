@@ -0,0 +1,110 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+// ****************** Multi-path set ***************
+/* SetMany writes several paths in order, so that a composite actuation is all or nothing from the view of the client.
+*  The current values of the paths are read before the first write, and if a write fails, the paths that were written are restored
+*  to their read values in reverse order. The result of each path is reported in the Results of the output, in the order of the input. */
+
+// set results
+const (
+	SET_DONE = "set"
+	SET_FAILED = "failed"
+	SET_SKIPPED = "skipped"  // not written, as an earlier path failed
+	SET_ROLLED_BACK = "rolled_back"
+	SET_ROLLBACK_FAILED = "rollback_failed"  // written, but the read value could not be restored
+)
+
+type PathValue struct {
+	Path string
+	Value string
+}
+
+type SetResult struct {
+	Path string
+	Result string
+	Error *ErrorData
+}
+
+type SetManyOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Results []SetResult
+}
+
+func SetMany(vehicleId VehicleHandle, values []PathValue, stCredentials string) SetManyOutput {
+	var out SetManyOutput
+	if getVehicleConnection(vehicleId) == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
+	if len(values) == 0 {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "missing values")
+		return out
+	}
+	out.Results = make([]SetResult, len(values))
+	for i := 0; i < len(values); i++ {
+		out.Results[i] = SetResult{Path: values[i].Path, Result: SET_SKIPPED}
+	}
+	for i := 0; i < len(values); i++ {
+		errorData := validateSetValue(values[i].Path, values[i].Value)
+		if errorData == nil && values[i].Value == "" {
+			errorData = getErrorObject(400, "invalid_data", "missing value")
+		}
+		if errorData != nil {
+			return failSetMany(out, i, errorData)
+		}
+	}
+	previousValues := make([]string, len(values))
+	for i := 0; i < len(values); i++ {
+		getOut := Get(vehicleId, values[i].Path, "", stCredentials)
+		if getOut.Status == FAILED {
+			return failSetMany(out, i, getOut.Error)
+		}
+		if len(getOut.Data) != 1 || len(getOut.Data[0].Dp) == 0 {
+			return failSetMany(out, i, getErrorObject(400, "invalid_data", "the path is not a leaf: " + values[i].Path))
+		}
+		previousValues[i] = getOut.Data[0].Dp[0].Value
+	}
+	for i := 0; i < len(values); i++ {
+		setOut := Set(vehicleId, values[i].Path, values[i].Value, stCredentials)
+		if setOut.Status == FAILED {
+			out = failSetMany(out, i, setOut.Error)
+			for j := i - 1; j >= 0; j-- {
+				var restoreOut GeneralOutput
+				if previousValues[j] == "" {
+					restoreOut = GeneralOutput{Status: FAILED, Error: getErrorObject(400, "invalid_data", "the read value is empty and can not be set")}
+				} else {
+					restoreOut = Set(vehicleId, values[j].Path, previousValues[j], stCredentials)
+				}
+				if restoreOut.Status == FAILED {
+					out.Results[j].Result = SET_ROLLBACK_FAILED
+					out.Results[j].Error = restoreOut.Error
+				} else {
+					out.Results[j].Result = SET_ROLLED_BACK
+				}
+			}
+			return out
+		}
+		out.Results[i].Result = SET_DONE
+	}
+	out.Status = SUCCESSFUL
+	return out
+}
+
+func failSetMany(out SetManyOutput, index int, errorData *ErrorData) SetManyOutput {
+	out.Status = FAILED
+	out.Error = errorData
+	out.Results[index].Result = SET_FAILED
+	out.Results[index].Error = errorData
+	return out
+}
@@ -0,0 +1,73 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"testing"
+)
+
+func TestSetMany(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	massagePath := "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage"
+	server.SetValue(massagePath + ".MassageType", "ROLLING")
+
+	out := SetMany(vehicleId, []PathValue{{massagePath + ".Intensity", "40"}, {massagePath + ".MassageType", "WAVE"}, {massagePath + ".IsOn", "true"}}, "")
+	if out.Status != SUCCESSFUL || len(out.Results) != 3 {
+		t.Fatalf("SetMany: status=%d, results=%+v, error=%v", out.Status, out.Results, out.Error)
+	}
+	for _, result := range out.Results {
+		if result.Result != SET_DONE || result.Error != nil {
+			t.Errorf("the result of %s is %+v", result.Path, result)
+		}
+	}
+
+	out = SetMany(vehicleId, []PathValue{{massagePath + ".Intensity", "80"}, {massagePath + ".MassageType", "SHIATSU"}, {"Vehicle.Speed", "10"},
+		{massagePath + ".IsOn", "false"}}, "")
+	if out.Status != FAILED || out.Error == nil || out.Error.Code != 400 {
+		t.Fatalf("SetMany with a failing write: status=%d, error=%v", out.Status, out.Error)
+	}
+	expected := []string{SET_ROLLED_BACK, SET_ROLLED_BACK, SET_FAILED, SET_SKIPPED}
+	for i, result := range out.Results {
+		if result.Result != expected[i] {
+			t.Errorf("the result of %s is %s, want %s", result.Path, result.Result, expected[i])
+		}
+	}
+	for path, value := range map[string]string{".Intensity": "40", ".MassageType": "WAVE", ".IsOn": "true"} {
+		if got, _ := server.GetValue(massagePath + path); got != value {
+			t.Errorf("%s = %s after the rollback, want %s", path, got, value)
+		}
+	}
+}
+
+func TestSetManyErrors(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	massagePath := "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage"
+	out := SetMany(vehicleId, []PathValue{{massagePath + ".MassageType", "WAVE"}, {massagePath + ".Width", "1"}}, "")
+	if out.Status != FAILED || out.Results[0].Result != SET_SKIPPED || out.Results[1].Result != SET_FAILED || out.Results[1].Error.Code != 404 {
+		t.Errorf("SetMany with an unknown path: status=%d, results=%+v", out.Status, out.Results)
+	}
+	if value, _ := server.GetValue(massagePath + ".MassageType"); value != "" {
+		t.Errorf("MassageType = %s, a path was written although a path could not be read", value)
+	}
+
+	out = SetMany(vehicleId, []PathValue{{massagePath + ".MassageType", "WAVE"}, {"Vehicle.Speed", "10"}}, "")
+	if out.Status != FAILED || out.Results[0].Result != SET_ROLLBACK_FAILED || out.Results[0].Error == nil {
+		t.Errorf("the rollback of an empty value: status=%d, results=%+v", out.Status, out.Results)
+	}
+
+	for _, values := range [][]PathValue{nil, {{massagePath + ".IsOn", ""}}, {{massagePath, "1"}}} {
+		out := SetMany(vehicleId, values, "")
+		if out.Status != FAILED || out.Error == nil || out.Error.Code != 400 {
+			t.Errorf("SetMany(%v): status=%d, error=%v, want the error 400", values, out.Status, out.Error)
+		}
+	}
+	if out := SetMany(VehicleHandle(12345), []PathValue{{massagePath + ".IsOn", "true"}}, ""); out.Status != FAILED {
+		t.Errorf("SetMany on an unknown vehicle: status=%d", out.Status)
+	}
+}
@@ -0,0 +1,188 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// ****************** Vehicle state shadow ***************
+/* A shadow is an optional local copy of the state of a vehicle. When it is enabled for a vehicle, it keeps the latest data point of every path
+*  that is read by Get, or received in an event of a subscription, together with the time it was received. GetFromShadow serves a Get of a path
+*  from the shadow if the value was received within a max age, and else reads it from the vehicle, so that a value that an active subscription
+*  already delivers is not requested again. The listeners of a shadow are notified when the value of a path changes. */
+type ShadowValue struct {
+	Dp DataPoint
+	Received time.Time
+}
+
+type ShadowChange struct {
+	Path string
+	Previous *DataPoint  // nil if the path was not in the shadow
+	Current DataPoint
+}
+
+type shadowListener struct {
+	path string  // the path, or the branch of the paths, that is listened to, all paths if empty
+	callback func(ShadowChange)
+}
+
+type vehicleShadow struct {
+	values map[string]ShadowValue  // by path
+	listeners map[uint32]shadowListener  // by listener id
+}
+
+var shadowMutex sync.Mutex
+var shadows = make(map[VehicleHandle]*vehicleShadow)
+
+// EnableShadow starts a shadow of the state of the vehicle, a shadow that is already enabled is kept.
+func EnableShadow(vehicleId VehicleHandle) GeneralOutput {
+	var out GeneralOutput
+	connectionMutex.Lock()  // ReleaseVehicle removes the shadow under it, so the vehicle can not be released before the shadow is added
+	defer connectionMutex.Unlock()
+	if findVehicleConnection(vehicleId) == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "unknown vehicle")
+		return out
+	}
+	shadowMutex.Lock()
+	if shadows[vehicleId] == nil {
+		shadows[vehicleId] = &vehicleShadow{values: make(map[string]ShadowValue), listeners: make(map[uint32]shadowListener)}
+	}
+	shadowMutex.Unlock()
+	out.Status = SUCCESSFUL
+	return out
+}
+
+// DisableShadow discards the shadow of the vehicle and its listeners.
+func DisableShadow(vehicleId VehicleHandle) GeneralOutput {
+	var out GeneralOutput
+	shadowMutex.Lock()
+	defer shadowMutex.Unlock()
+	if shadows[vehicleId] == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "the shadow is not enabled")
+		return out
+	}
+	delete(shadows, vehicleId)
+	out.Status = SUCCESSFUL
+	return out
+}
+
+// GetShadowValue returns the latest data point of the path in the shadow of the vehicle, and false if the path is not in it.
+func GetShadowValue(vehicleId VehicleHandle, path string) (ShadowValue, bool) {
+	shadowMutex.Lock()
+	defer shadowMutex.Unlock()
+	shadow := shadows[vehicleId]
+	if shadow == nil {
+		return ShadowValue{}, false
+	}
+	value, ok := shadow.values[path]
+	return value, ok
+}
+
+/* GetFromShadow returns the value of the path from the shadow if it was received within maxAge, and else reads it from the vehicle by Get.
+*  A path that is a branch or has wildcards, and a Get with a filter, are always read from the vehicle, as the shadow can not tell whether it has all the paths. */
+func GetFromShadow(vehicleId VehicleHandle, path string, filter string, stCredentials string, maxAge time.Duration) GetOutput {
+	if filter == "" {
+		if value, ok := GetShadowValue(vehicleId, path); ok && time.Since(value.Received) <= maxAge {
+			var out GetOutput
+			out.Status = SUCCESSFUL
+			out.Data = []DataContainer{{Path: path, Dp: []DataPoint{value.Dp}}}
+			return out
+		}
+	}
+	return Get(vehicleId, path, filter, stCredentials)
+}
+
+/* AddShadowListener adds a callback that is called when the value of the path, or of a path below it if it is a branch, changes in the shadow,
+*  and returns the id of the listener. An empty path listens to all paths. */
+func AddShadowListener(vehicleId VehicleHandle, path string, callback func(ShadowChange)) (uint32, *ErrorData) {
+	shadowMutex.Lock()
+	defer shadowMutex.Unlock()
+	shadow := shadows[vehicleId]
+	if shadow == nil {
+		return 0, getErrorObject(400, "invalid_data", "the shadow is not enabled")
+	}
+	listenerId := generateRandomUint32()
+	shadow.listeners[listenerId] = shadowListener{path: path, callback: callback}
+	return listenerId, nil
+}
+
+func RemoveShadowListener(vehicleId VehicleHandle, listenerId uint32) {
+	shadowMutex.Lock()
+	defer shadowMutex.Unlock()
+	if shadow := shadows[vehicleId]; shadow != nil {
+		delete(shadow.listeners, listenerId)
+	}
+}
+
+// updateShadow saves the newest data point of each path in the shadow, if it is enabled for the vehicle, and notifies the listeners of the changed values.
+func updateShadow(vehicleId VehicleHandle, data []DataContainer) {
+	var changes []ShadowChange
+	var callbacks []func(ShadowChange)
+	shadowMutex.Lock()
+	shadow := shadows[vehicleId]
+	if shadow == nil {
+		shadowMutex.Unlock()
+		return
+	}
+	received := time.Now()
+	for _, dataContainer := range data {
+		if len(dataContainer.Dp) == 0 {
+			continue
+		}
+		newest := dataContainer.Dp[0]
+		for _, dataPoint := range dataContainer.Dp[1:] {
+			if isNewerTimestamp(dataPoint.Timestamp, newest.Timestamp) {
+				newest = dataPoint
+			}
+		}
+		previous, ok := shadow.values[dataContainer.Path]
+		if ok && isNewerTimestamp(previous.Dp.Timestamp, newest.Timestamp) {  // e.g. an older data point of a history
+			continue
+		}
+		shadow.values[dataContainer.Path] = ShadowValue{Dp: newest, Received: received}
+		if ok && previous.Dp.Value == newest.Value {
+			continue
+		}
+		change := ShadowChange{Path: dataContainer.Path, Current: newest}
+		if ok {
+			change.Previous = &previous.Dp
+		}
+		for _, listener := range shadow.listeners {
+			if listener.path == "" || listener.path == change.Path || strings.HasPrefix(change.Path, listener.path + ".") {
+				changes = append(changes, change)
+				callbacks = append(callbacks, listener.callback)
+			}
+		}
+	}
+	shadowMutex.Unlock()
+	for i, callback := range callbacks {
+		callback(changes[i])
+	}
+}
+
+// isNewerTimestamp compares timestamps in the RFC3339 format of VISS, a timestamp that can not be parsed is not newer.
+func isNewerTimestamp(timestamp string, other string) bool {
+	time1, err1 := time.Parse(time.RFC3339Nano, timestamp)
+	time2, err2 := time.Parse(time.RFC3339Nano, other)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	return time1.After(time2)
+}
+
+func removeShadow(vehicleId VehicleHandle) {
+	shadowMutex.Lock()
+	delete(shadows, vehicleId)
+	shadowMutex.Unlock()
+}
@@ -0,0 +1,100 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShadow(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	massageTypePath := "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType"
+	server.SetValue(massageTypePath, "ROLLING")
+	if out := EnableShadow(vehicleId); out.Status != SUCCESSFUL {
+		t.Fatalf("EnableShadow: %v", out.Error)
+	}
+	getOut := GetFromShadow(vehicleId, massageTypePath, "", "", time.Minute)
+	if getOut.Status != SUCCESSFUL || len(getOut.Data) != 1 || getOut.Data[0].Dp[0].Value != "ROLLING" {
+		t.Fatalf("GetFromShadow of a path that is not in the shadow: %+v", getOut)
+	}
+	if value, ok := GetShadowValue(vehicleId, massageTypePath); !ok || value.Dp.Value != "ROLLING" {
+		t.Errorf("the shadow value after Get is %+v, %t", value, ok)
+	}
+
+	server.SetValue(massageTypePath, "WAVE")
+	getOut = GetFromShadow(vehicleId, massageTypePath, "", "", time.Minute)
+	if getOut.Status != SUCCESSFUL || getOut.Data[0].Dp[0].Value != "ROLLING" {
+		t.Errorf("GetFromShadow within the max age returned %+v, want the shadow value", getOut.Data)
+	}
+	getOut = GetFromShadow(vehicleId, massageTypePath, "", "", 0)
+	if getOut.Status != SUCCESSFUL || getOut.Data[0].Dp[0].Value != "WAVE" {
+		t.Errorf("GetFromShadow beyond the max age returned %+v, want the vehicle value", getOut.Data)
+	}
+
+	changeChan := make(chan ShadowChange, 100)
+	listenerId, errorData := AddShadowListener(vehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage", func(change ShadowChange) { changeChan <- change })
+	if errorData != nil {
+		t.Fatalf("AddShadowListener: %v", errorData)
+	}
+	subscribeOut := Subscribe(vehicleId, massageTypePath, `{"variant":"timebased","parameter":{"period":"20"}}`, "", func(SubscribeOutput) {})
+	if subscribeOut.Status != ONGOING {
+		t.Fatalf("Subscribe: %v", subscribeOut.Error)
+	}
+	defer Unsubscribe(vehicleId, subscribeOut.ServiceId)
+	server.SetValue(massageTypePath, "SHIATSU")
+	change := waitFor(t, changeChan, func(change ShadowChange) bool { return change.Current.Value == "SHIATSU" })
+	if change.Path != massageTypePath || change.Previous == nil || change.Previous.Value != "WAVE" {
+		t.Errorf("the change is %+v", change)
+	}
+	RemoveShadowListener(vehicleId, listenerId)
+
+	if out := DisableShadow(vehicleId); out.Status != SUCCESSFUL {
+		t.Errorf("DisableShadow: %v", out.Error)
+	}
+	if _, ok := GetShadowValue(vehicleId, massageTypePath); ok {
+		t.Errorf("the shadow has a value after DisableShadow")
+	}
+	if _, errorData := AddShadowListener(vehicleId, "", func(ShadowChange) {}); errorData == nil {
+		t.Errorf("AddShadowListener without a shadow did not fail")
+	}
+}
+
+func TestUpdateShadow(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	EnableShadow(vehicleId)
+	updateShadow(vehicleId, []DataContainer{{Path: "Vehicle.Speed", Dp: []DataPoint{{Value: "10", Timestamp: "2025-01-01T00:00:02Z"}, {Value: "20", Timestamp: "2025-01-01T00:00:03Z"}, {Value: "5", Timestamp: "2025-01-01T00:00:01Z"}}}})
+	if value, _ := GetShadowValue(vehicleId, "Vehicle.Speed"); value.Dp.Value != "20" {
+		t.Errorf("the shadow value is %s, want the newest data point 20", value.Dp.Value)
+	}
+	updateShadow(vehicleId, []DataContainer{{Path: "Vehicle.Speed", Dp: []DataPoint{{Value: "15", Timestamp: "2025-01-01T00:00:01Z"}}}})
+	if value, _ := GetShadowValue(vehicleId, "Vehicle.Speed"); value.Dp.Value != "20" {
+		t.Errorf("the shadow value is %s, an older data point replaced the newest", value.Dp.Value)
+	}
+}
+
+func TestEnableShadowReleaseVehicle(t *testing.T) {
+	RegisterVehicle("shadowReleaseVin", "127.0.0.1", []ConnectivityData{{PortNo: "1", Protocol: testProtocol}})
+	for i := 0; i < 50; i++ {
+		vehicleId := GetVehicle("shadowReleaseVin").VehicleId
+		doneChan := make(chan struct{})
+		go func() {
+			EnableShadow(vehicleId)
+			close(doneChan)
+		}()
+		ReleaseVehicle(vehicleId)
+		<- doneChan
+		shadowMutex.Lock()
+		shadow := shadows[vehicleId]
+		shadowMutex.Unlock()
+		if shadow != nil {
+			t.Fatalf("the shadow of a released vehicle is kept")
+		}
+	}
+}
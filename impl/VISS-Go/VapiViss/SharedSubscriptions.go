@@ -0,0 +1,173 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ****************** Shared subscriptions ***************
+/* Identical subscriptions, i. e. to the same path with the same filter and credentials on the same vehicle connection, share one
+*  subscription on the server. Each caller of Subscribe gets its own serviceId, and the events of the server subscription are
+*  delivered to the callbacks of all callers. Unsubscribe removes the callback of the caller, and the server subscription is
+*  unsubscribed when the last caller has unsubscribed.
+*  Only timebased subscriptions are shared, as the server sends the events of a change, range, or curvelog subscription when the value changes,
+*  and a caller that joins a shared one would not get an event until then. */
+type sharedSubscription struct {
+	key string
+	vehicleId VehicleHandle
+	protocol string
+	serviceId uint32  // of the server subscription
+	callbacks map[uint32]func(SubscribeOutput)  // by the serviceId of the caller
+	ready chan struct{}  // closed when out is set
+	out SubscribeOutput  // of the server subscription
+}
+
+var sharedSubscriptionMutex sync.Mutex
+var sharedSubscriptions = make(map[string]*sharedSubscription)  // by key
+var subscribers = make(map[uint32]*sharedSubscription)  // by the serviceId of the caller
+
+func subscribeShared(vehConn *VehicleConnection, path string, filter string, stCredentials string, callback func(SubscribeOutput)) SubscribeOutput {
+	if !isShareableFilter(filter) {
+		return subscribeCore(vehConn.vehicleId, path, "", filter, stCredentials, generateRandomUint32(), callback)
+	}
+	protocol := getSelectedProtocol(vehConn)
+	key := strings.Join([]string{strconv.FormatUint(uint64(vehConn.vehicleId), 10), protocol, path, filter, stCredentials}, "\x00")
+	callerId := generateRandomUint32()
+	sharedSubscriptionMutex.Lock()
+	shared := sharedSubscriptions[key]
+	isNew := shared == nil
+	if isNew {
+		shared = &sharedSubscription{key: key, vehicleId: vehConn.vehicleId, protocol: protocol, serviceId: generateRandomUint32(),
+			callbacks: make(map[uint32]func(SubscribeOutput)), ready: make(chan struct{})}
+		sharedSubscriptions[key] = shared
+	}
+	shared.callbacks[callerId] = callback
+	subscribers[callerId] = shared
+	sharedSubscriptionMutex.Unlock()
+	if isNew {
+		shared.out = subscribeCore(vehConn.vehicleId, path, "", filter, stCredentials, shared.serviceId, shared.fanOut)
+		if shared.out.Status == FAILED {
+			shared.remove()
+		}
+		close(shared.ready)
+	} else {
+		<- shared.ready
+	}
+	out := shared.out
+	if out.Status != FAILED {
+		out.ServiceId = callerId
+	}
+	return out
+}
+
+// isShareableFilter reports whether the filter has a timebased filter, and no filter that is triggered by the value.
+func isShareableFilter(filter string) bool {
+	var filterList []map[string]interface{}
+	if json.Unmarshal([]byte(filter), &filterList) != nil {
+		var filterMap map[string]interface{}
+		if json.Unmarshal([]byte(filter), &filterMap) != nil {
+			return false
+		}
+		filterList = append(filterList, filterMap)
+	}
+	isTimebased := false
+	for _, filterMap := range filterList {
+		switch filterMap["variant"] {
+			case "timebased":
+				isTimebased = true
+			case "change", "range", "curvelog":
+				return false
+		}
+	}
+	return isTimebased
+}
+
+// fanOut is the callback of the server subscription, which calls the callbacks of the callers with their serviceIds.
+func (shared *sharedSubscription) fanOut(out SubscribeOutput) {
+	sharedSubscriptionMutex.Lock()
+	callbacks := make(map[uint32]func(SubscribeOutput), len(shared.callbacks))
+	for callerId, callback := range shared.callbacks {
+		callbacks[callerId] = callback
+	}
+	sharedSubscriptionMutex.Unlock()
+	if out.Status == FAILED {  // the server subscription is terminated
+		shared.remove()
+	}
+	for callerId, callback := range callbacks {
+		event := out
+		event.ServiceId = callerId
+		callback(event)
+	}
+}
+
+func (shared *sharedSubscription) remove() {
+	sharedSubscriptionMutex.Lock()
+	if sharedSubscriptions[shared.key] == shared {
+		delete(sharedSubscriptions, shared.key)
+	}
+	for callerId := range shared.callbacks {
+		delete(subscribers, callerId)
+	}
+	shared.callbacks = make(map[uint32]func(SubscribeOutput))
+	sharedSubscriptionMutex.Unlock()
+}
+
+// isSharedSubscriber reports whether serviceId is of a caller of Subscribe on the vehicle.
+func isSharedSubscriber(vehicleId VehicleHandle, serviceId uint32) bool {
+	sharedSubscriptionMutex.Lock()
+	defer sharedSubscriptionMutex.Unlock()
+	shared := subscribers[serviceId]
+	return shared != nil && shared.vehicleId == vehicleId
+}
+
+/* releaseSharedSubscription removes the caller of serviceId from its shared subscription, and returns the serviceId of the server subscription,
+*  or false if other callers remain subscribed. A serviceId that is not of a caller of Subscribe is returned as is.
+*  It waits until the server subscription is set up, as it could else not be unsubscribed. */
+func releaseSharedSubscription(vehicleId VehicleHandle, serviceId uint32) (uint32, bool) {
+	sharedSubscriptionMutex.Lock()
+	shared := subscribers[serviceId]
+	sharedSubscriptionMutex.Unlock()
+	if shared == nil || shared.vehicleId != vehicleId {
+		return serviceId, true
+	}
+	<- shared.ready
+	sharedSubscriptionMutex.Lock()
+	defer sharedSubscriptionMutex.Unlock()
+	if subscribers[serviceId] != shared {  // the server subscription failed, or the caller is already released
+		return serviceId, true
+	}
+	delete(subscribers, serviceId)
+	delete(shared.callbacks, serviceId)
+	if len(shared.callbacks) > 0 {
+		return 0, false
+	}
+	if sharedSubscriptions[shared.key] == shared {
+		delete(sharedSubscriptions, shared.key)
+	}
+	return shared.serviceId, true
+}
+
+// removeSharedSubscriptions forgets the shared subscriptions of a connection that is removed.
+func removeSharedSubscriptions(vehicleId VehicleHandle, protocol string) {
+	sharedSubscriptionMutex.Lock()
+	var removed []*sharedSubscription
+	for _, shared := range sharedSubscriptions {
+		if shared.vehicleId == vehicleId && shared.protocol == protocol {
+			removed = append(removed, shared)
+		}
+	}
+	sharedSubscriptionMutex.Unlock()
+	for _, shared := range removed {
+		shared.remove()
+	}
+}
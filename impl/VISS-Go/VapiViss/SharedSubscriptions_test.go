@@ -0,0 +1,110 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSharedSubscriptions(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	filter := `{"variant":"timebased","parameter":{"period":"20"}}`
+	eventChan1 := make(chan SubscribeOutput, 100)
+	eventChan2 := make(chan SubscribeOutput, 100)
+	subscribeOut1 := Subscribe(vehicleId, "Vehicle.Speed", filter, "", func(out SubscribeOutput) { eventChan1 <- out })
+	subscribeOut2 := Subscribe(vehicleId, "Vehicle.Speed", filter, "", func(out SubscribeOutput) { eventChan2 <- out })
+	if subscribeOut1.Status != ONGOING || subscribeOut2.Status != ONGOING {
+		t.Fatalf("Subscribe: status=%d/%d", subscribeOut1.Status, subscribeOut2.Status)
+	}
+	if subscribeOut1.ServiceId == subscribeOut2.ServiceId {
+		t.Errorf("the callers have the same serviceId %d", subscribeOut1.ServiceId)
+	}
+	if server.Subscriptions() != 1 {
+		t.Errorf("the server has %d subscriptions, want 1", server.Subscriptions())
+	}
+	event1 := waitFor(t, eventChan1, func(out SubscribeOutput) bool { return out.Status == SUCCESSFUL })
+	event2 := waitFor(t, eventChan2, func(out SubscribeOutput) bool { return out.Status == SUCCESSFUL })
+	if event1.ServiceId != subscribeOut1.ServiceId || event2.ServiceId != subscribeOut2.ServiceId {
+		t.Errorf("the events have the serviceIds %d/%d, want %d/%d", event1.ServiceId, event2.ServiceId, subscribeOut1.ServiceId, subscribeOut2.ServiceId)
+	}
+
+	otherOut := Subscribe(vehicleId, "Vehicle.Speed", `{"variant":"timebased","parameter":{"period":"50"}}`, "", func(out SubscribeOutput) {})
+	if otherOut.Status != ONGOING || server.Subscriptions() != 2 {
+		t.Errorf("a subscription with another filter: status=%d, server subscriptions=%d", otherOut.Status, server.Subscriptions())
+	}
+	Unsubscribe(vehicleId, otherOut.ServiceId)
+
+	if out := Unsubscribe(vehicleId, subscribeOut1.ServiceId); out.Status != SUCCESSFUL {
+		t.Fatalf("Unsubscribe of the first caller: %v", out.Error)
+	}
+	if server.Subscriptions() != 1 {
+		t.Errorf("the server has %d subscriptions after the first Unsubscribe, want 1", server.Subscriptions())
+	}
+	waitFor(t, eventChan2, func(out SubscribeOutput) bool { return out.Status == SUCCESSFUL })
+	if out := Unsubscribe(vehicleId, subscribeOut2.ServiceId); out.Status != SUCCESSFUL {
+		t.Fatalf("Unsubscribe of the second caller: %v", out.Error)
+	}
+	if server.Subscriptions() != 0 {
+		t.Errorf("the server has %d subscriptions after the last Unsubscribe, want 0", server.Subscriptions())
+	}
+}
+
+func TestSharedSubscriptionFailed(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	for i := 0; i < 2; i++ {
+		out := Subscribe(vehicleId, "Vehicle.Unknown", "", "", func(out SubscribeOutput) {})
+		if out.Status != FAILED || out.Error == nil {
+			t.Errorf("Subscribe %d of an unknown path: status=%d", i, out.Status)
+		}
+	}
+	if server.Subscriptions() != 0 {
+		t.Errorf("the server has %d subscriptions", server.Subscriptions())
+	}
+}
+
+func TestChangeSubscriptionsNotShared(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	filter := `{"variant":"change","parameter":{"logic-op":"ne","diff":"0"}}`
+	subscribeOut1 := Subscribe(vehicleId, "Vehicle.Speed", filter, "", func(out SubscribeOutput) {})
+	subscribeOut2 := Subscribe(vehicleId, "Vehicle.Speed", filter, "", func(out SubscribeOutput) {})
+	if subscribeOut1.Status != ONGOING || subscribeOut2.Status != ONGOING {
+		t.Fatalf("Subscribe: status=%d/%d", subscribeOut1.Status, subscribeOut2.Status)
+	}
+	if server.Subscriptions() != 2 {
+		t.Errorf("the server has %d subscriptions, want 2", server.Subscriptions())
+	}
+	Unsubscribe(vehicleId, subscribeOut1.ServiceId)
+	Unsubscribe(vehicleId, subscribeOut2.ServiceId)
+	if server.Subscriptions() != 0 {
+		t.Errorf("the server has %d subscriptions after Unsubscribe, want 0", server.Subscriptions())
+	}
+}
+
+func TestReleasePendingSharedSubscription(t *testing.T) {
+	shared := &sharedSubscription{key: "pending", vehicleId: 1, serviceId: 2, callbacks: map[uint32]func(SubscribeOutput){3: func(SubscribeOutput) {}}, ready: make(chan struct{})}
+	sharedSubscriptionMutex.Lock()
+	sharedSubscriptions[shared.key] = shared
+	subscribers[3] = shared
+	sharedSubscriptionMutex.Unlock()
+	releasedChan := make(chan uint32, 1)
+	go func() {
+		serviceId, _ := releaseSharedSubscription(1, 3)
+		releasedChan <- serviceId
+	}()
+	select {
+		case <- releasedChan:
+			t.Fatalf("released before the server subscription is set up")
+		case <- time.After(50 * time.Millisecond):
+	}
+	close(shared.ready)
+	if serviceId := <- releasedChan; serviceId != 2 {
+		t.Errorf("released serviceId %d, want 2", serviceId)
+	}
+}
@@ -0,0 +1,68 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"testing"
+	"time"
+
+	"VISS-Go/vapi"
+)
+
+func TestSubscribeStream(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	subscribeOut, stream := vapi.SubscribeStream(NewBackend(), vehicleId, "Vehicle.Speed", `{"variant":"timebased","parameter":{"period":"20"}}`, "", 4, vapi.DROP_OLDEST)
+	if subscribeOut.Status != ONGOING || stream == nil {
+		t.Fatalf("SubscribeStream: status=%d, error=%v", subscribeOut.Status, subscribeOut.Error)
+	}
+	events := 0
+	for event := range stream.All() {
+		if event.Status != SUCCESSFUL || len(event.Data) != 1 || event.Data[0].Path != "Vehicle.Speed" {
+			t.Errorf("unexpected event %+v", event)
+		}
+		events++
+		if events == 3 {
+			break
+		}
+	}
+	if server.Subscriptions() != 0 {
+		t.Errorf("the subscription was not unsubscribed when the loop was left")
+	}
+
+	subscribeOut, stream = vapi.SubscribeStream(NewBackend(), vehicleId, "Vehicle.Unknown", "", "", 4, vapi.DROP_OLDEST)
+	if subscribeOut.Status != FAILED || stream != nil {
+		t.Errorf("SubscribeStream of an unknown path: status=%d", subscribeOut.Status)
+	}
+}
+
+func TestServiceStream(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	stream := vapi.NewServiceStream[MoveSeatOutput](1, vapi.COALESCE)
+	moveOut := MoveSeat(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, LONGITUDINAL, 50, "", stream.Send)
+	if moveOut.Status != ONGOING {
+		t.Fatalf("MoveSeat: status=%d, error=%v", moveOut.Status, moveOut.Error)
+	}
+	var final MoveSeatOutput
+	timeout := time.After(10 * time.Second)
+	for done := false; !done; {
+		select {
+			case event, ok := <- stream.C():
+				if !ok {
+					done = true
+					break
+				}
+				final = event
+			case <- timeout:
+				t.Fatalf("the stream of MoveSeat was not closed")
+		}
+	}
+	if final.Status != SUCCESSFUL || final.Position != 50 {
+		t.Errorf("the final event is %+v", final)
+	}
+}
@@ -0,0 +1,57 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ****************** Registered transports ***************
+/* A transport connects a vehicle for a protocol that is not built into VapiViss. It exchanges VISS messages,
+*  so all procedures of VapiViss, e.g. the seating services, are executed over it unchanged.
+*  A transport may translate the VISS messages to another vehicle API, which is how e.g. VapiKuksa uses the KUKSA databroker.
+*  The replay of recordings is a transport for the protocol REPLAY_PROTOCOL. */
+type Transport interface {
+	Send(clientMessage []byte)
+	Receive() <-chan []byte  // the server messages, the channel is closed when the transport terminates
+	Close()
+}
+
+// A TransportDialer connects a transport, socket is the "ipAddress:portNo" of the vehicle, or only the "ipAddress" if the portNo is empty.
+type TransportDialer func(socket string) (Transport, error)
+
+var transportMutex sync.Mutex
+var transportDialers = map[string]TransportDialer{}
+
+func init() {
+	RegisterTransport(REPLAY_PROTOCOL, dialReplay)
+}
+
+// RegisterTransport makes protocol connectable by Connect for the vehicles that support it, see RegisterVehicle.
+func RegisterTransport(protocol string, dialer TransportDialer) {
+	transportMutex.Lock()
+	defer transportMutex.Unlock()
+	transportDialers[protocol] = dialer
+}
+
+func getTransportDialer(protocol string) TransportDialer {
+	transportMutex.Lock()
+	defer transportMutex.Unlock()
+	return transportDialers[protocol]
+}
+
+func dialTransport(protocol string, socket string) (Transport, bool) {
+	transport, err := getTransportDialer(protocol)(socket)
+	if err != nil {
+		fmt.Printf("dialTransport: protocol=%s, socket=%s, error=%s\n", protocol, socket, err)
+		return nil, false
+	}
+	return transport, true
+}
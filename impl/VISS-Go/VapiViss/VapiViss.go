@@ -14,37 +14,72 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"math"
 	"math/rand"
-	"flag"
+	"sync"
 	"net/url"
 	"github.com/gorilla/websocket"
+	"VISS-Go/vapi"
 )
 
-type Percentage float32  // min = 0, max = 100
-
-type MatrixId struct {
-	RowName string
-	ColumnName string
-}
+// The VAPI types are defined by the backend independent vapi package, see Backend.go
+type ProcedureStatus = vapi.ProcedureStatus
+const (
+	ONGOING = vapi.ONGOING
+	SUCCESSFUL = vapi.SUCCESSFUL
+	FAILED = vapi.FAILED
+)
 
-type SeatConfig struct {
-	MovementType string
-	Position Percentage
-}
+type ErrorData = vapi.ErrorData
+type VehicleHandle = vapi.VehicleHandle
+type GetVehicleOutput = vapi.GetVehicleOutput
+type ConnectOutput = vapi.ConnectOutput
+type ServiceSignature = vapi.ServiceSignature
+type ServiceInquiryOutput = vapi.ServiceInquiryOutput
+type GetStCredentialsOutput = vapi.GetStCredentialsOutput
+type InvokeOutput = vapi.InvokeOutput
+type GetMetadataOutput = vapi.GetMetadataOutput
+type GeneralOutput = vapi.GeneralOutput
+type DataPoint = vapi.DataPoint
+type DataContainer = vapi.DataContainer
+type GetOutput = vapi.GetOutput
+type SubscribeOutput = vapi.SubscribeOutput
+
+type Percentage = vapi.Percentage
+type MatrixId = vapi.MatrixId
+type SeatConfig = vapi.SeatConfig
+type MassageOutput = vapi.MassageOutput
+type SeatClimateOutput = vapi.SeatClimateOutput
+type MassageStep = vapi.MassageStep
+type MassageProgramOutput = vapi.MassageProgramOutput
+type MoveSeatOutput = vapi.MoveSeatOutput
+type ConfigureSeatOutput = vapi.ConfigureSeatOutput
+type SupportData = vapi.SupportData
+type ColumnData = vapi.ColumnData
+type RowDef = vapi.RowDef
+type RaggedMatrix = vapi.RaggedMatrix
+type GetPropertiesSeatingOutput = vapi.GetPropertiesSeatingOutput
 
-type ProcedureStatus int8
 const (
-	ONGOING = 1     // in execution of latest call
-	SUCCESSFUL = 0  // terminated successfully in latest call
-	FAILED = -1      // terminated due to failure in latest call
+	LONGITUDINAL = vapi.LONGITUDINAL
+	VERTICAL = vapi.VERTICAL
+	BACKREST = vapi.BACKREST
+	LUMBAR = vapi.LUMBAR
+	FORWARD = vapi.FORWARD
+	BACKWARD = vapi.BACKWARD
+	UP = vapi.UP
+	DOWN = vapi.DOWN
+	INFLATE = vapi.INFLATE
+	DEFLATE = vapi.DEFLATE
+	FORWARD_RECLINE = vapi.FORWARD_RECLINE
+	BACKWARD_RECLINE = vapi.BACKWARD_RECLINE
+	ROLL = vapi.ROLL
+	PULSE = vapi.PULSE
+	WAVE = vapi.WAVE
+	HEATING = vapi.HEATING
+	VENTILATION = vapi.VENTILATION
 )
 
-type ErrorData struct {
-	Code int32
-	Reason string
-	Description string
-}
-
 type ConnectivityData struct {
 	PortNo string
 	Protocol string
@@ -56,6 +91,7 @@ type ActiveService struct {
 	messageId string
 	messageChan chan map[string]interface{}
 	cancelChan chan string
+	cancelOnce sync.Once
 	next *ActiveService
 }
 
@@ -64,6 +100,7 @@ type ConnectedData struct {
 	socket string
 	clientTopic string
 	connHandle interface{}  //*websocket.Conn, *grpc....
+	writeMutex sync.Mutex  // a websocket connection supports one concurrent writer, and services send from their own threads
 	activeService *ActiveService
 	next *ConnectedData
 }
@@ -75,61 +112,12 @@ type VehicleConnection struct {
 	connectivitySupport []ConnectivityData
 	selectedProtocol string
 	connectedData *ConnectedData
+	recorder *sessionRecorder
 	next *VehicleConnection
 }
 
 var vehConnList *VehicleConnection
-
-type VehicleHandle uint32
-
-type GetVehicleOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	VehicleId VehicleHandle
-	Protocol []string
-}
-
-type ConnectOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	LtCredential string
-}
-
-type ServiceSignature struct {
-	Name string
-	Input string
-	Output string
-}
-
-type ServiceInquiryOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	Service []ServiceSignature
-}
-
-type GetStCredentialsOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	StCredentials string
-}
-
-type InvokeOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	ServiceOutput string
-	ServiceId uint32
-}
-
-type GetMetadataOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	Metadata string
-}
-
-type GeneralOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-}
+var connectionMutex sync.Mutex  // guards vehConnList, and the connected data and active service lists of its connections
 
 // ****************** Common services ***************
 func GetVehicle(vehicleGuid string) GetVehicleOutput {
@@ -155,11 +143,14 @@ func GetVehicle(vehicleGuid string) GetVehicleOutput {
 
 func ReleaseVehicle(vehicleId VehicleHandle) GeneralOutput {
 	var out GeneralOutput
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
 	if vehConnList != nil {
 		iterator := &vehConnList
 		for *iterator != nil {
 			if (*iterator).vehicleId == vehicleId {
 				fmt.Printf("Disconnected to vehicle id=%s\n", (*iterator).vehicleGuid)
+				removeShadow(vehicleId)
 				*iterator =(*iterator).next
 				out.Status = SUCCESSFUL
 				return out
@@ -177,7 +168,7 @@ func Connect(vehicleId VehicleHandle, protocol string, clientCredentials string)
 	out.LtCredential = ""  // not implemented
 	out.Status = SUCCESSFUL
 	vehConn := getVehicleConnection(vehicleId)
-	if getConnHandle(vehConn.connectedData, protocol) != nil {
+	if getConnHandle(&vehConn.connectedData, protocol) != nil {
 		return out
 	}
 	matchingIndex := -1
@@ -190,7 +181,10 @@ func Connect(vehicleId VehicleHandle, protocol string, clientCredentials string)
 	if matchingIndex >= 0 {
 		var connectedData ConnectedData
 		connectedData.protocol = protocol
-		connectedData.socket = vehConn.ipAddress + ":" + vehConn.connectivitySupport[matchingIndex].PortNo
+		connectedData.socket = vehConn.ipAddress
+		if vehConn.connectivitySupport[matchingIndex].PortNo != "" { // no port e.g. for the replay protocol, where the "ipAddress" is the recording file
+			connectedData.socket += ":" + vehConn.connectivitySupport[matchingIndex].PortNo
+		}
 		if strings.Contains(protocol, "mqtt") || strings.Contains(protocol, "MQTT") {
 			connectedData.clientTopic = generateRandomString()  //needed for VISSv3.0-mqtt
 		}
@@ -198,7 +192,7 @@ func Connect(vehicleId VehicleHandle, protocol string, clientCredentials string)
 		connectedData.connHandle, isConnected = connectToVehicle(protocol, connectedData.socket)
 		if isConnected {
 			addConnectedData(&(vehConn.connectedData), &connectedData)
-			vehConn.selectedProtocol = protocol
+			setSelectedProtocol(vehConn, protocol)
 			go initReceiveMessage(vehConn, protocol)
 		} else {
 			out.Error = getErrorObject(502, "bad_gateway", "Protocol not supported")
@@ -220,13 +214,12 @@ func Disconnect(vehicleId VehicleHandle, protocol string) GeneralOutput {
 		return out
 	}
 	for {
-		serviceId := getActiveServiceId(vehConn.connectedData, protocol)
+		serviceId := getActiveServiceId(&vehConn.connectedData, protocol)
 //fmt.Printf("Disconnect: serviceId = %d\n", serviceId)
 		if serviceId == 0 {
 			break
 		}
-		Unsubscribe(vehicleId, serviceId)
-		removeActiveService(&vehConn.connectedData, protocol, serviceId)
+		CancelService(vehicleId, serviceId)
 	}
 	removeConnection(&vehConn, protocol)
 	out.Status = SUCCESSFUL
@@ -239,8 +232,8 @@ func SelectProtocol(vehicleId VehicleHandle, protocol string) GeneralOutput {
 	if vehConn != nil {
 		for i := 0; i<len(vehConn.connectivitySupport); i++ {
 			if vehConn.connectivitySupport[i].Protocol == protocol {
-				if getConnHandle(vehConn.connectedData, protocol) != nil {
-					vehConn.selectedProtocol = protocol
+				if getConnHandle(&vehConn.connectedData, protocol) != nil {
+					setSelectedProtocol(vehConn, protocol)
 					out.Status = SUCCESSFUL
 					return out
 				}
@@ -260,6 +253,12 @@ func GetMetadata(vehicleId VehicleHandle, path string, stCredentials string) Get
 		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
 		return out
 	}
+	if errorData := validatePath(path, ""); errorData != nil {
+		var out GetMetadataOutput
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
 	filterParam := `", "filter": {"variant":"metadata", "parameter":"0"}`
 	stCredParam := ""
 	if stCredentials != "" {
@@ -267,11 +266,17 @@ func GetMetadata(vehicleId VehicleHandle, path string, stCredentials string) Get
 	}
 	serviceId := generateRandomUint32()
 	requestId := generateRandomString()
-	messageChan := addActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId, requestId, "")
+	messageChan := addActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, requestId, "")
+	if messageChan == nil {
+		var out GetMetadataOutput
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
 	clientMessage := `{"action":"get", "path":"` + path + filterParam + stCredParam + `, "requestId":"` + requestId + `"}`
 	sendMessage(vehConn, "", clientMessage)
 	responseMap := <- messageChan
-	removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+	removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
 	return reformatOutput(responseMap, "getmetadata").(GetMetadataOutput)
 }
 
@@ -314,17 +319,29 @@ func Set(vehicleId VehicleHandle, path string, value string, stCredentials strin
 		out.Error = getErrorObject(400, "invalid_data", "missing value")
 		return out
 	}
+	if errorData := validateSetValue(path, value); errorData != nil {
+		var out GeneralOutput
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
 	stCredParam := ""
 	if stCredentials != "" {
 		stCredParam = `, "authorization":"` + stCredentials + "\""
 	}
 	serviceId := generateRandomUint32()
 	requestId := generateRandomString()
-	messageChan := addActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId, requestId, "")
+	messageChan := addActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, requestId, "")
+	if messageChan == nil {
+		var out GeneralOutput
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
 	clientMessage := `{"action":"set", "path":"` + path  + `", "value":"` + value + "\"" + stCredParam + `, "requestId":"` + requestId + `"}`
 	sendMessage(vehConn, "", clientMessage)
 	responseMap := <- messageChan
-	removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+	removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
 	return reformatOutput(responseMap, "set").(GeneralOutput)
 }
 
@@ -336,6 +353,11 @@ func Get(vehicleId VehicleHandle, path string, filter string, stCredentials stri
 		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
 		return out
 	}
+	if errorData := validatePath(path, filter); errorData != nil {
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
 	filterParam := ""
 	if filter != "" {
 		filterParam = `, "filter":` + filter
@@ -346,17 +368,30 @@ func Get(vehicleId VehicleHandle, path string, filter string, stCredentials stri
 	}
 	serviceId := generateRandomUint32()
 	requestId := generateRandomString()
-	messageChan := addActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId, requestId, "")
+	messageChan := addActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, requestId, "")
+	if messageChan == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
 	clientMessage := `{"action":"get", "path":"` + path + "\"" + filterParam + stCredParam + `, "requestId":"` + requestId + `"}`
 	sendMessage(vehConn, "", clientMessage)
 	responseMap := <- messageChan
-	removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
-	return reformatOutput(responseMap, "get").(GetOutput)
+	removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
+	out = reformatOutput(responseMap, "get").(GetOutput)
+	updateShadow(vehicleId, out.Data)
+	return out
 }
 
 func Subscribe(vehicleId VehicleHandle, path string, filter string, stCredentials string, callback func(SubscribeOutput)) SubscribeOutput {
-	serviceId := generateRandomUint32()
-	return subscribeCore(vehicleId, path, "", filter, stCredentials, serviceId, callback)
+	vehConn := getVehicleConnection(vehicleId)
+	if vehConn == nil {
+		var out SubscribeOutput
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
+	return subscribeShared(vehConn, path, filter, stCredentials, callback)
 }
 
 func subscribeCore(vehicleId VehicleHandle, path string, cancelValue string, filter string, stCredentials string, serviceId uint32, callback func(SubscribeOutput)) SubscribeOutput {
@@ -367,6 +402,11 @@ func subscribeCore(vehicleId VehicleHandle, path string, cancelValue string, fil
 		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
 		return out
 	}
+	if errorData := validatePath(path, filter); errorData != nil {
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
 	filterParam := ""
 	if filter != "" {
 		filterParam = `, "filter":` + filter
@@ -376,18 +416,23 @@ func subscribeCore(vehicleId VehicleHandle, path string, cancelValue string, fil
 		stCredParam = `, "authorization":"` + stCredentials + "\""
 	}
 	requestId := generateRandomString()
-	messageChan := addActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId, requestId, "")
+	messageChan := addActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, requestId, "")
+	if messageChan == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
 	message := `{"action":"subscribe", "path":"` + path + "\"" + filterParam + stCredParam + `, "requestId":"` + requestId + `"}`
 	sendMessage(vehConn, "", message)
 	messageMap := <- messageChan
 	if messageMap["error"] != nil {
-		removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+		removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
 		return reformatOutput(messageMap, "subscribe").(SubscribeOutput)
 	}
 	cancelChan := make(chan string)
-	ok := saveCancelHandle(&vehConn.connectedData, vehConn.selectedProtocol, serviceId, messageMap["subscriptionId"].(string), cancelChan)
+	ok := saveCancelHandle(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, messageMap["subscriptionId"].(string), cancelChan)
 	if !ok {
-		removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+		removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
 		out.Status = FAILED
 		out.Error = getErrorObject(502, "bad_gateway", "Server internal error")
 		return out
@@ -398,13 +443,14 @@ func subscribeCore(vehicleId VehicleHandle, path string, cancelValue string, fil
 			case messageMap = <- messageChan:
 			messageMap["serviceId"] = serviceId
 			out := reformatOutput(messageMap, "subscribe").(SubscribeOutput)
+			updateShadow(vehicleId, out.Data)
 			callback(out)
 			if messageMap["error"] != nil {
-				removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+				removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
 				return
 			}
 			case <- cancelChan:
-				removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+				removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
 				return
 			}
 		}
@@ -423,14 +469,32 @@ func Unsubscribe(vehicleId VehicleHandle, serviceId uint32) GeneralOutput {
 		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
 		return out
 	}
+	serviceId, isLast := releaseSharedSubscription(vehicleId, serviceId)
+	if !isLast {  // the server subscription is shared with other callers
+		var out GeneralOutput
+		out.Status = SUCCESSFUL
+		return out
+	}
 	protocol := getProtocol(&vehConn.connectedData, serviceId)
-	subscriptionId := getCancelData(vehConn.connectedData, protocol, serviceId)
+	if protocol == "" {
+		var out GeneralOutput
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "unknown serviceId")
+		return out
+	}
+	subscriptionId := getCancelData(&vehConn.connectedData, protocol, serviceId)
 	removeActiveService(&vehConn.connectedData, protocol, serviceId)
 	requestId := generateRandomString()
 	serviceId = generateRandomUint32()
 	clientMessage := `{"action":"unsubscribe", "subscriptionId":"` + subscriptionId + `", "requestId":"` + requestId + `"}`
 	responseChan := addActiveService(&vehConn.connectedData, protocol, serviceId, requestId, "")
-	sendMessage(vehConn, "", clientMessage)
+	if responseChan == nil {
+		var out GeneralOutput
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
+	sendMessage(vehConn, protocol, clientMessage)
 	responseMap := <- responseChan
 	removeActiveService(&vehConn.connectedData, protocol, serviceId)
 	return reformatOutput(responseMap, "unsubscribe").(GeneralOutput)
@@ -444,111 +508,39 @@ func CancelService(vehicleId VehicleHandle, serviceId uint32) GeneralOutput {
 		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
 		return out
 	}
-	Unsubscribe(vehicleId, serviceId)
+	if isSharedSubscriber(vehicleId, serviceId) {
+		return Unsubscribe(vehicleId, serviceId)
+	}
+	protocol := getProtocol(&vehConn.connectedData, serviceId)
+	activeService := getActiveService(&vehConn.connectedData, protocol, serviceId)
+	if activeService == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "unknown serviceId")
+		return out
+	}
+	if isLocalService(activeService.name) {
+		removeActiveService(&vehConn.connectedData, protocol, serviceId)
+	} else {
+		Unsubscribe(vehicleId, serviceId)
+	}
+	if activeService.cancelChan != nil {
+		activeService.cancelOnce.Do(func() { close(activeService.cancelChan) })
+	}
 	out.Status = SUCCESSFUL
 	return out
 }
 
 // ****************** Seat services ***************
-// constants for the different seat movement types
-const (
-	LONGITUDINAL = "longitudinal" //Forward-backward direction of the vehicle
-	VERTICAL = "vertical"         // Up-down direction of the vehicle
-	BACKREST = "backrest"         // Seat backrest angular
-	LUMBAR = "lumbar"             // Seat inflate-deflate lumbar
-)
-
-/* constants for asynchronous seat movements; invoke MoveSeat using one of the constants together with its associated movement type,
-*  then terminate the movement by invoking CancelService */
-const (
-	FORWARD = 0            //longitudinal movement
-	BACKWARD = 100         //longitudinal movement
-	UP = 100               //vertical movement
-	DOWN = 0               //vertical movement
-	INFLATE = 100          //lumbar movement
-	DEFLATE = 0            //lumbar movement
-	FORWARD_RECLINE = 0    //backrest movement
-	BACKWARD_RECLINE = 100 //backrest movement
-)
-
-// constants for massage support
-const (
-	ROLL = "roll"
-	PULSE = "pulse"
-	WAVE = "wave"
-)
-
-type DataPoint struct {
-	Value string
-	Timestamp string
-}
-
-type DataContainer struct {
-	Path string
-	Dp []DataPoint
-}
-
-type GetOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	Data []DataContainer
-}
-
-type SubscribeOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	Data []DataContainer
-	ServiceId uint32
-}
+const MASSAGE = "massage" // arbitration resource type of the massage services
 
-type MassageOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	ServiceId uint32
-}
-
-type MoveSeatOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	Position Percentage
-	ServiceId uint32
-}
-
-type ConfigureSeatOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	Configured []SeatConfig
-	Unconfigured []string
-	ServiceId uint32
-}
+const CLIMATE = "climate" // arbitration resource type of the seat climate services, heating and ventilation share it
 
-type SupportData struct {
-	Name string
-	Description string
-}
+const MASSAGE_PROGRAM = "massageprogram" // name prefix of active massage program services
 
-type ColumnData struct {
-	Name string
-	MovementSupport []SupportData
-	MassageSupport []SupportData
-}
-
-type RowDef struct {
-	RowName string
-	Column []ColumnData
-}
-
-type RaggedMatrix []RowDef
-
-type GetPropertiesSeatingOutput struct {
-	Status ProcedureStatus
-	Error *ErrorData
-	Properties RaggedMatrix
-}
+const CONFIGURE_SEAT = "configureseat" // name prefix of active seat configuration services
 
 func MoveSeat(vehicleId VehicleHandle, seatId MatrixId, movementType string, position Percentage, stCredentials string, callback func(MoveSeatOutput)) MoveSeatOutput {
 	var out MoveSeatOutput
-	var actuatorPath string
 	vehConn := getVehicleConnection(vehicleId)
 	if vehConn == nil {
 		out.Status = FAILED
@@ -565,31 +557,31 @@ func MoveSeat(vehicleId VehicleHandle, seatId MatrixId, movementType string, pos
 		out.Status = FAILED
 		return out
 	}
-	if isMoving(&vehConn.connectedData, vehConn.selectedProtocol, seatId, movementType) {
+	if isMoving(&vehConn.connectedData, getSelectedProtocol(vehConn), seatId, movementType) {
 		out.Error = getErrorObject(503, "service_unavailable", "Movement type is busy for this seat")
 		out.Status = FAILED
 		return out
 	}
-	var A, B Percentage
-	switch movementType {
-		case LONGITUDINAL:
-			actuatorPath = getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Position", seatId)
-			A = 3
-			B = 0
-//			position = 3 * position  //in millimeter, 300 mm dynamic range??
-		case LUMBAR:
-			A = 1
-			B = 0
-			actuatorPath = getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Backrest.Lumbar.Support", seatId)
-		case BACKREST:
-			A = 0.9
-			B = -45
-			actuatorPath = getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Backrest.Recline", seatId)
-//			position = 0.9 * position - 45 //transform position to degrees; f(x) = 0.9*x - 45 f(0)=-45; f(100)=45 ??
-		default:
-			out.Error = getErrorObject(400, "invalid_data", "unknown movementType")
-			out.Status = FAILED
-			return out
+	preconditions := getSeatPreconditions("MoveSeat", seatId)
+	errorData := checkPreconditions(vehicleId, preconditions, stCredentials)
+	if errorData != nil {
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	preemptChan := make(chan *ErrorData, 1)
+	leaseId, errorData := acquireServiceLease(vehConn, createSeatResourceName(seatId, movementType), preemptChan)
+	if errorData != nil {
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	actuatorPath, A, B := getSeatActuatorData(movementType, seatId)
+	if actuatorPath == "" {
+		out.Error = getErrorObject(400, "invalid_data", "unknown movementType")
+		out.Status = FAILED
+		releaseServiceLease(leaseId)
+		return out
 	}
 	position = A * position + B
 	posStr := strconv.FormatFloat(float64(position), 'f', -1, 32)
@@ -603,15 +595,25 @@ func MoveSeat(vehicleId VehicleHandle, seatId MatrixId, movementType string, pos
 	if setOut.Status == FAILED {
 		out.Status = FAILED
 		out.Error = setOut.Error
+		releaseServiceLease(leaseId)
 		return out
 	}
 	getOut := Get(vehicleId, actuatorPath, "", stCredentials)
 	if getOut.Status == FAILED {
 		out.Status = FAILED
 		out.Error = getOut.Error
+		releaseServiceLease(leaseId)
 		return out
 	}
-	currPos, _ := strconv.Atoi(getOut.Data[0].Dp[0].Value)
+	if len(getOut.Data) == 0 || len(getOut.Data[0].Dp) == 0 {
+		out.Status = FAILED
+		out.Error = getErrorObject(502, "bad_gateway", "Server returned no data for " + actuatorPath)
+		releaseServiceLease(leaseId)
+		return out
+	}
+	targetValue, _ := strconv.ParseFloat(posStr, 32)
+	currPosStr := getOut.Data[0].Dp[0].Value
+	currPos, _ := strconv.ParseFloat(currPosStr, 32)
 	out.Position = (Percentage(currPos)-B)/A
 	out.Status = ONGOING
 	serviceId := generateRandomUint32()
@@ -624,24 +626,42 @@ func MoveSeat(vehicleId VehicleHandle, seatId MatrixId, movementType string, pos
 		stCredParam = `, "authorization":"` + stCredentials + "\""
 	}
 	message := `{"action":"subscribe", "path":"` + actuatorPath + "\"" + filterParam + stCredParam + `, "requestId":"` + requestId + `"}`
-	messageChan := addActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId, requestId, createMoveSeatName(movementType, seatId))
+	messageChan := addActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, requestId, createMoveSeatName(movementType, seatId))
+	if messageChan == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		releaseServiceLease(leaseId)
+		return out
+	}
 	sendMessage(vehConn, "", message)
 	messageMap := <- messageChan
 	if messageMap["error"] != nil {
-		removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+		removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
 		out.Status = FAILED
 		out.Error = getErrorInfo(messageMap["error"].(map[string]interface{}))
+		releaseServiceLease(leaseId)
 		return out
 	}
 	cancelChan := make(chan string)
-	ok := saveCancelHandle(&vehConn.connectedData, vehConn.selectedProtocol, serviceId, messageMap["subscriptionId"].(string), cancelChan)
+	ok := saveCancelHandle(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, messageMap["subscriptionId"].(string), cancelChan)
 	if !ok {
-		removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+		removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
 		out.Status = FAILED
 		out.Error = getErrorObject(502, "bad_gateway", "Server internal error")
+		releaseServiceLease(leaseId)
 		return out
 	}
-	go func() {
+	violationChan := make(chan *ErrorData, 1)
+	monitorIds, errorData := startPreconditionMonitor(vehicleId, preconditions, stCredentials, violationChan)
+	if errorData != nil {
+		Set(vehicleId, actuatorPath, currPosStr, stCredentials) // stop the movement at the current position
+		Unsubscribe(vehicleId, serviceId)
+		releaseServiceLease(leaseId)
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	go func(out MoveSeatOutput) {  // a copy, as the returned output must not be written by the thread
 		for {
 			select {
 			case messageMap = <- messageChan:
@@ -651,25 +671,56 @@ func MoveSeat(vehicleId VehicleHandle, seatId MatrixId, movementType string, pos
 				} else {
 					out.Status = ONGOING
 					data := populateData(messageMap["data"])
-					currPos, _ := strconv.Atoi(data[0].Dp[0].Value)
-					out.Position = (Percentage(currPos)-B)/A
-					if out.Position >= 100 {
-						out.Status = SUCCESSFUL
+					if len(data) > 0 && len(data[0].Dp) > 0 {
+						currPosStr = data[0].Dp[0].Value
+						currPos, _ := strconv.ParseFloat(currPosStr, 32)
+						out.Position = (Percentage(currPos)-B)/A
+						if math.Abs(currPos - targetValue) < 1 { // target reached, within the resolution of the actuator
+							out.Status = SUCCESSFUL
+						}
 					}
 				}
 				if callback != nil {
 					callback(out)
 				}
 				if messageMap["error"] != nil || out.Status == SUCCESSFUL {
+					stopPreconditionMonitor(vehicleId, monitorIds)
 					Unsubscribe(vehicleId, serviceId)
-					removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+					releaseServiceLease(leaseId)
 					return
 				}
+			case errorData := <- violationChan:
+				Set(vehicleId, actuatorPath, currPosStr, stCredentials) // stop the movement at the current position
+				out.Status = FAILED
+				out.Error = errorData
+				if callback != nil {
+					callback(out)
+				}
+				stopPreconditionMonitor(vehicleId, monitorIds)
+				Unsubscribe(vehicleId, serviceId)
+				releaseServiceLease(leaseId)
+				return
+			case errorData := <- preemptChan: // the new lease holder takes over the actuator
+				out.Status = FAILED
+				out.Error = errorData
+				if callback != nil {
+					callback(out)
+				}
+				stopPreconditionMonitor(vehicleId, monitorIds)
+				Unsubscribe(vehicleId, serviceId)
+				return
 			case <- cancelChan:
+				stopPreconditionMonitor(vehicleId, monitorIds)
+				releaseServiceLease(leaseId)
+				out.Status = FAILED
+				out.Error = getCancelledError()
+				if callback != nil {
+					callback(out)
+				}
 				return
 			}
 		}
-	}()
+	}(out)
 	return out
 }
 
@@ -686,28 +737,55 @@ func ConfigureSeat(vehicleId VehicleHandle, seatId MatrixId, configuration []Sea
 		respOut.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
 		return respOut
 	}
+	errorData := checkPreconditions(vehicleId, getSeatPreconditions("ConfigureSeat", seatId), stCredentials)
+	if errorData != nil {
+		respOut.Status = FAILED
+		respOut.Error = errorData
+		return respOut
+	}
+	serviceId := generateRandomUint32()
+	protocol := getSelectedProtocol(vehConn)
+	addActiveService(&vehConn.connectedData, protocol, serviceId, generateRandomString(), createConfigureSeatName(seatId))
+	cancelChan := make(chan string)
+	if !saveCancelHandle(&vehConn.connectedData, protocol, serviceId, generateRandomString(), cancelChan) {
+		removeActiveService(&vehConn.connectedData, protocol, serviceId)
+		respOut.Status = FAILED
+		respOut.Error = getErrorObject(502, "bad_gateway", "Server internal error")
+		return respOut
+	}
+	respOut.ServiceId = serviceId
 	go func() {
 		var eventOut ConfigureSeatOutput
 		eventOut.Status = ONGOING
+		eventOut.ServiceId = serviceId
+		var moveMutex sync.Mutex  // moveOut is written by the callbacks of the movements
 		moveOut := make([]MoveSeatOutput, len(configuration))
 		var mapData []MapData
+		var startError *ErrorData  // of the first movement that failed to start
 
-		moveSeatCb := func(cbOut MoveSeatOutput) {
-			confIndex := findConfIndex(cbOut.ServiceId, moveOut)
-			if confIndex != -1 {
-				moveOut[confIndex].Status = cbOut.Status
-				moveOut[confIndex].Error = cbOut.Error
-				moveOut[confIndex].Position = cbOut.Position
+		moveSeatCb := func(confIndex int) func(MoveSeatOutput) {
+			return func(cbOut MoveSeatOutput) {
+				moveMutex.Lock()
+				moveOut[confIndex] = cbOut
+				moveMutex.Unlock()
 			}
 		}
 
 		for i := 0; i < len(configuration); i++ {
 			if isSupportedMovement(seatId, configuration[i].MovementType) {
-				moveOut[i] = MoveSeat(vehicleId, seatId, configuration[i].MovementType, configuration[i].Position, stCredentials, moveSeatCb)
-				if moveOut[i].Status != FAILED {
+				startOut := MoveSeat(vehicleId, seatId, configuration[i].MovementType, configuration[i].Position, stCredentials, moveSeatCb(i))
+				if startOut.Status != FAILED {
+					moveMutex.Lock()
+					if moveOut[i].ServiceId == 0 {  // else a callback already arrived
+						moveOut[i] = startOut
+					}
+					moveMutex.Unlock()
 					eventOut.Configured = append(eventOut.Configured, configuration[i])
-					mapData = append(mapData, MapData{moveOut[i].ServiceId, i})
+					mapData = append(mapData, MapData{startOut.ServiceId, i})
 				} else {
+					if startError == nil {
+						startError = startOut.Error
+					}
 					eventOut.Unconfigured = append(eventOut.Unconfigured, configuration[i].MovementType)
 				}
 			} else {
@@ -716,21 +794,54 @@ func ConfigureSeat(vehicleId VehicleHandle, seatId MatrixId, configuration []Sea
 		}
 		done := false
 		for !done {
-			for i :=0; i < len(moveOut); i++ {
-				if moveOut[i].Status == ONGOING {
-					for i := 0; i < len(eventOut.Configured); i++ {
-						eventOut.Configured[i].Position = getMoveTypePosition(mapData[i].serviceId, moveOut)
-					}
-					eventOut.Status = ONGOING
-					break
+			ongoing := false
+			var failure *ErrorData
+			moveMutex.Lock()
+			for i := 0; i < len(mapData); i++ {
+				eventOut.Configured[i].Position = moveOut[mapData[i].index].Position
+				switch moveOut[mapData[i].index].Status {
+					case ONGOING: ongoing = true
+					case FAILED: failure = moveOut[mapData[i].index].Error
 				}
 			}
-			if seatConfigComplete(eventOut, configuration, mapData) {
+			moveMutex.Unlock()
+			if len(mapData) == 0 { // no movement was started
+				eventOut.Status = FAILED
+				eventOut.Error = startError
+				if eventOut.Error == nil {
+					eventOut.Error = getErrorObject(400, "invalid_data", "No movement of the configuration is supported by the seat")
+				}
+				done = true
+			} else if seatConfigComplete(eventOut, configuration, mapData) || (!ongoing && failure == nil) {
 				eventOut.Status = SUCCESSFUL
 				done = true
+			} else if !ongoing { // a movement terminated without reaching its position
+				eventOut.Status = FAILED
+				eventOut.Error = failure
+				done = true
+			}
+			if done {
+				removeActiveService(&vehConn.connectedData, protocol, serviceId)
+			}
+			if callback != nil {
+				callback(eventOut)
+			}
+			if done {
+				return
+			}
+			select {
+				case <- cancelChan:
+					for i := 0; i < len(mapData); i++ {
+						CancelService(vehicleId, mapData[i].serviceId)
+					}
+					eventOut.Status = FAILED
+					eventOut.Error = getCancelledError()
+					if callback != nil {
+						callback(eventOut)
+					}
+					return
+				case <- time.After(1 * time.Second):
 			}
-			callback(eventOut)
-			time.Sleep(1 * time.Second)
 		}
 	}()
 	respOut.Status = ONGOING
@@ -738,6 +849,11 @@ func ConfigureSeat(vehicleId VehicleHandle, seatId MatrixId, configuration []Sea
 }
 
 func ActivateMassage(vehicleId VehicleHandle, seatId MatrixId, massageType string, intensity Percentage, duration uint32, stCredentials string, callback func(MassageOutput)) MassageOutput {
+	return activateMassage(vehicleId, seatId, massageType, intensity, duration, stCredentials, callback, false)
+}
+
+// activateMassage executes a massage, isLeased is true for the steps of a massage program, which holds the lease of the massage for all its steps.
+func activateMassage(vehicleId VehicleHandle, seatId MatrixId, massageType string, intensity Percentage, duration uint32, stCredentials string, callback func(MassageOutput), isLeased bool) MassageOutput {
 	var out MassageOutput
 	vehConn := getVehicleConnection(vehicleId)
 	if vehConn == nil {
@@ -755,27 +871,334 @@ func ActivateMassage(vehicleId VehicleHandle, seatId MatrixId, massageType strin
 		out.Status = FAILED
 		return out
 	}
+	preconditions := getSeatPreconditions("ActivateMassage", seatId)
+	errorData := checkPreconditions(vehicleId, preconditions, stCredentials)
+	if errorData != nil {
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	preemptChan := make(chan *ErrorData, 1)
+	var leaseId uint32
+	if !isLeased {
+		leaseId, errorData = acquireServiceLease(vehConn, createSeatResourceName(seatId, MASSAGE), preemptChan)
+		if errorData != nil {
+			out.Status = FAILED
+			out.Error = errorData
+			return out
+		}
+	}
 	massageOnPath := getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Switch.Massage.IsOn", seatId)
 	intensityPath := getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Switch.Massage.Intensity", seatId)
 	massageTypePath := getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Switch.Massage.MassageType", seatId)
 
 	intensityStr := strconv.FormatFloat(float64(intensity), 'f', -1, 32)
-	setOut := Set(vehicleId, intensityPath, intensityStr, stCredentials)
+	setOut := SetMany(vehicleId, []PathValue{{intensityPath, intensityStr}, {massageTypePath, massageType}, {massageOnPath, "true"}}, stCredentials)
 	if setOut.Status == FAILED {
 		out.Status = FAILED
 		out.Error = setOut.Error
+		releaseServiceLease(leaseId)
 		return out
 	}
-	setOut = Set(vehicleId, massageTypePath, massageType, stCredentials)
-	if setOut.Status == FAILED {
+	serviceId := generateRandomUint32()
+	out.ServiceId = serviceId
+	requestId := generateRandomString()
+	if duration == 0 || duration > 24 * 3600 {
+		duration = 24 * 3600  //24 hours limit
+	}
+	filter := `{"variant":"timebased","parameter":{"period":"1000"}}`
+	filterParam := `, "filter":` + filter
+	stCredParam := ""
+	if stCredentials != "" {
+		stCredParam = `, "authorization":"` + stCredentials + "\""
+	}
+	message := `{"action":"subscribe", "path":"` + massageOnPath + "\"" + filterParam + stCredParam + `, "requestId":"` + requestId + `"}`
+	messageChan := addActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, requestId, "")
+	if messageChan == nil {
 		out.Status = FAILED
-		out.Error = setOut.Error
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		releaseServiceLease(leaseId)
+		return out
+	}
+	sendMessage(vehConn, "", message)
+	messageMap := <- messageChan
+	if messageMap["error"] != nil {
+		removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
+		out.Status = FAILED
+		out.Error = getErrorInfo(messageMap["error"].(map[string]interface{}))
+		releaseServiceLease(leaseId)
+		return out
+	}
+	cancelChan := make(chan string)
+	ok := saveCancelHandle(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, messageMap["subscriptionId"].(string), cancelChan)
+	if !ok {
+		removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
+		out.Status = FAILED
+		out.Error = getErrorObject(502, "bad_gateway", "Server internal error")
+		releaseServiceLease(leaseId)
+		return out
+	}
+	violationChan := make(chan *ErrorData, 1)
+	monitorIds, errorData := startPreconditionMonitor(vehicleId, preconditions, stCredentials, violationChan)
+	if errorData != nil {
+		Set(vehicleId, massageOnPath, "false", stCredentials)
+		Unsubscribe(vehicleId, serviceId)
+		releaseServiceLease(leaseId)
+		out.Status = FAILED
+		out.Error = errorData
 		return out
 	}
-	setOut = Set(vehicleId, massageOnPath, "true", stCredentials)
+	go func(out MassageOutput) {  // a copy, as the returned output must not be written by the thread
+		finalTime := time.Now().Add(time.Duration(float64(duration)*1e9))
+		for {
+			select {
+			case messageMap = <- messageChan:
+				if messageMap["error"] != nil {
+					out.Status = FAILED
+					out.Error = getErrorInfo(messageMap["error"].(map[string]interface{}))
+				} else {
+					out.Status = ONGOING
+					data := populateData(messageMap["data"])
+					massageOn := ""
+					if len(data) > 0 && len(data[0].Dp) > 0 {
+						massageOn = data[0].Dp[0].Value
+					}
+					if massageOn == "false" || time.Now().After(finalTime) {
+						out.Status = SUCCESSFUL
+					}
+				}
+				if callback != nil {
+					callback(out)
+				}
+				if messageMap["error"] != nil || out.Status == SUCCESSFUL {
+					stopPreconditionMonitor(vehicleId, monitorIds)
+					Unsubscribe(vehicleId, serviceId)
+					releaseServiceLease(leaseId)
+					return
+				}
+			case errorData := <- violationChan:
+				Set(vehicleId, massageOnPath, "false", stCredentials)
+				out.Status = FAILED
+				out.Error = errorData
+				if callback != nil {
+					callback(out)
+				}
+				stopPreconditionMonitor(vehicleId, monitorIds)
+				Unsubscribe(vehicleId, serviceId)
+				releaseServiceLease(leaseId)
+				return
+			case errorData := <- preemptChan: // the new lease holder takes over the massage
+				out.Status = FAILED
+				out.Error = errorData
+				if callback != nil {
+					callback(out)
+				}
+				stopPreconditionMonitor(vehicleId, monitorIds)
+				Unsubscribe(vehicleId, serviceId)
+				return
+			case <- cancelChan:
+				stopPreconditionMonitor(vehicleId, monitorIds)
+				releaseServiceLease(leaseId)
+				out.Status = FAILED
+				out.Error = getCancelledError()
+				if callback != nil {
+					callback(out)
+				}
+				return
+			}
+		}
+	}(out)
+	out.Status = ONGOING
+	return out
+}
+
+/* A massage program executes a sequence of massage steps back to back. It is a local service, i.e. it has no server side subscription,
+*  the steps are executed as massages under one lease of the massage of the seat, which the program holds until it terminates.
+*  The program is terminated by invoking CancelService with the returned ServiceId, which ends it with a final callback with the status FAILED. */
+func ActivateMassageProgram(vehicleId VehicleHandle, seatId MatrixId, steps []MassageStep, stCredentials string, callback func(MassageProgramOutput)) MassageProgramOutput {
+	var out MassageProgramOutput
+	vehConn := getVehicleConnection(vehicleId)
+	if vehConn == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
+	if len(steps) == 0 {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "missing program steps")
+		return out
+	}
+	for i := 0; i < len(steps); i++ {
+		if steps[i].Intensity < 0 || steps[i].Intensity > 100 {
+			out.Error = getErrorObject(400, "invalid_data", "intensity out of range in step " + strconv.Itoa(i))
+			out.Status = FAILED
+			return out
+		}
+		if steps[i].Duration == 0 {
+			out.Error = getErrorObject(400, "invalid_data", "missing duration in step " + strconv.Itoa(i))
+			out.Status = FAILED
+			return out
+		}
+		if !checkSupport(seatId, steps[i].MassageType, "massage") {
+			out.Error = getErrorObject(400, "invalid_data", "Massage type not supported for this seat in step " + strconv.Itoa(i))
+			out.Status = FAILED
+			return out
+		}
+	}
+	programName := createMassageProgramName(seatId)
+	if isActiveServiceName(&vehConn.connectedData, getSelectedProtocol(vehConn), programName) {
+		out.Error = getErrorObject(503, "service_unavailable", "Massage program is busy for this seat")
+		out.Status = FAILED
+		return out
+	}
+	preemptChan := make(chan *ErrorData, 1)
+	leaseId, errorData := acquireServiceLease(vehConn, createSeatResourceName(seatId, MASSAGE), preemptChan)
+	if errorData != nil {
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	serviceId := generateRandomUint32()
+	out.ServiceId = serviceId
+	protocol := getSelectedProtocol(vehConn)
+	addActiveService(&vehConn.connectedData, protocol, serviceId, generateRandomString(), programName)
+	cancelChan := make(chan string)
+	if !saveCancelHandle(&vehConn.connectedData, protocol, serviceId, generateRandomString(), cancelChan) {
+		removeActiveService(&vehConn.connectedData, protocol, serviceId)
+		releaseServiceLease(leaseId)
+		out.Status = FAILED
+		out.Error = getErrorObject(502, "bad_gateway", "Server internal error")
+		return out
+	}
+	massageOnPath := getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Switch.Massage.IsOn", seatId)
+	go func(out MassageProgramOutput) {  // a copy, as the returned output must not be written by the thread
+		doneChan := make(chan struct{})
+		defer close(doneChan)
+		stepChan := make(chan MassageOutput)
+		stepCb := func(stepOut MassageOutput) {
+			select {
+				case stepChan <- stepOut:
+				case <- doneChan:
+			}
+		}
+		terminate := func(status ProcedureStatus, errorData *ErrorData) {
+			Set(vehicleId, massageOnPath, "false", stCredentials)
+			removeActiveService(&vehConn.connectedData, protocol, serviceId)
+			releaseServiceLease(leaseId)
+			out.Status = status
+			out.Error = errorData
+			if callback != nil {
+				callback(out)
+			}
+		}
+		for i := 0; i < len(steps); i++ {
+			out.StepIndex = i
+			stepOut := activateMassage(vehicleId, seatId, steps[i].MassageType, steps[i].Intensity, steps[i].Duration, stCredentials, stepCb, true)
+			if stepOut.Status == FAILED {
+				terminate(FAILED, stepOut.Error)
+				return
+			}
+			out.Status = ONGOING
+			if callback != nil {
+				callback(out)
+			}
+			stepDone := false
+			for !stepDone {
+				select {
+				case stepOut = <- stepChan:
+					if stepOut.Status == FAILED {
+						terminate(FAILED, stepOut.Error)
+						return
+					}
+					if stepOut.Status == SUCCESSFUL {
+						stepDone = true
+					} else if callback != nil {
+						callback(out)
+					}
+				case errorData := <- preemptChan: // the new lease holder takes over the massage
+					CancelService(vehicleId, stepOut.ServiceId)
+					removeActiveService(&vehConn.connectedData, protocol, serviceId)
+					out.Status = FAILED
+					out.Error = errorData
+					if callback != nil {
+						callback(out)
+					}
+					return
+				case <- cancelChan:
+					CancelService(vehicleId, stepOut.ServiceId)
+					Set(vehicleId, massageOnPath, "false", stCredentials)
+					releaseServiceLease(leaseId)
+					out.Status = FAILED
+					out.Error = getCancelledError()
+					if callback != nil {
+						callback(out)
+					}
+					return
+				}
+			}
+		}
+		terminate(SUCCESSFUL, nil)
+	}(out)
+	out.Status = ONGOING
+	return out
+}
+
+func ActivateSeatHeating(vehicleId VehicleHandle, seatId MatrixId, level Percentage, duration uint32, stCredentials string, callback func(SeatClimateOutput)) SeatClimateOutput {
+	return activateSeatClimate(vehicleId, seatId, HEATING, level, duration, stCredentials, callback)
+}
+
+func ActivateSeatVentilation(vehicleId VehicleHandle, seatId MatrixId, level Percentage, duration uint32, stCredentials string, callback func(SeatClimateOutput)) SeatClimateOutput {
+	return activateSeatClimate(vehicleId, seatId, VENTILATION, level, duration, stCredentials, callback)
+}
+
+func activateSeatClimate(vehicleId VehicleHandle, seatId MatrixId, climateType string, level Percentage, duration uint32, stCredentials string, callback func(SeatClimateOutput)) SeatClimateOutput {
+	var out SeatClimateOutput
+	vehConn := getVehicleConnection(vehicleId)
+	if vehConn == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
+	if level < 0 || level > 100 {
+		out.Error = getErrorObject(400, "invalid_data", "level out of range")
+		out.Status = FAILED
+		return out
+	}
+	if !checkSupport(seatId, climateType, "climate") {
+		out.Error = getErrorObject(400, "invalid_data", "Climate type not supported for this seat")
+		out.Status = FAILED
+		return out
+	}
+	var serviceName, climatePath, levelStr string
+	switch climateType {
+		case HEATING: // Heating: 0 = off, 100 = maximum heating
+			serviceName = "ActivateSeatHeating"
+			climatePath = getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Heating", seatId)
+			levelStr = strconv.Itoa(int(level))
+		case VENTILATION: // HeatingCooling: -100 = maximum cooling, 0 = off
+			serviceName = "ActivateSeatVentilation"
+			climatePath = getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.HeatingCooling", seatId)
+			levelStr = strconv.Itoa(-int(level))
+	}
+	preconditions := getSeatPreconditions(serviceName, seatId)
+	errorData := checkPreconditions(vehicleId, preconditions, stCredentials)
+	if errorData != nil {
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	preemptChan := make(chan *ErrorData, 1)
+	leaseId, errorData := acquireServiceLease(vehConn, createSeatResourceName(seatId, CLIMATE), preemptChan)
+	if errorData != nil {
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	setOut := Set(vehicleId, climatePath, levelStr, stCredentials)
 	if setOut.Status == FAILED {
 		out.Status = FAILED
 		out.Error = setOut.Error
+		releaseServiceLease(leaseId)
 		return out
 	}
 	serviceId := generateRandomUint32()
@@ -790,25 +1213,43 @@ func ActivateMassage(vehicleId VehicleHandle, seatId MatrixId, massageType strin
 	if stCredentials != "" {
 		stCredParam = `, "authorization":"` + stCredentials + "\""
 	}
-	message := `{"action":"subscribe", "path":"` + massageOnPath + "\"" + filterParam + stCredParam + `, "requestId":"` + requestId + `"}`
-	messageChan := addActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId, requestId, "")
+	message := `{"action":"subscribe", "path":"` + climatePath + "\"" + filterParam + stCredParam + `, "requestId":"` + requestId + `"}`
+	messageChan := addActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, requestId, "")
+	if messageChan == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		releaseServiceLease(leaseId)
+		return out
+	}
 	sendMessage(vehConn, "", message)
 	messageMap := <- messageChan
 	if messageMap["error"] != nil {
-		removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+		removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
 		out.Status = FAILED
 		out.Error = getErrorInfo(messageMap["error"].(map[string]interface{}))
+		releaseServiceLease(leaseId)
 		return out
 	}
 	cancelChan := make(chan string)
-	ok := saveCancelHandle(&vehConn.connectedData, vehConn.selectedProtocol, serviceId, messageMap["subscriptionId"].(string), cancelChan)
+	ok := saveCancelHandle(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId, messageMap["subscriptionId"].(string), cancelChan)
 	if !ok {
-		removeActiveService(&vehConn.connectedData, vehConn.selectedProtocol, serviceId)
+		removeActiveService(&vehConn.connectedData, getSelectedProtocol(vehConn), serviceId)
 		out.Status = FAILED
 		out.Error = getErrorObject(502, "bad_gateway", "Server internal error")
+		releaseServiceLease(leaseId)
 		return out
 	}
-	go func() {
+	violationChan := make(chan *ErrorData, 1)
+	monitorIds, errorData := startPreconditionMonitor(vehicleId, preconditions, stCredentials, violationChan)
+	if errorData != nil {
+		Set(vehicleId, climatePath, "0", stCredentials)
+		Unsubscribe(vehicleId, serviceId)
+		releaseServiceLease(leaseId)
+		out.Status = FAILED
+		out.Error = errorData
+		return out
+	}
+	go func(out SeatClimateOutput) {  // a copy, as the returned output must not be written by the thread
 		finalTime := time.Now().Add(time.Duration(float64(duration)*1e9))
 		for {
 			select {
@@ -819,8 +1260,10 @@ func ActivateMassage(vehicleId VehicleHandle, seatId MatrixId, massageType strin
 				} else {
 					out.Status = ONGOING
 					data := populateData(messageMap["data"])
-					massageOn := data[0].Dp[0].Value
-					if massageOn == "false" || time.Now().After(finalTime) {
+					if len(data) > 0 && len(data[0].Dp) > 0 && data[0].Dp[0].Value == "0" { // switched off by someone else
+						out.Status = SUCCESSFUL
+					} else if time.Now().After(finalTime) {
+						Set(vehicleId, climatePath, "0", stCredentials)
 						out.Status = SUCCESSFUL
 					}
 				}
@@ -828,14 +1271,44 @@ func ActivateMassage(vehicleId VehicleHandle, seatId MatrixId, massageType strin
 					callback(out)
 				}
 				if messageMap["error"] != nil || out.Status == SUCCESSFUL {
+					stopPreconditionMonitor(vehicleId, monitorIds)
 					Unsubscribe(vehicleId, serviceId)
+					releaseServiceLease(leaseId)
 					return
 				}
+			case errorData := <- violationChan:
+				Set(vehicleId, climatePath, "0", stCredentials)
+				out.Status = FAILED
+				out.Error = errorData
+				if callback != nil {
+					callback(out)
+				}
+				stopPreconditionMonitor(vehicleId, monitorIds)
+				Unsubscribe(vehicleId, serviceId)
+				releaseServiceLease(leaseId)
+				return
+			case errorData := <- preemptChan: // the new lease holder takes over the seat climate
+				out.Status = FAILED
+				out.Error = errorData
+				if callback != nil {
+					callback(out)
+				}
+				stopPreconditionMonitor(vehicleId, monitorIds)
+				Unsubscribe(vehicleId, serviceId)
+				return
 			case <- cancelChan:
+				stopPreconditionMonitor(vehicleId, monitorIds)
+				Set(vehicleId, climatePath, "0", stCredentials)
+				releaseServiceLease(leaseId)
+				out.Status = FAILED
+				out.Error = getCancelledError()
+				if callback != nil {
+					callback(out)
+				}
 				return
 			}
 		}
-	}()
+	}(out)
 	out.Status = ONGOING
 	return out
 }
@@ -848,7 +1321,7 @@ func GetPropertiesSeating(vehicleId VehicleHandle) GetPropertiesSeatingOutput {
 		out.Status = FAILED
 		return out
 	}
-	if len(vehConn.selectedProtocol) == 0 {
+	if len(getSelectedProtocol(vehConn)) == 0 {
 		out.Error = getErrorObject(400, "invalid_data", "vehicle not connected")
 		out.Status = FAILED
 		return out
@@ -883,9 +1356,30 @@ func generateRandomString() string {
 	return fmt.Sprint(uint32Topic)
 }
 
+type vehicleRegistration struct {
+	ipAddress string
+	support []ConnectivityData
+}
+
+var registeredVehicles = make(map[string]vehicleRegistration)
+var registeredVehiclesMutex sync.Mutex
+
+// RegisterVehicle makes a vehicle known to GetVehicle, in addition to the vehicles known by getSupportedConnectivity. The first protocol in support is the default.
+func RegisterVehicle(vehicleGuid string, ipAddress string, support []ConnectivityData) {
+	registeredVehiclesMutex.Lock()
+	registeredVehicles[vehicleGuid] = vehicleRegistration{ipAddress, support}
+	registeredVehiclesMutex.Unlock()
+}
+
 func getSupportedConnectivity(vehicleGuid string) ([]ConnectivityData, string) { // this method must be implemented to match the "ecosystem requirements"
 	var ipAddress string
 	var support []ConnectivityData
+	registeredVehiclesMutex.Lock()
+	registration, ok := registeredVehicles[vehicleGuid]
+	registeredVehiclesMutex.Unlock()
+	if ok {
+		return registration.support, registration.ipAddress
+	}
 	switch vehicleGuid {
 	case "pseudoVin1":
 		ipAddress = "127.0.0.1"
@@ -934,6 +1428,10 @@ func getSupportedConnectivity(vehicleGuid string) ([]ConnectivityData, string) {
 	return support, ipAddress
 }
 
+func getCancelledError() *ErrorData { // the error of the final callback of a service that is terminated by CancelService
+	return getErrorObject(499, "cancelled", "The service was cancelled")
+}
+
 func getErrorObject(code int32, reason string, description string) *ErrorData {
 		var errData ErrorData
 		errData.Code = code
@@ -943,6 +1441,8 @@ func getErrorObject(code int32, reason string, description string) *ErrorData {
 }
 
 func addConnectedData(connectedDataList **ConnectedData, connectedData *ConnectedData) {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
 	if *connectedDataList == nil {
 		*connectedDataList = connectedData
 	} else {
@@ -955,27 +1455,34 @@ func addConnectedData(connectedDataList **ConnectedData, connectedData *Connecte
 }
 
 func removeConnection(vehConn **VehicleConnection, protocol string) {
+	removeSharedSubscriptions((*vehConn).vehicleId, protocol)
+	connectionMutex.Lock()
 	connectedDataList := &(*vehConn).connectedData
-	if *connectedDataList == nil {
-		return
-	} else {
-		iterator := connectedDataList
-		for *iterator != nil {
-			if (*iterator).protocol == protocol {
+	var connHandle interface{}
+	iterator := connectedDataList
+	for *iterator != nil {
+		if (*iterator).protocol == protocol {
 //fmt.Printf("removeConnection: removed\n")
-				if (*vehConn).selectedProtocol == protocol {
-					(*vehConn).selectedProtocol = ""
-				}
-				closeConnection((*iterator).connHandle, protocol)
-				*iterator =(*iterator).next
-				break
+			if (*vehConn).selectedProtocol == protocol {
+				(*vehConn).selectedProtocol = ""
 			}
-			iterator = &(*iterator).next
+			connHandle = (*iterator).connHandle
+			*iterator =(*iterator).next
+			break
 		}
+		iterator = &(*iterator).next
+	}
+	connectionMutex.Unlock()
+	if connHandle != nil {
+		closeConnection(connHandle, protocol)  // outside of the lock, a transport may block while it closes
 	}
 }
 
 func closeConnection(connHandle interface{}, protocol string) {
+	if transport, ok := connHandle.(Transport); ok {
+		transport.Close()
+		return
+	}
 	switch protocol {
 		case "VISSv3.0-wss": fallthrough
 		case "VISSv3.0-ws":
@@ -988,8 +1495,10 @@ func closeConnection(connHandle interface{}, protocol string) {
 }
 
 func removeActiveService(connectedDataList **ConnectedData, protocol string, serviceId uint32) {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
 	if *connectedDataList == nil {
-		fmt.Printf("removeActiveService: connectedDataList is empty for protocol=%s, serviceId=%s\n", protocol, serviceId) // should not be possible...
+		fmt.Printf("removeActiveService: connectedDataList is empty for protocol=%s, serviceId=%d\n", protocol, serviceId) // should not be possible...
 		return
 	} else {
 		iterator := connectedDataList
@@ -999,7 +1508,7 @@ func removeActiveService(connectedDataList **ConnectedData, protocol string, ser
 				for *activeServiceIterator != nil {
 					if (*activeServiceIterator).serviceId == serviceId {
 //fmt.Printf("removeActiveService: removed\n")
-						*activeServiceIterator = nil
+						*activeServiceIterator = (*activeServiceIterator).next
 						return
 					}
 					activeServiceIterator = &(*activeServiceIterator).next
@@ -1011,11 +1520,13 @@ func removeActiveService(connectedDataList **ConnectedData, protocol string, ser
 	}
 }
 
-func getActiveServiceId(connectedDataList *ConnectedData, protocol string) uint32 {
-	if connectedDataList == nil {
+func getActiveServiceId(connectedDataList **ConnectedData, protocol string) uint32 {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
+	if *connectedDataList == nil {
 		return 0
 	} else {
-		iterator := connectedDataList
+		iterator := *connectedDataList
 		for iterator != nil {
 			if (*iterator).protocol == protocol {
 				activeServiceIterator := (*iterator).activeService
@@ -1030,12 +1541,14 @@ func getActiveServiceId(connectedDataList *ConnectedData, protocol string) uint3
 	return 0
 }
 
-func getCancelData(connectedDataList *ConnectedData, protocol string, serviceId uint32) string {
-	if connectedDataList == nil {
+func getCancelData(connectedDataList **ConnectedData, protocol string, serviceId uint32) string {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
+	if *connectedDataList == nil {
 		fmt.Printf("getCancelData: connectedDataList is empty for protocol=%s, serviceId=%d\n", protocol, serviceId)
 		return ""
 	} else {
-		iterator := connectedDataList
+		iterator := *connectedDataList
 		for iterator != nil {
 			if (*iterator).protocol == protocol {
 				activeServiceIterator := (*iterator).activeService
@@ -1052,12 +1565,52 @@ func getCancelData(connectedDataList *ConnectedData, protocol string, serviceId
 	return ""
 }
 
-func getMessageChan(connectedDataList *ConnectedData, protocol string, messageId string) chan map[string]interface{} {
-	if connectedDataList == nil {
+func getActiveService(connectedDataList **ConnectedData, protocol string, serviceId uint32) *ActiveService {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
+	iterator := *connectedDataList
+	for iterator != nil {
+		if (*iterator).protocol == protocol {
+			activeServiceIterator := (*iterator).activeService
+			for activeServiceIterator != nil {
+				if (*activeServiceIterator).serviceId == serviceId {
+					return activeServiceIterator
+				}
+				activeServiceIterator = (*activeServiceIterator).next
+			}
+		}
+		iterator = (*iterator).next
+	}
+	return nil
+}
+
+func isActiveServiceName(connectedDataList **ConnectedData, protocol string, name string) bool {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
+	iterator := *connectedDataList
+	for iterator != nil {
+		if (*iterator).protocol == protocol {
+			activeServiceIterator := (*iterator).activeService
+			for activeServiceIterator != nil {
+				if (*activeServiceIterator).name == name {
+					return true
+				}
+				activeServiceIterator = (*activeServiceIterator).next
+			}
+		}
+		iterator = (*iterator).next
+	}
+	return false
+}
+
+func getMessageChan(connectedDataList **ConnectedData, protocol string, messageId string) chan map[string]interface{} {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
+	if *connectedDataList == nil {
 		fmt.Printf("getMessageChan: connectedDataList is empty for protocol=%s, messageId=%s\n", protocol, messageId)
 		return nil
 	} else {
-		iterator := connectedDataList
+		iterator := *connectedDataList
 		for iterator != nil {
 			if (*iterator).protocol == protocol {
 				activeServiceIterator := &(*iterator).activeService
@@ -1076,6 +1629,8 @@ func getMessageChan(connectedDataList *ConnectedData, protocol string, messageId
 }
 
 func getProtocol(connectedDataList **ConnectedData, serviceId uint32) string {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
 	if *connectedDataList == nil {
 		return ""
 	} else {
@@ -1095,6 +1650,8 @@ func getProtocol(connectedDataList **ConnectedData, serviceId uint32) string {
 }
 
 func saveCancelHandle(connectedDataList **ConnectedData, protocol string, serviceId uint32, subscriptionId string, cancelChan chan string) bool {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
 	if *connectedDataList == nil {
 		return false
 	} else {
@@ -1118,6 +1675,8 @@ func saveCancelHandle(connectedDataList **ConnectedData, protocol string, servic
 }
 
 func addActiveService(connectedDataList **ConnectedData, protocol string, serviceId uint32, messageId string, name string) chan map[string]interface{} {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
 	messageChan := make(chan map[string]interface{})
 	if *connectedDataList == nil {
 		return nil
@@ -1154,15 +1713,17 @@ func addActiveService(connectedDataList **ConnectedData, protocol string, servic
 	return nil
 }
 
-func getConnHandle(connectedDataList *ConnectedData, protocol string) interface{} {
-	if connectedDataList == nil {
+func getConnHandle(connectedDataList **ConnectedData, protocol string) interface{} {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
+	if *connectedDataList == nil {
 		return nil
 	} else {
-		iterator := connectedDataList
+		iterator := *connectedDataList
 		for iterator != nil {
 //fmt.Printf("getConnHandle: iterator.protocol=%s\n", iterator.protocol)
 			if iterator.protocol == protocol {
-				if strings.Contains(protocol, "ws") {
+				if strings.Contains(protocol, "ws") || getTransportDialer(protocol) != nil {
 					return iterator.connHandle
 				}
 			}
@@ -1172,7 +1733,33 @@ func getConnHandle(connectedDataList *ConnectedData, protocol string) interface{
 	return nil
 }
 
+// getWriteMutex returns the mutex that serializes the writes to the connection of the protocol.
+func getWriteMutex(connectedDataList **ConnectedData, protocol string) *sync.Mutex {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
+	for iterator := *connectedDataList; iterator != nil; iterator = iterator.next {
+		if iterator.protocol == protocol {
+			return &iterator.writeMutex
+		}
+	}
+	return nil
+}
+
+func getSelectedProtocol(vehConn *VehicleConnection) string {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
+	return vehConn.selectedProtocol
+}
+
+func setSelectedProtocol(vehConn *VehicleConnection, protocol string) {
+	connectionMutex.Lock()
+	vehConn.selectedProtocol = protocol
+	connectionMutex.Unlock()
+}
+
 func addVehicleConnection(vehConn *VehicleConnection) {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
 	if vehConnList == nil {
 		vehConnList = vehConn
 	} else {
@@ -1185,6 +1772,12 @@ func addVehicleConnection(vehConn *VehicleConnection) {
 }
 
 func getVehicleConnection(vehicleId VehicleHandle) *VehicleConnection {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
+	return findVehicleConnection(vehicleId)
+}
+
+func findVehicleConnection(vehicleId VehicleHandle) *VehicleConnection {  // connectionMutex must be held
 	if vehConnList == nil {
 		return nil
 	} else {
@@ -1220,14 +1813,14 @@ func getErrorInfo(errorMap map[string]interface{}) *ErrorData {
 	for k, v := range errorMap {
 //		Info.Println("key=",k, "v=", v)
 		if k == "number" {
-			code, _ := strconv.Atoi(v.(string))
+			code, _ := strconv.Atoi(fmt.Sprint(v))
 			errorInfo.Code = (int32)(code)
 		}
 		if k == "reason" {
-			errorInfo.Reason = v.(string)
+			errorInfo.Reason = fmt.Sprint(v)
 		}
 		if k == "description" {
-			errorInfo.Description = v.(string)
+			errorInfo.Description = fmt.Sprint(v)
 		}
 	}
 	return &errorInfo
@@ -1235,22 +1828,36 @@ func getErrorInfo(errorMap map[string]interface{}) *ErrorData {
 
 func sendMessage(vehicle *VehicleConnection, protocol string, clientMessage string) {
 	if len(protocol) == 0 {
-		protocol = vehicle.selectedProtocol
+		protocol = getSelectedProtocol(vehicle)
 	}
+	recordMessage(vehicle, "client", protocol, []byte(clientMessage))
 	switch protocol {
 		case "VISSv3.0-wss": fallthrough
 		case "VISSv3.0-ws":
-			conn := getConnHandle(vehicle.connectedData, "VISSv3.0-ws").(*websocket.Conn)
-			sendMessageWs(conn, clientMessage)
+			conn, ok := getConnHandle(&vehicle.connectedData, "VISSv3.0-ws").(*websocket.Conn)
+			if !ok {
+				fmt.Printf("sendMessage: not connected for protocol=%s\n", protocol)
+				return
+			}
+			sendMessageWs(conn, getWriteMutex(&vehicle.connectedData, "VISSv3.0-ws"), clientMessage)
 		case "grpc":
 		case "mqtt":
 		case "http":
+		default:
+			transport, ok := getConnHandle(&vehicle.connectedData, protocol).(Transport)
+			if !ok {
+				fmt.Printf("sendMessage: not connected for protocol=%s\n", protocol)
+				return
+			}
+			transport.Send([]byte(clientMessage))
 //		default: response =  `{"error": {"number": "502", "reason": "bad_gateway", "description": "The active protocol is not supported."}}`
 	}
 }
 
-func sendMessageWs(conn *websocket.Conn, clientMessage string) {
+func sendMessageWs(conn *websocket.Conn, writeMutex *sync.Mutex, clientMessage string) {
+	writeMutex.Lock()
 	err := conn.WriteMessage(websocket.BinaryMessage, []byte(clientMessage))
+	writeMutex.Unlock()
 	if err != nil {
 		fmt.Printf("Request error:%s\n", err)
 	}
@@ -1258,14 +1865,14 @@ func sendMessageWs(conn *websocket.Conn, clientMessage string) {
 
 func initReceiveMessage(vehicle *VehicleConnection, protocol string) {
 	if len(protocol) == 0 {
-		protocol = vehicle.selectedProtocol
+		protocol = getSelectedProtocol(vehicle)
 	}
 	switch protocol {
 		case "VISSv3.0-wss": fallthrough
 		case "VISSv3.0-ws":
 			for{
-				conn := getConnHandle(vehicle.connectedData, "VISSv3.0-ws").(*websocket.Conn)
-				if conn == nil {
+				conn, ok := getConnHandle(&vehicle.connectedData, "VISSv3.0-ws").(*websocket.Conn)
+				if !ok {
 //fmt.Printf("receiveMessageWs: terminating\n")
 					return
 				}
@@ -1275,21 +1882,34 @@ func initReceiveMessage(vehicle *VehicleConnection, protocol string) {
 					return
 				}
 //				fmt.Printf("receiveMessageWs: message=%s\n", string(message))
-				var messageMap map[string]interface{}
-				err = json.Unmarshal(message, &messageMap)
-				if err != nil {
-					fmt.Printf("initReceiveMessage:error message=%s, err=%s", message, err)
-					continue
-				}
-				messageId := extractMessageId(messageMap)
-				messageChan := getMessageChan(vehicle.connectedData, protocol, messageId)
-				if messageChan != nil {
-					messageChan <- messageMap
-				}
+				dispatchMessage(vehicle, protocol, message)
 			}
 		case "grpc": //TBI
 		case "mqtt": //TBI
 		case "http": //TBI
+		default:
+			transport, ok := getConnHandle(&vehicle.connectedData, protocol).(Transport)
+			if !ok {
+				return
+			}
+			for message := range transport.Receive() {
+				dispatchMessage(vehicle, protocol, message)
+			}
+	}
+}
+
+func dispatchMessage(vehicle *VehicleConnection, protocol string, message []byte) {
+	recordMessage(vehicle, "server", protocol, message)
+	var messageMap map[string]interface{}
+	err := json.Unmarshal(message, &messageMap)
+	if err != nil {
+		fmt.Printf("initReceiveMessage:error message=%s, err=%s", message, err)
+		return
+	}
+	messageId := extractMessageId(messageMap)
+	messageChan := getMessageChan(&vehicle.connectedData, protocol, messageId)
+	if messageChan != nil {
+		messageChan <- messageMap
 	}
 }
 
@@ -1326,8 +1946,7 @@ func initVissV2WebSocket(socket string) (*websocket.Conn, bool) {
 			RootCAs:      &caCertPool,
 		}
 	}*/
-	var addr = flag.String("addr", socket, "http service address")
-	dataSessionUrl := url.URL{Scheme: scheme, Host: *addr, Path: ""}
+	dataSessionUrl := url.URL{Scheme: scheme, Host: socket, Path: ""}
 	subProtocol := make([]string, 1)
 	subProtocol[0] = "VISSv2"
 	dialer := websocket.Dialer{
@@ -1445,7 +2064,13 @@ func reformatGetMetadataMessage(messageMap map[string]interface{}) GetMetadataOu
 		out.Error = getErrorInfo(messageMap["error"].(map[string]interface{}))
 	} else {
 		out.Status = SUCCESSFUL
-		out.Metadata = messageMap["metadata"].(string)
+		switch metadata := messageMap["metadata"].(type) {
+			case string:
+				out.Metadata = metadata
+			default: // VISSR returns the metadata as a JSON object
+				metadataBytes, _ := json.Marshal(metadata)
+				out.Metadata = string(metadataBytes)
+		}
 	}
 	return out
 }
@@ -1478,7 +2103,9 @@ func reformatGeneralMessage(messageMap map[string]interface{}) GeneralOutput {
 
 func connectToVehicle(protocol string, socket string) (interface{}, bool) {
 //fmt.Printf("Socket=%s\n", socket)
-	if strings.Contains(protocol, "ws") {
+	if getTransportDialer(protocol) != nil {
+		return dialTransport(protocol, socket)
+	} else if strings.Contains(protocol, "ws") {
 		conn, isConnected := initVissV2WebSocket(socket)
 		return conn, isConnected  // TODO: switch on protocol
 	} else if strings.Contains(protocol, "grpc") {
@@ -1567,10 +2194,12 @@ func getSimulatedProperties() RaggedMatrix {
 	properties = make([]RowDef, 2)
 	var numofcols []int = []int{2, 2}
 	var columnName []string = []string{"DriverSide", "PassengerSide"}
-	var movementSupport []SupportData = []SupportData{{LONGITUDINAL, "Seat movement in the direction parallel to the driving direction"},
-	{VERTICAL, "Seat movement in the vertical direction to the horizontal plane"}, {LUMBAR, "Seat movement of the lumbar support"}}
-	var massageSupport []SupportData = []SupportData{{ROLL, "A rolling massage sensation"},
-	{PULSE, "A pulsating massage sensation"}, {WAVE, "A wave like massage sensation"}}
+	var movementSupport []SupportData = []SupportData{{Name: LONGITUDINAL, Description: "Seat movement in the direction parallel to the driving direction"},
+	{Name: VERTICAL, Description: "Seat movement in the vertical direction to the horizontal plane"}, {Name: LUMBAR, Description: "Seat movement of the lumbar support"}}
+	var massageSupport []SupportData = []SupportData{{Name: ROLL, Description: "A rolling massage sensation"},
+	{Name: PULSE, Description: "A pulsating massage sensation"}, {Name: WAVE, Description: "A wave like massage sensation"}}
+	var climateSupport []SupportData = []SupportData{{Name: HEATING, Description: "Heating of the seat surface"},
+	{Name: VENTILATION, Description: "Ventilation cooling of the seat surface"}}
 	for i := 0; i < 2; i++ {
 		properties[i].RowName = "Row" + strconv.Itoa(i+1)
 		properties[i].Column = make([]ColumnData, numofcols[i])
@@ -1578,6 +2207,7 @@ func getSimulatedProperties() RaggedMatrix {
 			properties[i].Column[j].Name = columnName[j]
 			properties[i].Column[j].MovementSupport = getSimulatedSupport(i, j, movementSupport)
 			properties[i].Column[j].MassageSupport = getSimulatedSupport(i, j, massageSupport)
+			properties[i].Column[j].ClimateSupport = getSimulatedSupport(i, j, climateSupport)
 		}
 	}
 	return properties
@@ -1596,6 +2226,8 @@ func getSimulatedSupport(row int, column int, support []SupportData) []SupportDa
 }
 
 func isMoving(connectedDataList **ConnectedData, protocol string, seatId MatrixId, movementType string) bool {
+	connectionMutex.Lock()
+	defer connectionMutex.Unlock()
 	if *connectedDataList == nil {
 		return false
 	} else {
@@ -1617,6 +2249,30 @@ func isMoving(connectedDataList **ConnectedData, protocol string, seatId MatrixI
 	return false
 }
 
+func getSeatActuatorData(movementType string, seatId MatrixId) (string, Percentage, Percentage) { // actuator path, and A, B in the transform actuatorValue = A * position + B
+	switch movementType {
+		case LONGITUDINAL:
+			return getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Position", seatId), 3, 0 //in millimeter, 300 mm dynamic range??
+		case LUMBAR:
+			return getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Backrest.Lumbar.Support", seatId), 1, 0
+		case BACKREST:
+			return getSeatPositionedPath("Vehicle.Cabin.Seat.RowX.ColumnY.Backrest.Recline", seatId), 0.9, -45 //transform position to degrees; f(x) = 0.9*x - 45 f(0)=-45; f(100)=45 ??
+	}
+	return "", 1, 0
+}
+
+func createConfigureSeatName(seatId MatrixId) string {
+	return CONFIGURE_SEAT + seatId.RowName + seatId.ColumnName
+}
+
+func createMassageProgramName(seatId MatrixId) string {
+	return MASSAGE_PROGRAM + seatId.RowName + seatId.ColumnName
+}
+
+func isLocalService(name string) bool { // local services are executed by VAPI without a server side subscription
+	return strings.HasPrefix(name, MASSAGE_PROGRAM) || strings.HasPrefix(name, CONFIGURE_SEAT)
+}
+
 func createMoveSeatName(movementType string, seatId MatrixId) string {
 	return movementType + seatId.RowName + seatId.ColumnName
 }
@@ -1638,6 +2294,20 @@ func checkSupport(seatId MatrixId, support string, supportType string) bool {
 					}
 				}
 			}
+		case "climate":
+			for i := 0; i < len(simProp); i++ {
+				if simProp[i].RowName == seatId.RowName {
+					for j := 0; j < len(simProp[i].Column); j++ {
+						if simProp[i].Column[j].Name == seatId.ColumnName {
+							for k := 0; k < len(simProp[i].Column[j].ClimateSupport); k++ {
+								if simProp[i].Column[j].ClimateSupport[k].Name == support {
+									return true
+								}
+							}
+						}
+					}
+				}
+			}
 		case "move":
 			for i := 0; i < len(simProp); i++ {
 				if simProp[i].RowName == seatId.RowName {
@@ -1660,24 +2330,6 @@ func isSupportedMovement(seatId MatrixId, movementType string) bool {
 	return checkSupport(seatId, movementType, "move")
 }
 
-func findConfIndex(serviceId uint32, moveOut []MoveSeatOutput) int {
-	for i := 0; i < len(moveOut); i++ {
-		if serviceId == moveOut[i].ServiceId {
-			return i
-		}
-	}
-	return -1
-}
-
-func getMoveTypePosition(serviceId uint32, moveOut []MoveSeatOutput) Percentage {
-	for i := 0; i < len(moveOut); i++ {
-		if serviceId == moveOut[i].ServiceId {
-			return moveOut[i].Position
-		}
-	}
-	return 0 //???
-}
-
 func seatConfigComplete(eventOut ConfigureSeatOutput, configuration []SeatConfig, mapData []MapData) bool {
 	for i := 0; i < len(eventOut.Configured); i++ {
 		for j := 0; j < len(configuration); j++ {
@@ -0,0 +1,707 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"VISS-Go/VissMock"
+	"VISS-Go/vapi"
+)
+
+const testProtocol = "VISSv3.0-ws"
+
+// startMockVehicle starts a mock VISS server, and returns it together with a handle of a vehicle that is connected to it.
+func startMockVehicle(t *testing.T, signals []VissMock.Signal) (*VissMock.Server, VehicleHandle) {
+	t.Helper()
+	server := VissMock.NewServer(signals)
+	_, err := server.StartWs("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("StartWs: %s", err)
+	}
+	vehicleGuid := "mockVin-" + t.Name()
+	RegisterVehicle(vehicleGuid, "127.0.0.1", []ConnectivityData{{server.Port(), testProtocol}})
+	getVehicleOut := GetVehicle(vehicleGuid)
+	if getVehicleOut.Status != SUCCESSFUL {
+		t.Fatalf("GetVehicle: %v", getVehicleOut.Error)
+	}
+	connectOut := Connect(getVehicleOut.VehicleId, testProtocol, "")
+	if connectOut.Status != SUCCESSFUL {
+		t.Fatalf("Connect: %v", connectOut.Error)
+	}
+	t.Cleanup(func() {
+		Disconnect(getVehicleOut.VehicleId, testProtocol)
+		ReleaseVehicle(getVehicleOut.VehicleId)
+		server.Close()
+	})
+	return server, getVehicleOut.VehicleId
+}
+
+// fastSignals returns the default mock signals with actuators that reach their set value within one tick.
+func fastSignals() []VissMock.Signal {
+	signals := VissMock.DefaultSignals()
+	for i := 0; i < len(signals); i++ {
+		if signals[i].Rate > 0 {
+			signals[i].Rate = 10000
+		}
+	}
+	return signals
+}
+
+func waitFor[T any](t *testing.T, eventChan chan T, isFinal func(T) bool) T {
+	t.Helper()
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case event := <- eventChan:
+			if isFinal(event) {
+				return event
+			}
+		case <- timeout:
+			t.Fatalf("timeout waiting for final callback")
+		}
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	setOut := Set(vehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType", ROLL, "")
+	if setOut.Status != SUCCESSFUL {
+		t.Fatalf("Set: %v", setOut.Error)
+	}
+	getOut := Get(vehicleId, "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType", "", "")
+	if getOut.Status != SUCCESSFUL {
+		t.Fatalf("Get: %v", getOut.Error)
+	}
+	if len(getOut.Data) != 1 || getOut.Data[0].Dp[0].Value != ROLL {
+		t.Errorf("Get data = %v, want %s", getOut.Data, ROLL)
+	}
+	getOut = Get(vehicleId, "Vehicle.CurrentLocation", `{"variant":"paths","parameter":["Latitude", "Longitude"]}`, "")
+	if getOut.Status != SUCCESSFUL || len(getOut.Data) != 2 {
+		t.Errorf("Get with paths filter: status=%d, data=%v", getOut.Status, getOut.Data)
+	}
+	getOut = Get(vehicleId, "Vehicle.Unknown", "", "")
+	if getOut.Status != FAILED || getOut.Error == nil || getOut.Error.Code != 404 {
+		t.Errorf("Get of unknown path: status=%d, error=%v", getOut.Status, getOut.Error)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	server.SetValue("Vehicle.Speed", "25")
+	eventChan := make(chan SubscribeOutput, 10)
+	subscribeOut := Subscribe(vehicleId, "Vehicle.Speed", `{"variant":"timebased","parameter":{"period":"100"}}`, "", func(out SubscribeOutput) {
+		eventChan <- out
+	})
+	if subscribeOut.Status != ONGOING || subscribeOut.ServiceId == 0 {
+		t.Fatalf("Subscribe: status=%d, error=%v", subscribeOut.Status, subscribeOut.Error)
+	}
+	event := waitFor(t, eventChan, func(out SubscribeOutput) bool { return true })
+	if event.Status != SUCCESSFUL || event.ServiceId != subscribeOut.ServiceId || event.Data[0].Dp[0].Value != "25" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	unsubscribeOut := Unsubscribe(vehicleId, subscribeOut.ServiceId)
+	if unsubscribeOut.Status != SUCCESSFUL {
+		t.Errorf("Unsubscribe: %v", unsubscribeOut.Error)
+	}
+}
+
+func TestMoveSeat(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	eventChan := make(chan MoveSeatOutput, 10)
+	seatId := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	moveSeatOut := MoveSeat(vehicleId, seatId, LONGITUDINAL, BACKWARD, "", func(out MoveSeatOutput) {
+		eventChan <- out
+	})
+	if moveSeatOut.Status != ONGOING {
+		t.Fatalf("MoveSeat: status=%d, error=%v", moveSeatOut.Status, moveSeatOut.Error)
+	}
+	event := waitFor(t, eventChan, func(out MoveSeatOutput) bool { return out.Status != ONGOING })
+	if event.Status != SUCCESSFUL || event.Position != 100 {
+		t.Errorf("final callback: status=%d, position=%f, error=%v", event.Status, event.Position, event.Error)
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Position"); value != "300" {
+		t.Errorf("actuator value = %s, want 300", value)
+	}
+}
+
+func TestActivateMassage(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	eventChan := make(chan MassageOutput, 10)
+	seatId := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	massageOut := ActivateMassage(vehicleId, seatId, PULSE, 50, 1, "", func(out MassageOutput) {
+		eventChan <- out
+	})
+	if massageOut.Status != ONGOING {
+		t.Fatalf("ActivateMassage: status=%d, error=%v", massageOut.Status, massageOut.Error)
+	}
+	for path, want := range map[string]string{"Switch.Massage.IsOn": "true", "Switch.Massage.Intensity": "50", "Switch.Massage.MassageType": PULSE} {
+		if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide." + path); value != want {
+			t.Errorf("%s = %s, want %s", path, value, want)
+		}
+	}
+	event := waitFor(t, eventChan, func(out MassageOutput) bool { return out.Status != ONGOING })
+	if event.Status != SUCCESSFUL {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+}
+
+func TestGetVehicle(t *testing.T) {
+	RegisterVehicle("testVin", "127.0.0.1", []ConnectivityData{{"1", testProtocol}, {"2", "VISSv3.0-grpc"}})
+	tests := []struct {
+		vehicleGuid string
+		status ProcedureStatus
+		protocols []string
+	}{
+		{"testVin", SUCCESSFUL, []string{testProtocol, "VISSv3.0-grpc"}},
+		{"pseudoVin2", SUCCESSFUL, []string{"VISSv3.0-grpc"}},
+		{"unknownVin", FAILED, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.vehicleGuid, func(t *testing.T) {
+			out := GetVehicle(test.vehicleGuid)
+			if out.Status != test.status {
+				t.Fatalf("status = %d, want %d", out.Status, test.status)
+			}
+			if out.Status == FAILED {
+				if out.Error == nil || out.Error.Code != 400 {
+					t.Errorf("error = %v, want code 400", out.Error)
+				}
+				return
+			}
+			defer ReleaseVehicle(out.VehicleId)
+			if len(out.Protocol) != len(test.protocols) {
+				t.Fatalf("protocols = %v, want %v", out.Protocol, test.protocols)
+			}
+			for i := 0; i < len(out.Protocol); i++ {
+				if out.Protocol[i] != test.protocols[i] {
+					t.Errorf("protocols = %v, want %v", out.Protocol, test.protocols)
+				}
+			}
+		})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	if out := Connect(vehicleId, testProtocol, ""); out.Status != SUCCESSFUL {
+		t.Errorf("Connect of connected protocol: status=%d, error=%v", out.Status, out.Error)
+	}
+	if out := Connect(vehicleId, "VISSv3.0-mqtt", ""); out.Status != FAILED || out.Error.Code != 400 {
+		t.Errorf("Connect of unsupported protocol: status=%d, error=%v", out.Status, out.Error)
+	}
+	RegisterVehicle("closedPortVin", "127.0.0.1", []ConnectivityData{{server.Port(), testProtocol}})
+	getVehicleOut := GetVehicle("closedPortVin")
+	defer ReleaseVehicle(getVehicleOut.VehicleId)
+	server.Close()
+	if out := Connect(getVehicleOut.VehicleId, testProtocol, ""); out.Status != FAILED || out.Error.Code != 502 {
+		t.Errorf("Connect to closed port: status=%d, error=%v", out.Status, out.Error)
+	}
+}
+
+func TestSelectProtocol(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	if out := SelectProtocol(vehicleId, testProtocol); out.Status != SUCCESSFUL {
+		t.Errorf("SelectProtocol of connected protocol: %v", out.Error)
+	}
+	if out := SelectProtocol(vehicleId, "VISSv3.0-grpc"); out.Status != FAILED {
+		t.Errorf("SelectProtocol of unconnected protocol: status=%d", out.Status)
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	subscribeOut := Subscribe(vehicleId, "Vehicle.Speed", "", "", func(SubscribeOutput) {})
+	if subscribeOut.Status != ONGOING {
+		t.Fatalf("Subscribe: %v", subscribeOut.Error)
+	}
+	if out := Disconnect(vehicleId, testProtocol); out.Status != SUCCESSFUL {
+		t.Fatalf("Disconnect: %v", out.Error)
+	}
+	vehConn := getVehicleConnection(vehicleId)
+	if vehConn.connectedData != nil || vehConn.selectedProtocol != "" {
+		t.Errorf("connection not removed by Disconnect")
+	}
+	if out := Get(vehicleId, "Vehicle.Speed", "", ""); out.Status != FAILED {
+		t.Errorf("Get after Disconnect: status=%d", out.Status)
+	}
+	if out := GetPropertiesSeating(vehicleId); out.Status != FAILED {
+		t.Errorf("GetPropertiesSeating after Disconnect: status=%d", out.Status)
+	}
+	if out := Disconnect(0, testProtocol); out.Status != FAILED {
+		t.Errorf("Disconnect of unknown vehicle: status=%d", out.Status)
+	}
+}
+
+func TestUnknownVehicle(t *testing.T) {
+	const vehicleId = VehicleHandle(0)
+	seatId := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	out1 := SelectProtocol(vehicleId, testProtocol)
+	out2 := GetMetadata(vehicleId, "Vehicle", "")
+	out3 := Set(vehicleId, "Vehicle.Speed", "1", "")
+	out4 := Get(vehicleId, "Vehicle.Speed", "", "")
+	out5 := Subscribe(vehicleId, "Vehicle.Speed", "", "", nil)
+	out6 := Unsubscribe(vehicleId, 1)
+	out7 := CancelService(vehicleId, 1)
+	out8 := MoveSeat(vehicleId, seatId, LONGITUDINAL, 10, "", nil)
+	out9 := ConfigureSeat(vehicleId, seatId, nil, "", nil)
+	out10 := ActivateMassage(vehicleId, seatId, ROLL, 10, 1, "", nil)
+	out11 := GetPropertiesSeating(vehicleId)
+	out12 := HvacService1(vehicleId)
+	out13 := ActivateSeatHeating(vehicleId, seatId, 10, 1, "", nil)
+	out14 := ActivateMassageProgram(vehicleId, seatId, []MassageStep{{MassageType: ROLL, Intensity: 10, Duration: 1}}, "", nil)
+	out15 := SaveSeatPreset(vehicleId, seatId, "p", "")
+	out16 := RecallSeatPreset(vehicleId, seatId, "p", "", nil)
+	tests := []struct {
+		name string
+		status ProcedureStatus
+		err *ErrorData
+	}{
+		{"SelectProtocol", out1.Status, out1.Error},
+		{"GetMetadata", out2.Status, out2.Error},
+		{"Set", out3.Status, out3.Error},
+		{"Get", out4.Status, out4.Error},
+		{"Subscribe", out5.Status, out5.Error},
+		{"Unsubscribe", out6.Status, out6.Error},
+		{"CancelService", out7.Status, out7.Error},
+		{"MoveSeat", out8.Status, out8.Error},
+		{"ConfigureSeat", out9.Status, out9.Error},
+		{"ActivateMassage", out10.Status, out10.Error},
+		{"GetPropertiesSeating", out11.Status, out11.Error},
+		{"HvacService1", out12.Status, out12.Error},
+		{"ActivateSeatHeating", out13.Status, out13.Error},
+		{"ActivateMassageProgram", out14.Status, out14.Error},
+		{"SaveSeatPreset", out15.Status, out15.Error},
+		{"RecallSeatPreset", out16.Status, out16.Error},
+	}
+	for _, test := range tests {
+		if test.status != FAILED || test.err == nil || test.err.Code != 400 {
+			t.Errorf("%s of unknown vehicle: status=%d, error=%v", test.name, test.status, test.err)
+		}
+	}
+}
+
+func TestNotImplemented(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	inquiryOut := ServiceInquiry(vehicleId)
+	invokeOut := Invoke(vehicleId, "service", "", "", nil)
+	credentialsOut := GetStCredentials(vehicleId, "", "")
+	for name, err := range map[string]*ErrorData{"ServiceInquiry": inquiryOut.Error, "Invoke": invokeOut.Error, "GetStCredentials": credentialsOut.Error} {
+		if err == nil || err.Code != 503 {
+			t.Errorf("%s: error = %v, want code 503", name, err)
+		}
+	}
+}
+
+func TestGetMetadata(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	out := GetMetadata(vehicleId, "Vehicle.Speed", "")
+	if out.Status != SUCCESSFUL || !strings.Contains(out.Metadata, `"Speed"`) {
+		t.Errorf("GetMetadata: status=%d, metadata=%s, error=%v", out.Status, out.Metadata, out.Error)
+	}
+	tree, err := vapi.ParseMetadata("Vehicle.Speed", out.Metadata)
+	if err != nil {
+		t.Fatalf("ParseMetadata: %s", err)
+	}
+	if speed := tree.Lookup("Vehicle.Speed"); speed == nil || speed.Type != vapi.SENSOR || speed.Datatype != "float" || speed.CheckValue("50") != nil {
+		t.Errorf("Vehicle.Speed = %+v", speed)
+	}
+	out = GetMetadata(vehicleId, "Vehicle.Unknown", "")
+	if out.Status != FAILED || out.Error.Code != 404 {
+		t.Errorf("GetMetadata of unknown path: status=%d, error=%v", out.Status, out.Error)
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	tests := []struct {
+		name string
+		path string
+		value string
+		code int32
+	}{
+		{"missing value", "Vehicle.Cabin.Seat.Row1.DriverSide.Position", "", 400},
+		{"sensor", "Vehicle.Speed", "10", 400},
+		{"unknown path", "Vehicle.Unknown", "10", 404},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := Set(vehicleId, test.path, test.value, "")
+			if out.Status != FAILED || out.Error.Code != test.code {
+				t.Errorf("status=%d, error=%v, want code %d", out.Status, out.Error, test.code)
+			}
+		})
+	}
+}
+
+func TestSubscribeErrors(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	out := Subscribe(vehicleId, "Vehicle.Unknown", "", "", nil)
+	if out.Status != FAILED || out.Error.Code != 404 {
+		t.Errorf("Subscribe of unknown path: status=%d, error=%v", out.Status, out.Error)
+	}
+	if out := Unsubscribe(vehicleId, 12345); out.Status != FAILED {
+		t.Errorf("Unsubscribe of unknown service: status=%d", out.Status)
+	}
+	if out := CancelService(vehicleId, 12345); out.Status != FAILED {
+		t.Errorf("CancelService of unknown service: status=%d", out.Status)
+	}
+}
+
+func TestMoveSeatErrors(t *testing.T) {
+	signals := fastSignals()
+	for i := 0; i < len(signals); i++ {
+		if strings.HasSuffix(signals[i].Path, "DriverSide.Position") {
+			signals[i].Rate = 1 // keeps the first movement ongoing
+		}
+	}
+	_, vehicleId := startMockVehicle(t, signals)
+	driver := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	first := MoveSeat(vehicleId, driver, LONGITUDINAL, 50, "", nil)
+	if first.Status != ONGOING {
+		t.Fatalf("MoveSeat: status=%d, error=%v", first.Status, first.Error)
+	}
+	defer CancelService(vehicleId, first.ServiceId)
+	tests := []struct {
+		name string
+		seatId MatrixId
+		movementType string
+		position Percentage
+		code int32
+	}{
+		{"position below range", driver, LONGITUDINAL, -1, 400},
+		{"position above range", driver, LONGITUDINAL, 101, 400},
+		{"unsupported movement", MatrixId{RowName: "Row1", ColumnName: "PassengerSide"}, LUMBAR, 10, 400},
+		{"unknown movement", driver, "sideways", 10, 400},
+		{"busy", driver, LONGITUDINAL, 10, 503},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := MoveSeat(vehicleId, test.seatId, test.movementType, test.position, "", nil)
+			if out.Status != FAILED || out.Error.Code != test.code {
+				t.Errorf("status=%d, error=%v, want code %d", out.Status, out.Error, test.code)
+			}
+		})
+	}
+}
+
+func TestCancelMoveSeat(t *testing.T) {
+	signals := fastSignals()
+	for i := 0; i < len(signals); i++ {
+		if strings.HasSuffix(signals[i].Path, "DriverSide.Position") {
+			signals[i].Rate = 1
+		}
+	}
+	_, vehicleId := startMockVehicle(t, signals)
+	driver := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	eventChan := make(chan MoveSeatOutput, 10)
+	moveSeatOut := MoveSeat(vehicleId, driver, LONGITUDINAL, BACKWARD, "", func(out MoveSeatOutput) {
+		eventChan <- out
+	})
+	if moveSeatOut.Status != ONGOING {
+		t.Fatalf("MoveSeat: status=%d, error=%v", moveSeatOut.Status, moveSeatOut.Error)
+	}
+	if out := CancelService(vehicleId, moveSeatOut.ServiceId); out.Status != SUCCESSFUL {
+		t.Fatalf("CancelService: %v", out.Error)
+	}
+	event := waitFor(t, eventChan, func(out MoveSeatOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || event.Error.Reason != "cancelled" {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+	vehConn := getVehicleConnection(vehicleId)
+	if isMoving(&vehConn.connectedData, testProtocol, driver, LONGITUDINAL) {
+		t.Errorf("movement still active after CancelService")
+	}
+	if out := MoveSeat(vehicleId, driver, LONGITUDINAL, FORWARD, "", nil); out.Status != ONGOING {
+		t.Errorf("MoveSeat after CancelService: status=%d, error=%v", out.Status, out.Error)
+	} else {
+		CancelService(vehicleId, out.ServiceId)
+	}
+}
+
+func TestConfigureSeat(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	eventChan := make(chan ConfigureSeatOutput, 10)
+	seatId := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	configuration := []SeatConfig{{MovementType: LONGITUDINAL, Position: 10}, {MovementType: LUMBAR, Position: 50}, {MovementType: VERTICAL, Position: 20}, {MovementType: BACKREST, Position: 30}}
+	out := ConfigureSeat(vehicleId, seatId, configuration, "", func(out ConfigureSeatOutput) {
+		eventChan <- out
+	})
+	if out.Status != ONGOING {
+		t.Fatalf("ConfigureSeat: status=%d, error=%v", out.Status, out.Error)
+	}
+	event := waitFor(t, eventChan, func(out ConfigureSeatOutput) bool { return out.Status != ONGOING })
+	if event.Status != SUCCESSFUL || len(event.Configured) != 2 {
+		t.Fatalf("final callback: %+v", event)
+	}
+	if len(event.Unconfigured) != 2 || event.Unconfigured[0] != VERTICAL || event.Unconfigured[1] != BACKREST {
+		t.Errorf("Unconfigured = %v, want [%s %s]", event.Unconfigured, VERTICAL, BACKREST)
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Backrest.Lumbar.Support"); value != "50" {
+		t.Errorf("lumbar support = %s, want 50", value)
+	}
+}
+
+func TestConfigureSeatNothingConfigured(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	eventChan := make(chan ConfigureSeatOutput, 10)
+	out := ConfigureSeat(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, []SeatConfig{{MovementType: "unknown", Position: 50}}, "", func(out ConfigureSeatOutput) {
+		eventChan <- out
+	})
+	if out.Status != ONGOING {
+		t.Fatalf("ConfigureSeat: status=%d, error=%v", out.Status, out.Error)
+	}
+	event := waitFor(t, eventChan, func(out ConfigureSeatOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || len(event.Configured) != 0 || len(event.Unconfigured) != 1 {
+		t.Errorf("final callback: %+v, error=%v", event, event.Error)
+	}
+}
+
+func TestCancelConfigureSeat(t *testing.T) {
+	signals := fastSignals()
+	for i := 0; i < len(signals); i++ {
+		if strings.HasSuffix(signals[i].Path, "DriverSide.Position") {
+			signals[i].Rate = 1
+		}
+	}
+	_, vehicleId := startMockVehicle(t, signals)
+	eventChan := make(chan ConfigureSeatOutput, 10)
+	driver := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	out := ConfigureSeat(vehicleId, driver, []SeatConfig{{MovementType: LONGITUDINAL, Position: BACKWARD}}, "", func(out ConfigureSeatOutput) {
+		eventChan <- out
+	})
+	if out.Status != ONGOING || out.ServiceId == 0 {
+		t.Fatalf("ConfigureSeat: status=%d, serviceId=%d, error=%v", out.Status, out.ServiceId, out.Error)
+	}
+	if event := waitFor(t, eventChan, func(out ConfigureSeatOutput) bool { return true }); event.ServiceId != out.ServiceId {
+		t.Errorf("event ServiceId = %d, want %d", event.ServiceId, out.ServiceId)
+	}
+	if cancelOut := CancelService(vehicleId, out.ServiceId); cancelOut.Status != SUCCESSFUL {
+		t.Fatalf("CancelService: %v", cancelOut.Error)
+	}
+	event := waitFor(t, eventChan, func(out ConfigureSeatOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || event.Error.Reason != "cancelled" {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+	vehConn := getVehicleConnection(vehicleId)
+	deadline := time.Now().Add(2 * time.Second)
+	for isMoving(&vehConn.connectedData, testProtocol, driver, LONGITUDINAL) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if isMoving(&vehConn.connectedData, testProtocol, driver, LONGITUDINAL) {
+		t.Errorf("movement still active after CancelService of the configuration")
+	}
+}
+
+func TestActivateMassageErrors(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	tests := []struct {
+		name string
+		seatId MatrixId
+		massageType string
+		intensity Percentage
+	}{
+		{"intensity below range", MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, ROLL, -1},
+		{"intensity above range", MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, ROLL, 101},
+		{"unsupported type", MatrixId{RowName: "Row1", ColumnName: "PassengerSide"}, WAVE, 10},
+		{"unknown seat", MatrixId{RowName: "Row3", ColumnName: "DriverSide"}, ROLL, 10},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := ActivateMassage(vehicleId, test.seatId, test.massageType, test.intensity, 1, "", nil)
+			if out.Status != FAILED || out.Error.Code != 400 {
+				t.Errorf("status=%d, error=%v", out.Status, out.Error)
+			}
+		})
+	}
+}
+
+func TestActivateMassageProgram(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	eventChan := make(chan MassageProgramOutput, 10)
+	seatId := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	steps := []MassageStep{{MassageType: ROLL, Intensity: 30, Duration: 1}, {MassageType: WAVE, Intensity: 60, Duration: 1}}
+	out := ActivateMassageProgram(vehicleId, seatId, steps, "", func(out MassageProgramOutput) {
+		eventChan <- out
+	})
+	if out.Status != ONGOING {
+		t.Fatalf("ActivateMassageProgram: status=%d, error=%v", out.Status, out.Error)
+	}
+	if busyOut := ActivateMassageProgram(vehicleId, seatId, steps, "", nil); busyOut.Status != FAILED || busyOut.Error.Code != 503 {
+		t.Errorf("second program on same seat: status=%d, error=%v", busyOut.Status, busyOut.Error)
+	}
+	maxStep := -1
+	event := waitFor(t, eventChan, func(out MassageProgramOutput) bool {
+		if out.StepIndex > maxStep {
+			maxStep = out.StepIndex
+		}
+		return out.Status != ONGOING
+	})
+	if event.Status != SUCCESSFUL || maxStep != 1 || event.ServiceId != out.ServiceId {
+		t.Errorf("final callback: %+v, max step %d", event, maxStep)
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType"); value != WAVE {
+		t.Errorf("massage type = %s, want %s", value, WAVE)
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.IsOn"); value != "false" {
+		t.Errorf("massage not switched off at program end")
+	}
+}
+
+func TestCancelMassageProgram(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	seatId := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	tests := []struct {
+		name string
+		steps []MassageStep
+		code int32
+	}{
+		{"no steps", nil, 400},
+		{"intensity out of range", []MassageStep{{MassageType: ROLL, Intensity: 200, Duration: 1}}, 400},
+		{"unsupported type", []MassageStep{{MassageType: ROLL, Intensity: 20, Duration: 1}, {MassageType: "knead", Intensity: 20, Duration: 1}}, 400},
+		{"missing duration", []MassageStep{{MassageType: ROLL, Intensity: 20, Duration: 1}, {MassageType: WAVE, Intensity: 20}}, 400},
+	}
+	for _, test := range tests {
+		if out := ActivateMassageProgram(vehicleId, seatId, test.steps, "", nil); out.Status != FAILED || out.Error.Code != test.code {
+			t.Errorf("%s: status=%d, error=%v", test.name, out.Status, out.Error)
+		}
+	}
+	eventChan := make(chan MassageProgramOutput, 10)
+	out := ActivateMassageProgram(vehicleId, seatId, []MassageStep{{MassageType: ROLL, Intensity: 30, Duration: 60}}, "", func(out MassageProgramOutput) {
+		eventChan <- out
+	})
+	if out.Status != ONGOING {
+		t.Fatalf("ActivateMassageProgram: status=%d, error=%v", out.Status, out.Error)
+	}
+	if cancelOut := CancelService(vehicleId, out.ServiceId); cancelOut.Status != SUCCESSFUL {
+		t.Fatalf("CancelService: %v", cancelOut.Error)
+	}
+	event := waitFor(t, eventChan, func(out MassageProgramOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || event.Error.Reason != "cancelled" {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.IsOn"); value == "false" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("massage not switched off after CancelService")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestActivateSeatClimate(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	tests := []struct {
+		name string
+		activate func(VehicleHandle, MatrixId, Percentage, uint32, string, func(SeatClimateOutput)) SeatClimateOutput
+		seatId MatrixId
+		level Percentage
+		path string
+		value string
+		code int32
+	}{
+		{"heating", ActivateSeatHeating, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, 40, "Vehicle.Cabin.Seat.Row1.DriverSide.Heating", "40", 0},
+		{"ventilation", ActivateSeatVentilation, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, 70, "Vehicle.Cabin.Seat.Row1.DriverSide.HeatingCooling", "-70", 0},
+		{"level out of range", ActivateSeatHeating, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, 120, "", "", 400},
+		{"unsupported", ActivateSeatVentilation, MatrixId{RowName: "Row1", ColumnName: "PassengerSide"}, 50, "", "", 400},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eventChan := make(chan SeatClimateOutput, 10)
+			out := test.activate(vehicleId, test.seatId, test.level, 1, "", func(out SeatClimateOutput) {
+				eventChan <- out
+			})
+			if test.code != 0 {
+				if out.Status != FAILED || out.Error.Code != test.code {
+					t.Errorf("status=%d, error=%v, want code %d", out.Status, out.Error, test.code)
+				}
+				return
+			}
+			if out.Status != ONGOING {
+				t.Fatalf("status=%d, error=%v", out.Status, out.Error)
+			}
+			if value, _ := server.GetValue(test.path); value != test.value {
+				t.Errorf("%s = %s, want %s", test.path, value, test.value)
+			}
+			event := waitFor(t, eventChan, func(out SeatClimateOutput) bool { return out.Status != ONGOING })
+			if event.Status != SUCCESSFUL {
+				t.Errorf("final callback: %+v", event)
+			}
+			if value, _ := server.GetValue(test.path); value != "0" {
+				t.Errorf("%s = %s after duration, want 0", test.path, value)
+			}
+		})
+	}
+}
+
+func TestCancelMassage(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	eventChan := make(chan MassageOutput, 10)
+	out := ActivateMassage(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, ROLL, 50, 3600, "", func(out MassageOutput) {
+		eventChan <- out
+	})
+	if out.Status != ONGOING {
+		t.Fatalf("ActivateMassage: status=%d, error=%v", out.Status, out.Error)
+	}
+	if cancelOut := CancelService(vehicleId, out.ServiceId); cancelOut.Status != SUCCESSFUL {
+		t.Fatalf("CancelService: %v", cancelOut.Error)
+	}
+	event := waitFor(t, eventChan, func(out MassageOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || event.Error.Reason != "cancelled" {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+}
+
+func TestCancelSeatClimate(t *testing.T) {
+	server, vehicleId := startMockVehicle(t, fastSignals())
+	eventChan := make(chan SeatClimateOutput, 10)
+	out := ActivateSeatHeating(vehicleId, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, 60, 3600, "", func(out SeatClimateOutput) {
+		eventChan <- out
+	})
+	if out.Status != ONGOING {
+		t.Fatalf("ActivateSeatHeating: status=%d, error=%v", out.Status, out.Error)
+	}
+	if cancelOut := CancelService(vehicleId, out.ServiceId); cancelOut.Status != SUCCESSFUL {
+		t.Fatalf("CancelService: %v", cancelOut.Error)
+	}
+	event := waitFor(t, eventChan, func(out SeatClimateOutput) bool { return out.Status != ONGOING })
+	if event.Status != FAILED || event.Error == nil || event.Error.Reason != "cancelled" {
+		t.Errorf("final callback: status=%d, error=%v", event.Status, event.Error)
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Heating"); value != "0" {
+		t.Errorf("heating = %s after CancelService, want 0", value)
+	}
+}
+
+func TestGetPropertiesSeating(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	out := GetPropertiesSeating(vehicleId)
+	if out.Status != SUCCESSFUL || len(out.Properties) != 2 {
+		t.Fatalf("GetPropertiesSeating: status=%d, properties=%v", out.Status, out.Properties)
+	}
+	driver := out.Properties[0].Column[0]
+	if driver.Name != "DriverSide" || len(driver.MovementSupport) != 3 || len(driver.MassageSupport) != 3 || len(driver.ClimateSupport) != 2 {
+		t.Errorf("unexpected driver seat properties: %+v", driver)
+	}
+	if out.Properties[1].Column[0].MovementSupport != nil {
+		t.Errorf("unexpected rear seat movement support: %+v", out.Properties[1].Column[0])
+	}
+}
+
+func TestHvacService1(t *testing.T) {
+	_, vehicleId := startMockVehicle(t, fastSignals())
+	if out := HvacService1(vehicleId); out.Status != SUCCESSFUL {
+		t.Errorf("HvacService1: %v", out.Error)
+	}
+}
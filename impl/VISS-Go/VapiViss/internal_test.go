@@ -0,0 +1,306 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VapiViss
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func unmarshalTestMessage(t *testing.T, message string) map[string]interface{} {
+	t.Helper()
+	var messageMap map[string]interface{}
+	err := json.Unmarshal([]byte(message), &messageMap)
+	if err != nil {
+		t.Fatalf("Unmarshal: %s", err)
+	}
+	return messageMap
+}
+
+func TestPopulateData(t *testing.T) {
+	tests := []struct {
+		name string
+		message string
+		want []DataContainer
+	}{
+		{"single container", `{"data":{"path":"Vehicle.Speed", "dp":{"value":"10", "ts":"t1"}}}`,
+			[]DataContainer{{Path: "Vehicle.Speed", Dp: []DataPoint{{Value: "10", Timestamp: "t1"}}}}},
+		{"container array", `{"data":[{"path":"A", "dp":{"value":"1", "ts":"t1"}}, {"path":"B", "dp":{"value":"2", "ts":"t2"}}]}`,
+			[]DataContainer{{Path: "A", Dp: []DataPoint{{Value: "1", Timestamp: "t1"}}}, {Path: "B", Dp: []DataPoint{{Value: "2", Timestamp: "t2"}}}}},
+		{"data point array", `{"data":{"path":"A", "dp":[{"value":"1", "ts":"t1"}, {"value":"2", "ts":"t2"}]}}`,
+			[]DataContainer{{Path: "A", Dp: []DataPoint{{Value: "1", Timestamp: "t1"}, {Value: "2", Timestamp: "t2"}}}}},
+		{"no data", `{}`, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := populateData(unmarshalTestMessage(t, test.message)["data"])
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("populateData = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPopulateDp(t *testing.T) {
+	tests := []struct {
+		name string
+		message string
+		want []DataPoint
+	}{
+		{"object", `{"dp":{"value":"1", "ts":"t1"}}`, []DataPoint{{Value: "1", Timestamp: "t1"}}},
+		{"array", `{"dp":[{"value":"1", "ts":"t1"}, {"value":"2", "ts":"t2"}]}`, []DataPoint{{Value: "1", Timestamp: "t1"}, {Value: "2", Timestamp: "t2"}}},
+		{"invalid", `{"dp":"1"}`, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := populateDp(unmarshalTestMessage(t, test.message)["dp"])
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("populateDp = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetErrorInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		message string
+		want ErrorData
+	}{
+		{"string number", `{"error":{"number":"404", "reason":"unavailable_data", "description":"Path not found"}}`, ErrorData{Code: 404, Reason: "unavailable_data", Description: "Path not found"}},
+		{"numeric number", `{"error":{"number":400, "reason":"bad_request"}}`, ErrorData{Code: 400, Reason: "bad_request"}},
+		{"empty", `{"error":{}}`, ErrorData{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := getErrorInfo(unmarshalTestMessage(t, test.message)["error"].(map[string]interface{}))
+			if *got != test.want {
+				t.Errorf("getErrorInfo = %v, want %v", *got, test.want)
+			}
+		})
+	}
+}
+
+func TestExtractMessageId(t *testing.T) {
+	tests := []struct {
+		name string
+		message string
+		want string
+	}{
+		{"request id", `{"requestId":"1"}`, "1"},
+		{"subscription id", `{"subscriptionId":"2"}`, "2"},
+		{"request id has precedence", `{"requestId":"1", "subscriptionId":"2"}`, "1"},
+		{"none", `{"action":"subscription"}`, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := extractMessageId(unmarshalTestMessage(t, test.message)); got != test.want {
+				t.Errorf("extractMessageId = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetSeatPositionedPath(t *testing.T) {
+	tests := []struct {
+		path string
+		seatId MatrixId
+		want string
+	}{
+		{"Vehicle.Cabin.Seat.RowX.ColumnY.Position", MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, "Vehicle.Cabin.Seat.Row1.DriverSide.Position"},
+		{"Vehicle.Cabin.Seat.RowX.ColumnY.Switch.Massage.IsOn", MatrixId{RowName: "Row2", ColumnName: "Middle"}, "Vehicle.Cabin.Seat.Row2.Middle.Switch.Massage.IsOn"},
+	}
+	for _, test := range tests {
+		if got := getSeatPositionedPath(test.path, test.seatId); got != test.want {
+			t.Errorf("getSeatPositionedPath(%s, %v) = %s, want %s", test.path, test.seatId, got, test.want)
+		}
+	}
+}
+
+func TestCheckSupport(t *testing.T) {
+	driver := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	passenger := MatrixId{RowName: "Row1", ColumnName: "PassengerSide"}
+	rearSeat := MatrixId{RowName: "Row2", ColumnName: "DriverSide"}
+	tests := []struct {
+		seatId MatrixId
+		support string
+		supportType string
+		want bool
+	}{
+		{driver, LONGITUDINAL, "move", true},
+		{driver, LUMBAR, "move", true},
+		{driver, BACKREST, "move", false},
+		{passenger, LONGITUDINAL, "move", true},
+		{passenger, LUMBAR, "move", false},
+		{rearSeat, LONGITUDINAL, "move", false},
+		{driver, WAVE, "massage", true},
+		{passenger, ROLL, "massage", true},
+		{passenger, PULSE, "massage", false},
+		{driver, VENTILATION, "climate", true},
+		{passenger, HEATING, "climate", true},
+		{passenger, VENTILATION, "climate", false},
+		{driver, LONGITUDINAL, "massage", false},
+		{driver, LONGITUDINAL, "unknown", false},
+		{MatrixId{RowName: "Row9", ColumnName: "DriverSide"}, LONGITUDINAL, "move", false},
+	}
+	for _, test := range tests {
+		if got := checkSupport(test.seatId, test.support, test.supportType); got != test.want {
+			t.Errorf("checkSupport(%v, %s, %s) = %t, want %t", test.seatId, test.support, test.supportType, got, test.want)
+		}
+	}
+}
+
+func TestSeatConfigComplete(t *testing.T) {
+	configuration := []SeatConfig{{MovementType: LONGITUDINAL, Position: 10}, {MovementType: LUMBAR, Position: 50}}
+	mapData := []MapData{{1, 0}, {2, 1}}
+	tests := []struct {
+		name string
+		configured []SeatConfig
+		want bool
+	}{
+		{"all reached", []SeatConfig{{MovementType: LONGITUDINAL, Position: 10}, {MovementType: LUMBAR, Position: 50}}, true},
+		{"one ongoing", []SeatConfig{{MovementType: LONGITUDINAL, Position: 10}, {MovementType: LUMBAR, Position: 20}}, false},
+		{"none configured", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eventOut := ConfigureSeatOutput{Configured: test.configured}
+			if got := seatConfigComplete(eventOut, configuration, mapData); got != test.want {
+				t.Errorf("seatConfigComplete = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestActiveServiceList(t *testing.T) {
+	var connectedDataList *ConnectedData
+	if addActiveService(&connectedDataList, "p1", 1, "m1", "") != nil {
+		t.Fatalf("addActiveService on empty connection list returned a channel")
+	}
+	addConnectedData(&connectedDataList, &ConnectedData{protocol: "p1"})
+	addConnectedData(&connectedDataList, &ConnectedData{protocol: "p2"})
+	chan1 := addActiveService(&connectedDataList, "p1", 1, "m1", "")
+	chan2 := addActiveService(&connectedDataList, "p1", 2, "m2", createMoveSeatName(LUMBAR, MatrixId{RowName: "Row1", ColumnName: "DriverSide"}))
+	chan3 := addActiveService(&connectedDataList, "p1", 3, "m3", "")
+	chan4 := addActiveService(&connectedDataList, "p2", 4, "m4", "")
+	if chan1 == nil || chan2 == nil || chan3 == nil || chan4 == nil {
+		t.Fatalf("addActiveService returned a nil channel")
+	}
+	if addActiveService(&connectedDataList, "p3", 5, "m5", "") != nil {
+		t.Errorf("addActiveService for unconnected protocol returned a channel")
+	}
+	if getMessageChan(&connectedDataList, "p1", "m2") != chan2 || getMessageChan(&connectedDataList, "p2", "m4") != chan4 {
+		t.Errorf("getMessageChan returned the wrong channel")
+	}
+	if getMessageChan(&connectedDataList, "p2", "m1") != nil {
+		t.Errorf("getMessageChan matched a message id of another protocol")
+	}
+	if getProtocol(&connectedDataList, 4) != "p2" || getProtocol(&connectedDataList, 9) != "" {
+		t.Errorf("getProtocol returned the wrong protocol")
+	}
+	if !isMoving(&connectedDataList, "p1", MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, LUMBAR) || isMoving(&connectedDataList, "p1", MatrixId{RowName: "Row1", ColumnName: "DriverSide"}, LONGITUDINAL) {
+		t.Errorf("isMoving returned the wrong result")
+	}
+	cancelChan := make(chan string)
+	if !saveCancelHandle(&connectedDataList, "p1", 3, "sub3", cancelChan) || saveCancelHandle(&connectedDataList, "p1", 9, "sub9", cancelChan) {
+		t.Errorf("saveCancelHandle returned the wrong result")
+	}
+	if getCancelData(&connectedDataList, "p1", 3) != "sub3" || getMessageChan(&connectedDataList, "p1", "sub3") != chan3 {
+		t.Errorf("saved cancel handle not found")
+	}
+	if getActiveService(&connectedDataList, "p1", 3).cancelChan != cancelChan {
+		t.Errorf("getActiveService returned the wrong service")
+	}
+
+	removeActiveService(&connectedDataList, "p1", 2) // removing in the middle must keep the following services
+	if getCancelData(&connectedDataList, "p1", 2) != "" || getCancelData(&connectedDataList, "p1", 3) != "sub3" {
+		t.Errorf("removeActiveService did not remove only the addressed service")
+	}
+	removeActiveService(&connectedDataList, "p1", 1)
+	if getActiveServiceId(&connectedDataList, "p1") != 3 {
+		t.Errorf("getActiveServiceId = %d, want 3", getActiveServiceId(&connectedDataList, "p1"))
+	}
+	removeActiveService(&connectedDataList, "p1", 3)
+	if getActiveServiceId(&connectedDataList, "p1") != 0 || getActiveServiceId(&connectedDataList, "p2") != 4 {
+		t.Errorf("getActiveServiceId returned the wrong service after removal")
+	}
+}
+
+func TestVehicleConnectionList(t *testing.T) {
+	savedList := vehConnList
+	defer func() { vehConnList = savedList }()
+	vehConnList = nil
+	if getVehicleConnection(1) != nil {
+		t.Fatalf("getVehicleConnection on empty list returned a connection")
+	}
+	vehConn1 := &VehicleConnection{vehicleGuid: "vin1", vehicleId: 1}
+	vehConn2 := &VehicleConnection{vehicleGuid: "vin2", vehicleId: 2}
+	addVehicleConnection(vehConn1)
+	addVehicleConnection(vehConn2)
+	if getVehicleConnection(2) != vehConn2 || getVehicleConnection(3) != nil {
+		t.Errorf("getVehicleConnection returned the wrong connection")
+	}
+	if ReleaseVehicle(1).Status != SUCCESSFUL || getVehicleConnection(1) != nil || getVehicleConnection(2) != vehConn2 {
+		t.Errorf("ReleaseVehicle did not remove only the addressed vehicle")
+	}
+	if ReleaseVehicle(1).Status != FAILED {
+		t.Errorf("ReleaseVehicle of released vehicle did not fail")
+	}
+}
+
+func TestRemoveConnection(t *testing.T) {
+	vehConn := &VehicleConnection{selectedProtocol: "p2"}
+	addConnectedData(&vehConn.connectedData, &ConnectedData{protocol: "p1"})
+	addConnectedData(&vehConn.connectedData, &ConnectedData{protocol: "p2"})
+	removeConnection(&vehConn, "p2")
+	if vehConn.selectedProtocol != "" || vehConn.connectedData.protocol != "p1" || vehConn.connectedData.next != nil {
+		t.Errorf("removeConnection did not remove the selected protocol")
+	}
+}
+
+func TestGetSeatActuatorData(t *testing.T) {
+	seatId := MatrixId{RowName: "Row1", ColumnName: "DriverSide"}
+	tests := []struct {
+		movementType string
+		path string
+		A, B Percentage
+	}{
+		{LONGITUDINAL, "Vehicle.Cabin.Seat.Row1.DriverSide.Position", 3, 0},
+		{LUMBAR, "Vehicle.Cabin.Seat.Row1.DriverSide.Backrest.Lumbar.Support", 1, 0},
+		{BACKREST, "Vehicle.Cabin.Seat.Row1.DriverSide.Backrest.Recline", 0.9, -45},
+		{VERTICAL, "", 1, 0},
+	}
+	for _, test := range tests {
+		path, A, B := getSeatActuatorData(test.movementType, seatId)
+		if path != test.path || A != test.A || B != test.B {
+			t.Errorf("getSeatActuatorData(%s) = %s, %f, %f", test.movementType, path, A, B)
+		}
+	}
+}
+
+func TestReformatOutput(t *testing.T) {
+	errorMessage := unmarshalTestMessage(t, `{"error":{"number":"401", "reason":"bad_request", "description":"d"}}`)
+	for _, outputType := range []string{"set", "get", "getmetadata", "subscribe", "unsubscribe"} {
+		var status ProcedureStatus
+		switch out := reformatOutput(errorMessage, outputType).(type) {
+			case GeneralOutput: status = out.Status
+			case GetOutput: status = out.Status
+			case GetMetadataOutput: status = out.Status
+			case SubscribeOutput: status = out.Status
+		}
+		if status != FAILED {
+			t.Errorf("reformatOutput(%s) of error message: status = %d", outputType, status)
+		}
+	}
+	metadataOut := reformatOutput(unmarshalTestMessage(t, `{"metadata":{"Vehicle":{"type":"branch"}}}`), "getmetadata").(GetMetadataOutput)
+	if metadataOut.Status != SUCCESSFUL || metadataOut.Metadata != `{"Vehicle":{"type":"branch"}}` {
+		t.Errorf("reformatOutput(getmetadata) of object = %+v", metadataOut)
+	}
+}
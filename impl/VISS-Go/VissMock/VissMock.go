@@ -0,0 +1,583 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VissMock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+/* VissMock is an in-process VISSv3.0 server for tests. It holds an in-memory signal tree,
+*  where a set of an actuator starts a movement of the actuator value towards the set value with the rate of the signal.
+*  The get, set, subscribe and unsubscribe actions are supported, with the paths, metadata and timebased filters. */
+type Signal struct {
+	Path string
+	Type string      // branch, sensor, actuator, or attribute
+	Datatype string  // VSS datatype, e.g. uint8, float, boolean, string
+	Value string
+	Rate float64     // change of an actuator value per second when moving towards the set value, 0 = immediate
+	Description string
+}
+
+type signalState struct {
+	signal Signal
+	target float64
+	moving bool
+	ts string
+	history []historyPoint  // oldest first, at most historySize points
+}
+
+type historyPoint struct {
+	value string
+	ts string
+}
+
+type Server struct {
+	mutex sync.Mutex
+	signals map[string]*signalState
+	listener net.Listener
+	httpServer *http.Server
+	connections map[*websocket.Conn]*wsSession
+	dynamicsStop chan struct{}
+	closeOnce sync.Once
+}
+
+type wsSession struct {
+	conn *websocket.Conn
+	writeMutex sync.Mutex
+	subscriptions map[string]chan struct{}
+}
+
+const dynamicsTick = 50 * time.Millisecond
+const historySize = 1000
+
+var subscriptionCounter uint32
+
+func NewServer(signals []Signal) *Server {
+	var server Server
+	server.signals = make(map[string]*signalState)
+	server.connections = make(map[*websocket.Conn]*wsSession)
+	server.dynamicsStop = make(chan struct{})
+	for i := 0; i < len(signals); i++ {
+		state := &signalState{signal: signals[i]}
+		state.update(signals[i].Value)
+		server.signals[signals[i].Path] = state
+	}
+	go server.runDynamics()
+	return &server
+}
+
+// DefaultSignals returns a signal tree with the signals used by the VapiViss services for the seats Row1.DriverSide and Row1.PassengerSide.
+func DefaultSignals() []Signal {
+	signals := []Signal{
+		{"Vehicle.Speed", "sensor", "float", "0", 0, "Vehicle speed."},
+		{"Vehicle.CurrentLocation.Latitude", "sensor", "double", "57.7", 0, "Current latitude of vehicle."},
+		{"Vehicle.CurrentLocation.Longitude", "sensor", "double", "11.9", 0, "Current longitude of vehicle."},
+	}
+	for _, column := range []string{"DriverSide", "PassengerSide"} {
+		seatPath := "Vehicle.Cabin.Seat.Row1." + column
+		signals = append(signals, []Signal{
+			{seatPath + ".IsOccupied", "sensor", "boolean", "true", 0, "Does the seat have a passenger in it."},
+			{seatPath + ".Position", "actuator", "uint16", "0", 100, "Seat position on vehicle x-axis."},
+			{seatPath + ".Height", "actuator", "uint16", "0", 100, "Seat position on vehicle z-axis."},
+			{seatPath + ".Backrest.Recline", "actuator", "float", "0", 20, "Backrest recline."},
+			{seatPath + ".Backrest.Lumbar.Support", "actuator", "float", "0", 20, "Lumbar support."},
+			{seatPath + ".Heating", "actuator", "int8", "0", 0, "Seat cooling / heating."},
+			{seatPath + ".HeatingCooling", "actuator", "int8", "0", 0, "Heating or Cooling requested for the seat."},
+			{seatPath + ".Switch.Massage.IsOn", "actuator", "boolean", "false", 0, "Indicates if massage is on or off."},
+			{seatPath + ".Switch.Massage.Intensity", "actuator", "float", "0", 0, "The intensity of the massage."},
+			{seatPath + ".Switch.Massage.MassageType", "actuator", "string", "", 0, "The type of massage."},
+		}...)
+	}
+	return signals
+}
+
+// StartWs starts serving the VISS websocket transport on address, e.g. "127.0.0.1:0", and returns the address that is listened on.
+func (server *Server) StartWs(address string) (string, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", err
+	}
+	server.listener = listener
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", server.serveWs)
+	server.httpServer = &http.Server{Handler: mux}
+	go server.httpServer.Serve(listener)
+	return listener.Addr().String(), nil
+}
+
+// Port returns the port number of the websocket transport, as used in VapiViss.ConnectivityData.
+func (server *Server) Port() string {
+	_, port, _ := net.SplitHostPort(server.listener.Addr().String())
+	return port
+}
+
+func (server *Server) Close() {
+	server.closeOnce.Do(func() {
+		close(server.dynamicsStop)
+		if server.httpServer != nil {
+			server.httpServer.Close()
+		}
+		server.mutex.Lock()
+		for conn := range server.connections {
+			conn.Close()
+		}
+		server.mutex.Unlock()
+	})
+}
+
+// SetValue sets the current value of a signal directly, without actuator dynamics, e.g. to simulate a changed sensor value.
+func (server *Server) SetValue(path string, value string) bool {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	state := server.signals[path]
+	if state == nil {
+		return false
+	}
+	state.update(value)
+	state.moving = false
+	return true
+}
+
+func (server *Server) GetValue(path string) (string, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	state := server.signals[path]
+	if state == nil {
+		return "", false
+	}
+	return state.signal.Value, true
+}
+
+// Actuate sets the value of an actuator as a set request does, i. e. with the actuator dynamics. It returns false if path is not an actuator.
+func (server *Server) Actuate(path string, value string) bool {
+	return server.setValue(path, value) == nil
+}
+
+// Subscriptions returns the number of active subscriptions on all connections.
+func (server *Server) Subscriptions() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	count := 0
+	for _, session := range server.connections {
+		count += len(session.subscriptions)
+	}
+	return count
+}
+
+// Signals returns the signals with their current values, sorted by path.
+func (server *Server) Signals() []Signal {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	signals := make([]Signal, 0, len(server.signals))
+	for _, state := range server.signals {
+		signals = append(signals, state.signal)
+	}
+	sort.Slice(signals, func(i, j int) bool { return signals[i].Path < signals[j].Path })
+	return signals
+}
+
+func (server *Server) runDynamics() {
+	ticker := time.NewTicker(dynamicsTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <- server.dynamicsStop:
+			return
+		case <- ticker.C:
+			server.mutex.Lock()
+			for _, state := range server.signals {
+				if !state.moving {
+					continue
+				}
+				current, _ := strconv.ParseFloat(state.signal.Value, 64)
+				step := state.signal.Rate * dynamicsTick.Seconds()
+				if math.Abs(state.target - current) <= step {
+					current = state.target
+					state.moving = false
+				} else if state.target > current {
+					current += step
+				} else {
+					current -= step
+				}
+				state.update(formatValue(current, state.signal.Datatype))
+			}
+			server.mutex.Unlock()
+		}
+	}
+}
+
+func (server *Server) serveWs(w http.ResponseWriter, req *http.Request) {
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{"VISSv2"},
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		return
+	}
+	session := &wsSession{conn: conn, subscriptions: make(map[string]chan struct{})}
+	server.mutex.Lock()
+	server.connections[conn] = session
+	server.mutex.Unlock()
+	defer func() {
+		server.mutex.Lock()
+		delete(server.connections, conn)
+		for _, stopChan := range session.subscriptions {
+			close(stopChan)
+		}
+		session.subscriptions = nil
+		server.mutex.Unlock()
+		conn.Close()
+	}()
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var requestMap map[string]interface{}
+		err = json.Unmarshal(message, &requestMap)
+		if err != nil {
+			session.writeMessage(map[string]interface{}{"action": "unknown", "error": getErrorMap("400", "bad_request", "Invalid JSON"), "ts": getTimestamp()})
+			continue
+		}
+		session.writeMessage(server.processRequest(session, requestMap))
+	}
+}
+
+func (session *wsSession) writeMessage(messageMap map[string]interface{}) {
+	message, _ := json.Marshal(messageMap)
+	session.writeMutex.Lock()
+	session.conn.WriteMessage(websocket.TextMessage, message)
+	session.writeMutex.Unlock()
+}
+
+func (server *Server) processRequest(session *wsSession, requestMap map[string]interface{}) map[string]interface{} {
+	action, _ := requestMap["action"].(string)
+	requestId, _ := requestMap["requestId"].(string)
+	responseMap := map[string]interface{}{"action": action, "requestId": requestId, "ts": getTimestamp()}
+	path, _ := requestMap["path"].(string)
+	switch action {
+		case "get":
+			if isMetadataFilter(requestMap["filter"]) {
+				metadata, errorMap := server.getMetadata(path)
+				if errorMap != nil {
+					responseMap["error"] = errorMap
+				} else {
+					responseMap["metadata"] = metadata
+				}
+				return responseMap
+			}
+			data, errorMap := server.getData(path, requestMap["filter"])
+			if errorMap != nil {
+				responseMap["error"] = errorMap
+			} else {
+				responseMap["data"] = data
+			}
+		case "set":
+			value, _ := requestMap["value"].(string)
+			errorMap := server.setValue(path, value)
+			if errorMap != nil {
+				responseMap["error"] = errorMap
+			}
+		case "subscribe":
+			_, errorMap := server.getData(path, requestMap["filter"])
+			if errorMap != nil {
+				responseMap["error"] = errorMap
+				return responseMap
+			}
+			responseMap["subscriptionId"] = server.startSubscription(session, path, requestMap["filter"])
+		case "unsubscribe":
+			subscriptionId, _ := requestMap["subscriptionId"].(string)
+			responseMap["subscriptionId"] = subscriptionId
+			server.mutex.Lock()
+			stopChan := session.subscriptions[subscriptionId]
+			delete(session.subscriptions, subscriptionId)
+			server.mutex.Unlock()
+			if stopChan == nil {
+				responseMap["error"] = getErrorMap("404", "invalid_data", "Unknown subscriptionId")
+			} else {
+				close(stopChan)
+			}
+		default:
+			responseMap["error"] = getErrorMap("400", "bad_request", "Unknown action")
+	}
+	return responseMap
+}
+
+func (server *Server) startSubscription(session *wsSession, path string, filter interface{}) string {
+	subscriptionId := strconv.FormatUint(uint64(atomic.AddUint32(&subscriptionCounter, 1)), 10)
+	period := getPeriod(filter)
+	stopChan := make(chan struct{})
+	server.mutex.Lock()
+	session.subscriptions[subscriptionId] = stopChan
+	server.mutex.Unlock()
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <- stopChan:
+				return
+			case <- ticker.C:
+				eventMap := map[string]interface{}{"action": "subscription", "subscriptionId": subscriptionId, "ts": getTimestamp()}
+				data, errorMap := server.getData(path, filter)
+				if errorMap != nil {
+					eventMap["error"] = errorMap
+				} else {
+					eventMap["data"] = data
+				}
+				session.writeMessage(eventMap)
+			}
+		}
+	}()
+	return subscriptionId
+}
+
+func (server *Server) setValue(path string, value string) map[string]interface{} {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	state := server.signals[path]
+	if state == nil {
+		return getErrorMap("404", "unavailable_data", "Path not found: " + path)
+	}
+	if state.signal.Type != "actuator" {
+		return getErrorMap("400", "bad_request", "Set request on a non-actuator: " + path)
+	}
+	target, err := strconv.ParseFloat(value, 64)
+	if state.signal.Rate == 0 || err != nil {
+		if err == nil {
+			value = formatValue(target, state.signal.Datatype)
+		}
+		state.update(value)
+		state.moving = false
+		return nil
+	}
+	state.target = target
+	state.moving = true
+	return nil
+}
+
+// update sets the value of the signal, and adds it to the history of the signal.
+func (state *signalState) update(value string) {
+	state.signal.Value = value
+	state.ts = getTimestamp()
+	state.history = append(state.history, historyPoint{value, state.ts})
+	if len(state.history) > historySize {
+		state.history = state.history[len(state.history) - historySize:]
+	}
+}
+
+func (server *Server) getData(path string, filter interface{}) (interface{}, map[string]interface{}) {
+	paths := server.matchPaths(path, getPathsParameter(filter))
+	if len(paths) == 0 {
+		return nil, getErrorMap("404", "unavailable_data", "Path not found: " + path)
+	}
+	period, isHistory, err := getHistoryPeriod(filter)
+	if err != nil {
+		return nil, getErrorMap("400", "bad_request", "Invalid history period: " + err.Error())
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	data := make([]interface{}, len(paths))
+	for i := 0; i < len(paths); i++ {
+		state := server.signals[paths[i]]
+		if isHistory {  // the data points that were set within the period, oldest first
+			since := time.Now().Add(-period).UTC().Format("2006-01-02T15:04:05.000000Z")
+			dataPoints := []interface{}{}
+			for _, point := range state.history {
+				if point.ts >= since {
+					dataPoints = append(dataPoints, map[string]interface{}{"value": point.value, "ts": point.ts})
+				}
+			}
+			data[i] = map[string]interface{}{"path": paths[i], "dp": dataPoints}
+			continue
+		}
+		data[i] = map[string]interface{}{"path": paths[i], "dp": map[string]interface{}{"value": state.signal.Value, "ts": state.ts}}
+	}
+	if len(data) == 1 {
+		return data[0], nil
+	}
+	return data, nil
+}
+
+func (server *Server) matchPaths(path string, relativePaths []string) []string {
+	patterns := []string{path}
+	if len(relativePaths) > 0 {
+		patterns = make([]string, len(relativePaths))
+		for i := 0; i < len(relativePaths); i++ {
+			patterns[i] = path + "." + relativePaths[i]
+		}
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	var paths []string
+	for signalPath, state := range server.signals {
+		if state.signal.Type == "branch" {
+			continue
+		}
+		for _, pattern := range patterns {
+			if matchPath(pattern, signalPath) {
+				paths = append(paths, signalPath)
+				break
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func matchPath(pattern string, path string) bool { // a pattern matches itself, the leaves below it, and * matches one path segment
+	patternSegments := strings.Split(pattern, ".")
+	pathSegments := strings.Split(path, ".")
+	if len(pathSegments) < len(patternSegments) {
+		return false
+	}
+	for i := 0; i < len(patternSegments); i++ {
+		if patternSegments[i] != "*" && patternSegments[i] != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (server *Server) getMetadata(path string) (map[string]interface{}, map[string]interface{}) {
+	paths := server.matchPaths(path, nil)
+	if len(paths) == 0 {
+		return nil, getErrorMap("404", "unavailable_data", "Path not found: " + path)
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	root := make(map[string]interface{})
+	for _, signalPath := range paths {
+		signal := server.signals[signalPath].signal
+		segments := strings.Split(signalPath, ".")
+		children := root
+		for i := 0; i < len(segments) - 1; i++ {
+			if children[segments[i]] == nil {
+				children[segments[i]] = map[string]interface{}{"type": "branch", "description": segments[i] + " branch.", "children": make(map[string]interface{})}
+			}
+			children = children[segments[i]].(map[string]interface{})["children"].(map[string]interface{})
+		}
+		children[segments[len(segments)-1]] = map[string]interface{}{"type": signal.Type, "datatype": signal.Datatype, "description": signal.Description}
+	}
+	return root, nil
+}
+
+func isMetadataFilter(filter interface{}) bool {
+	for _, filterMap := range getFilterList(filter) {
+		if filterMap["variant"] == "metadata" {
+			return true
+		}
+	}
+	return false
+}
+
+func getPathsParameter(filter interface{}) []string {
+	for _, filterMap := range getFilterList(filter) {
+		if filterMap["variant"] == "paths" {
+			switch parameter := filterMap["parameter"].(type) {
+				case string:
+					return []string{parameter}
+				case []interface{}:
+					paths := make([]string, 0, len(parameter))
+					for _, relativePath := range parameter {
+						paths = append(paths, fmt.Sprint(relativePath))
+					}
+					return paths
+			}
+		}
+	}
+	return nil
+}
+
+func getPeriod(filter interface{}) time.Duration {
+	for _, filterMap := range getFilterList(filter) {
+		if filterMap["variant"] == "timebased" {
+			if parameter, ok := filterMap["parameter"].(map[string]interface{}); ok {
+				period, err := strconv.Atoi(fmt.Sprint(parameter["period"]))
+				if err == nil && period > 0 {
+					return time.Duration(period) * time.Millisecond
+				}
+			}
+		}
+	}
+	return time.Second
+}
+
+// getHistoryPeriod returns the period of a history filter, which is an ISO 8601 duration, e.g. P2DT12H, and false if there is no history filter.
+func getHistoryPeriod(filter interface{}) (time.Duration, bool, error) {
+	for _, filterMap := range getFilterList(filter) {
+		if filterMap["variant"] == "history" {
+			period, err := parseIsoDuration(fmt.Sprint(filterMap["parameter"]))
+			return period, true, err
+		}
+	}
+	return 0, false, nil
+}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+func parseIsoDuration(period string) (time.Duration, error) {
+	match := isoDurationPattern.FindStringSubmatch(period)
+	if match == nil || period == "P" || strings.HasSuffix(period, "T") {
+		return 0, fmt.Errorf("%s is not an ISO 8601 duration", period)
+	}
+	var duration time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if match[i+1] != "" {
+			value, _ := strconv.ParseFloat(match[i+1], 64)
+			duration += time.Duration(value * float64(unit))
+		}
+	}
+	return duration, nil
+}
+
+func getFilterList(filter interface{}) []map[string]interface{} {
+	switch vv := filter.(type) {
+		case map[string]interface{}:
+			return []map[string]interface{}{vv}
+		case []interface{}:
+			var filterList []map[string]interface{}
+			for i := 0; i < len(vv); i++ {
+				if filterMap, ok := vv[i].(map[string]interface{}); ok {
+					filterList = append(filterList, filterMap)
+				}
+			}
+			return filterList
+	}
+	return nil
+}
+
+func formatValue(value float64, datatype string) string {
+	if strings.HasPrefix(datatype, "int") || strings.HasPrefix(datatype, "uint") {
+		return strconv.FormatInt(int64(math.Round(value)), 10)
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func getErrorMap(number string, reason string, description string) map[string]interface{} {
+	return map[string]interface{}{"number": number, "reason": reason, "description": description}
+}
+
+func getTimestamp() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000000Z")
+}
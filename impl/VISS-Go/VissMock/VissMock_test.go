@@ -0,0 +1,222 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package VissMock
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func startTestServer(t *testing.T, signals []Signal) (*Server, *websocket.Conn) {
+	t.Helper()
+	server := NewServer(signals)
+	address, err := server.StartWs("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("StartWs: %s", err)
+	}
+	dialer := websocket.Dialer{HandshakeTimeout: time.Second, Subprotocols: []string{"VISSv2"}}
+	conn, _, err := dialer.Dial("ws://" + address, nil)
+	if err != nil {
+		t.Fatalf("Dial: %s", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server.Close()
+	})
+	return server, conn
+}
+
+func request(t *testing.T, conn *websocket.Conn, message string) map[string]interface{} {
+	t.Helper()
+	err := conn.WriteMessage(websocket.TextMessage, []byte(message))
+	if err != nil {
+		t.Fatalf("WriteMessage: %s", err)
+	}
+	return readMessage(t, conn)
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	_, message, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage: %s", err)
+	}
+	var messageMap map[string]interface{}
+	err = json.Unmarshal(message, &messageMap)
+	if err != nil {
+		t.Fatalf("Unmarshal: %s", err)
+	}
+	return messageMap
+}
+
+func getErrorNumber(messageMap map[string]interface{}) string {
+	errorMap, ok := messageMap["error"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	return errorMap["number"].(string)
+}
+
+func TestGet(t *testing.T) {
+	_, conn := startTestServer(t, DefaultSignals())
+	tests := []struct {
+		name string
+		message string
+		errorNumber string
+		paths []string
+	}{
+		{"leaf", `{"action":"get", "path":"Vehicle.Speed", "requestId":"1"}`, "", []string{"Vehicle.Speed"}},
+		{"branch", `{"action":"get", "path":"Vehicle.CurrentLocation", "requestId":"2"}`, "", []string{"Vehicle.CurrentLocation.Latitude", "Vehicle.CurrentLocation.Longitude"}},
+		{"paths filter", `{"action":"get", "path":"Vehicle.CurrentLocation", "filter":{"variant":"paths","parameter":["Latitude"]}, "requestId":"3"}`, "", []string{"Vehicle.CurrentLocation.Latitude"}},
+		{"wildcard", `{"action":"get", "path":"Vehicle.Cabin.Seat.Row1.*.Position", "requestId":"4"}`, "", []string{"Vehicle.Cabin.Seat.Row1.DriverSide.Position", "Vehicle.Cabin.Seat.Row1.PassengerSide.Position"}},
+		{"unknown path", `{"action":"get", "path":"Vehicle.Unknown", "requestId":"5"}`, "404", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := request(t, conn, test.message)
+			if getErrorNumber(response) != test.errorNumber {
+				t.Fatalf("error number = %q, want %q", getErrorNumber(response), test.errorNumber)
+			}
+			if test.errorNumber != "" {
+				return
+			}
+			var dataList []interface{}
+			switch data := response["data"].(type) {
+				case []interface{}: dataList = data
+				case map[string]interface{}: dataList = []interface{}{data}
+			}
+			if len(dataList) != len(test.paths) {
+				t.Fatalf("got %d data containers, want %d", len(dataList), len(test.paths))
+			}
+			for i := 0; i < len(dataList); i++ {
+				if path := dataList[i].(map[string]interface{})["path"]; path != test.paths[i] {
+					t.Errorf("path[%d] = %v, want %s", i, path, test.paths[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSetActuatorDynamics(t *testing.T) {
+	signals := []Signal{{"Vehicle.Cabin.Seat.Row1.DriverSide.Position", "actuator", "uint16", "0", 1000, ""}}
+	server, conn := startTestServer(t, signals)
+	response := request(t, conn, `{"action":"set", "path":"Vehicle.Cabin.Seat.Row1.DriverSide.Position", "value":"300", "requestId":"1"}`)
+	if response["error"] != nil {
+		t.Fatalf("set failed: %v", response["error"])
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Position")
+		if value == "300" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("actuator value = %s, want 300", value)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	_, conn := startTestServer(t, DefaultSignals())
+	response := request(t, conn, `{"action":"set", "path":"Vehicle.Speed", "value":"10", "requestId":"1"}`)
+	if getErrorNumber(response) != "400" {
+		t.Errorf("set of sensor: error number = %q, want 400", getErrorNumber(response))
+	}
+	response = request(t, conn, `{"action":"set", "path":"Vehicle.Unknown", "value":"10", "requestId":"2"}`)
+	if getErrorNumber(response) != "404" {
+		t.Errorf("set of unknown path: error number = %q, want 404", getErrorNumber(response))
+	}
+}
+
+func TestSubscribeUnsubscribe(t *testing.T) {
+	server, conn := startTestServer(t, DefaultSignals())
+	response := request(t, conn, `{"action":"subscribe", "path":"Vehicle.Speed", "filter":{"variant":"timebased","parameter":{"period":"50"}}, "requestId":"1"}`)
+	subscriptionId, ok := response["subscriptionId"].(string)
+	if !ok || response["requestId"] != "1" {
+		t.Fatalf("unexpected subscribe response: %v", response)
+	}
+	server.SetValue("Vehicle.Speed", "42")
+	event := readMessage(t, conn)
+	if event["subscriptionId"] != subscriptionId || event["requestId"] != nil {
+		t.Fatalf("unexpected event: %v", event)
+	}
+	value := event["data"].(map[string]interface{})["dp"].(map[string]interface{})["value"]
+	if value != "42" {
+		t.Errorf("event value = %v, want 42", value)
+	}
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"action":"unsubscribe", "subscriptionId":"` + subscriptionId + `", "requestId":"2"}`))
+	for {
+		response = readMessage(t, conn) // events may be in flight
+		if response["action"] == "unsubscribe" {
+			break
+		}
+	}
+	if response["error"] != nil {
+		t.Fatalf("unsubscribe failed: %v", response["error"])
+	}
+}
+
+func TestGetMetadata(t *testing.T) {
+	_, conn := startTestServer(t, DefaultSignals())
+	response := request(t, conn, `{"action":"get", "path":"Vehicle.CurrentLocation", "filter":{"variant":"metadata","parameter":"0"}, "requestId":"1"}`)
+	metadata, ok := response["metadata"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected response: %v", response)
+	}
+	vehicle := metadata["Vehicle"].(map[string]interface{})
+	location := vehicle["children"].(map[string]interface{})["CurrentLocation"].(map[string]interface{})
+	latitude := location["children"].(map[string]interface{})["Latitude"].(map[string]interface{})
+	if latitude["type"] != "sensor" || latitude["datatype"] != "double" {
+		t.Errorf("unexpected Latitude metadata: %v", latitude)
+	}
+}
+
+func TestGetHistory(t *testing.T) {
+	server, conn := startTestServer(t, DefaultSignals())
+	for _, value := range []string{"10", "20", "30"} {
+		server.SetValue("Vehicle.Speed", value)
+		time.Sleep(time.Millisecond)
+	}
+	response := request(t, conn, `{"action":"get", "path":"Vehicle.Speed", "filter":{"variant":"history","parameter":"PT1M"}, "requestId":"1"}`)
+	dataPoints, ok := response["data"].(map[string]interface{})["dp"].([]interface{})
+	if !ok || len(dataPoints) != 4 {
+		t.Fatalf("got the data %v, want 4 data points", response["data"])
+	}
+	if value := dataPoints[3].(map[string]interface{})["value"]; value != "30" {
+		t.Errorf("the newest value = %v, want 30", value)
+	}
+	time.Sleep(20 * time.Millisecond)
+	response = request(t, conn, `{"action":"get", "path":"Vehicle.Speed", "filter":{"variant":"history","parameter":"PT0.01S"}, "requestId":"2"}`)
+	if dataPoints := response["data"].(map[string]interface{})["dp"].([]interface{}); len(dataPoints) != 0 {
+		t.Errorf("got %d data points outside the period", len(dataPoints))
+	}
+	response = request(t, conn, `{"action":"get", "path":"Vehicle.Speed", "filter":{"variant":"history","parameter":"2 days"}, "requestId":"3"}`)
+	if getErrorNumber(response) != "400" {
+		t.Errorf("error number = %q for an invalid period, want 400", getErrorNumber(response))
+	}
+}
+
+func TestParseIsoDuration(t *testing.T) {
+	tests := map[string]time.Duration{"P2DT12H": 60 * time.Hour, "PT1M30S": 90 * time.Second, "PT0.5S": 500 * time.Millisecond, "P1D": 24 * time.Hour}
+	for period, expected := range tests {
+		if duration, err := parseIsoDuration(period); err != nil || duration != expected {
+			t.Errorf("parseIsoDuration(%s) = %v, %v, want %v", period, duration, err, expected)
+		}
+	}
+	for _, period := range []string{"", "P", "PT", "1H", "PT1H2D"} {
+		if _, err := parseIsoDuration(period); err == nil {
+			t.Errorf("parseIsoDuration(%s) did not fail", period)
+		}
+	}
+}
@@ -0,0 +1,454 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+/*
+#include <stdlib.h>
+#include "vapi.h"
+
+// cgo can not call a C function pointer, the callbacks are called by these functions.
+static void callInvokeCallback(VapiInvokeCallback callback, VapiInvokeOutput* out, void* context) { callback(out, context); }
+static void callSubscribeCallback(VapiSubscribeCallback callback, VapiSubscribeOutput* out, void* context) { callback(out, context); }
+static void callMoveSeatCallback(VapiMoveSeatCallback callback, VapiMoveSeatOutput* out, void* context) { callback(out, context); }
+static void callConfigureSeatCallback(VapiConfigureSeatCallback callback, VapiConfigureSeatOutput* out, void* context) { callback(out, context); }
+static void callMassageCallback(VapiMassageCallback callback, VapiMassageOutput* out, void* context) { callback(out, context); }
+static void callMassageProgramCallback(VapiMassageProgramCallback callback, VapiMassageProgramOutput* out, void* context) { callback(out, context); }
+static void callSeatClimateCallback(VapiSeatClimateCallback callback, VapiSeatClimateOutput* out, void* context) { callback(out, context); }
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"VISS-Go/vapi"
+)
+
+// ****************** C memory ***************
+// cArray allocates a zeroed C array, and returns it together with a Go slice that refers to it.
+func cArray[T any](length int) (*T, []T) {
+	if length == 0 {
+		return nil, nil
+	}
+	var element T
+	ptr := (*T)(C.calloc(C.size_t(length), C.size_t(unsafe.Sizeof(element))))
+	return ptr, unsafe.Slice(ptr, length)
+}
+
+func goArray[T any](ptr *T, length C.int) []T {
+	if ptr == nil || length <= 0 {
+		return nil
+	}
+	return unsafe.Slice(ptr, int(length))
+}
+
+func cFree[T any](ptr *T) {
+	C.free(unsafe.Pointer(ptr))
+}
+
+// goString returns the Go string of a C string, a NULL string is empty.
+func goString(str *C.char) string {
+	if str == nil {
+		return ""
+	}
+	return C.GoString(str)
+}
+
+func cStrings(strs []string) (**C.char, C.int) {
+	ptr, array := cArray[*C.char](len(strs))
+	for i := 0; i < len(strs); i++ {
+		array[i] = C.CString(strs[i])
+	}
+	return ptr, C.int(len(strs))
+}
+
+func freeStrings(ptr **C.char, length C.int) {
+	for _, str := range goArray(ptr, length) {
+		C.free(unsafe.Pointer(str))
+	}
+	cFree(ptr)
+}
+
+func cError(err *vapi.ErrorData) *C.VapiErrorData {
+	if err == nil {
+		return nil
+	}
+	ptr, array := cArray[C.VapiErrorData](1)
+	array[0].code = C.int32_t(err.Code)
+	array[0].reason = C.CString(err.Reason)
+	array[0].description = C.CString(err.Description)
+	return ptr
+}
+
+func freeError(err *C.VapiErrorData) {
+	if err == nil {
+		return
+	}
+	C.free(unsafe.Pointer(err.reason))
+	C.free(unsafe.Pointer(err.description))
+	cFree(err)
+}
+
+// ****************** Inputs ***************
+func goMatrixId(seatId C.VapiMatrixId) vapi.MatrixId {
+	return vapi.MatrixId{RowName: goString(seatId.rowName), ColumnName: goString(seatId.columnName)}
+}
+
+func goSeatConfigs(ptr *C.VapiSeatConfig, length C.int) []vapi.SeatConfig {
+	var configuration []vapi.SeatConfig
+	for _, config := range goArray(ptr, length) {
+		configuration = append(configuration, vapi.SeatConfig{MovementType: goString(config.movementType), Position: vapi.Percentage(config.position)})
+	}
+	return configuration
+}
+
+func goMassageSteps(ptr *C.VapiMassageStep, length C.int) []vapi.MassageStep {
+	var steps []vapi.MassageStep
+	for _, step := range goArray(ptr, length) {
+		steps = append(steps, vapi.MassageStep{MassageType: goString(step.massageType), Intensity: vapi.Percentage(step.intensity), Duration: uint32(step.duration)})
+	}
+	return steps
+}
+
+// ****************** Outputs ***************
+func cGeneralOutput(out vapi.GeneralOutput) C.VapiGeneralOutput {
+	return C.VapiGeneralOutput{status: C.VapiProcedureStatus(out.Status), error: cError(out.Error)}
+}
+
+func freeGeneralOutput(out *C.VapiGeneralOutput) {
+	freeError(out.error)
+	out.error = nil
+}
+
+func cGetVehicleOutput(out vapi.GetVehicleOutput) C.VapiGetVehicleOutput {
+	var cOut C.VapiGetVehicleOutput
+	cOut.status = C.VapiProcedureStatus(out.Status)
+	cOut.error = cError(out.Error)
+	cOut.vehicleId = C.VapiVehicleHandle(out.VehicleId)
+	cOut.protocol, cOut.protocolCount = cStrings(out.Protocol)
+	return cOut
+}
+
+func freeGetVehicleOutput(out *C.VapiGetVehicleOutput) {
+	freeError(out.error)
+	freeStrings(out.protocol, out.protocolCount)
+	*out = C.VapiGetVehicleOutput{}
+}
+
+func cConnectOutput(out vapi.ConnectOutput) C.VapiConnectOutput {
+	return C.VapiConnectOutput{status: C.VapiProcedureStatus(out.Status), error: cError(out.Error), ltCredential: C.CString(out.LtCredential)}
+}
+
+func freeConnectOutput(out *C.VapiConnectOutput) {
+	freeError(out.error)
+	C.free(unsafe.Pointer(out.ltCredential))
+	*out = C.VapiConnectOutput{}
+}
+
+func cServiceInquiryOutput(out vapi.ServiceInquiryOutput) C.VapiServiceInquiryOutput {
+	var cOut C.VapiServiceInquiryOutput
+	cOut.status = C.VapiProcedureStatus(out.Status)
+	cOut.error = cError(out.Error)
+	var service []C.VapiServiceSignature
+	cOut.service, service = cArray[C.VapiServiceSignature](len(out.Service))
+	cOut.serviceCount = C.int(len(out.Service))
+	for i := 0; i < len(out.Service); i++ {
+		service[i].name = C.CString(out.Service[i].Name)
+		service[i].input = C.CString(out.Service[i].Input)
+		service[i].output = C.CString(out.Service[i].Output)
+	}
+	return cOut
+}
+
+func freeServiceInquiryOutput(out *C.VapiServiceInquiryOutput) {
+	freeError(out.error)
+	for _, service := range goArray(out.service, out.serviceCount) {
+		C.free(unsafe.Pointer(service.name))
+		C.free(unsafe.Pointer(service.input))
+		C.free(unsafe.Pointer(service.output))
+	}
+	cFree(out.service)
+	*out = C.VapiServiceInquiryOutput{}
+}
+
+func cGetStCredentialsOutput(out vapi.GetStCredentialsOutput) C.VapiGetStCredentialsOutput {
+	return C.VapiGetStCredentialsOutput{status: C.VapiProcedureStatus(out.Status), error: cError(out.Error), stCredentials: C.CString(out.StCredentials)}
+}
+
+func freeGetStCredentialsOutput(out *C.VapiGetStCredentialsOutput) {
+	freeError(out.error)
+	C.free(unsafe.Pointer(out.stCredentials))
+	*out = C.VapiGetStCredentialsOutput{}
+}
+
+func cInvokeOutput(out vapi.InvokeOutput) C.VapiInvokeOutput {
+	return C.VapiInvokeOutput{status: C.VapiProcedureStatus(out.Status), error: cError(out.Error), serviceOutput: C.CString(out.ServiceOutput), serviceId: C.uint32_t(out.ServiceId)}
+}
+
+func freeInvokeOutput(out *C.VapiInvokeOutput) {
+	freeError(out.error)
+	C.free(unsafe.Pointer(out.serviceOutput))
+	*out = C.VapiInvokeOutput{}
+}
+
+func cGetMetadataOutput(out vapi.GetMetadataOutput) C.VapiGetMetadataOutput {
+	return C.VapiGetMetadataOutput{status: C.VapiProcedureStatus(out.Status), error: cError(out.Error), metadata: C.CString(out.Metadata)}
+}
+
+func freeGetMetadataOutput(out *C.VapiGetMetadataOutput) {
+	freeError(out.error)
+	C.free(unsafe.Pointer(out.metadata))
+	*out = C.VapiGetMetadataOutput{}
+}
+
+func cDataContainers(data []vapi.DataContainer) (*C.VapiDataContainer, C.int) {
+	ptr, containers := cArray[C.VapiDataContainer](len(data))
+	for i := 0; i < len(data); i++ {
+		containers[i].path = C.CString(data[i].Path)
+		var dp []C.VapiDataPoint
+		containers[i].dp, dp = cArray[C.VapiDataPoint](len(data[i].Dp))
+		containers[i].dpCount = C.int(len(data[i].Dp))
+		for j := 0; j < len(data[i].Dp); j++ {
+			dp[j].value = C.CString(data[i].Dp[j].Value)
+			dp[j].timestamp = C.CString(data[i].Dp[j].Timestamp)
+		}
+	}
+	return ptr, C.int(len(data))
+}
+
+func freeDataContainers(ptr *C.VapiDataContainer, length C.int) {
+	for _, container := range goArray(ptr, length) {
+		C.free(unsafe.Pointer(container.path))
+		for _, dp := range goArray(container.dp, container.dpCount) {
+			C.free(unsafe.Pointer(dp.value))
+			C.free(unsafe.Pointer(dp.timestamp))
+		}
+		cFree(container.dp)
+	}
+	cFree(ptr)
+}
+
+func cGetOutput(out vapi.GetOutput) C.VapiGetOutput {
+	var cOut C.VapiGetOutput
+	cOut.status = C.VapiProcedureStatus(out.Status)
+	cOut.error = cError(out.Error)
+	cOut.data, cOut.dataCount = cDataContainers(out.Data)
+	return cOut
+}
+
+func freeGetOutput(out *C.VapiGetOutput) {
+	freeError(out.error)
+	freeDataContainers(out.data, out.dataCount)
+	*out = C.VapiGetOutput{}
+}
+
+func cSubscribeOutput(out vapi.SubscribeOutput) C.VapiSubscribeOutput {
+	var cOut C.VapiSubscribeOutput
+	cOut.status = C.VapiProcedureStatus(out.Status)
+	cOut.error = cError(out.Error)
+	cOut.data, cOut.dataCount = cDataContainers(out.Data)
+	cOut.serviceId = C.uint32_t(out.ServiceId)
+	return cOut
+}
+
+func freeSubscribeOutput(out *C.VapiSubscribeOutput) {
+	freeError(out.error)
+	freeDataContainers(out.data, out.dataCount)
+	*out = C.VapiSubscribeOutput{}
+}
+
+func cMoveSeatOutput(out vapi.MoveSeatOutput) C.VapiMoveSeatOutput {
+	return C.VapiMoveSeatOutput{status: C.VapiProcedureStatus(out.Status), error: cError(out.Error), position: C.VapiPercentage(out.Position), serviceId: C.uint32_t(out.ServiceId)}
+}
+
+func freeMoveSeatOutput(out *C.VapiMoveSeatOutput) {
+	freeError(out.error)
+	*out = C.VapiMoveSeatOutput{}
+}
+
+func cConfigureSeatOutput(out vapi.ConfigureSeatOutput) C.VapiConfigureSeatOutput {
+	var cOut C.VapiConfigureSeatOutput
+	cOut.status = C.VapiProcedureStatus(out.Status)
+	cOut.error = cError(out.Error)
+	var configured []C.VapiSeatConfig
+	cOut.configured, configured = cArray[C.VapiSeatConfig](len(out.Configured))
+	cOut.configuredCount = C.int(len(out.Configured))
+	for i := 0; i < len(out.Configured); i++ {
+		configured[i].movementType = C.CString(out.Configured[i].MovementType)
+		configured[i].position = C.VapiPercentage(out.Configured[i].Position)
+	}
+	cOut.unconfigured, cOut.unconfiguredCount = cStrings(out.Unconfigured)
+	cOut.serviceId = C.uint32_t(out.ServiceId)
+	return cOut
+}
+
+func freeConfigureSeatOutput(out *C.VapiConfigureSeatOutput) {
+	freeError(out.error)
+	for _, config := range goArray(out.configured, out.configuredCount) {
+		C.free(unsafe.Pointer(config.movementType))
+	}
+	cFree(out.configured)
+	freeStrings(out.unconfigured, out.unconfiguredCount)
+	*out = C.VapiConfigureSeatOutput{}
+}
+
+func cMassageOutput(out vapi.MassageOutput) C.VapiMassageOutput {
+	return C.VapiMassageOutput{status: C.VapiProcedureStatus(out.Status), error: cError(out.Error), serviceId: C.uint32_t(out.ServiceId)}
+}
+
+func freeMassageOutput(out *C.VapiMassageOutput) {
+	freeError(out.error)
+	*out = C.VapiMassageOutput{}
+}
+
+func cMassageProgramOutput(out vapi.MassageProgramOutput) C.VapiMassageProgramOutput {
+	return C.VapiMassageProgramOutput{status: C.VapiProcedureStatus(out.Status), error: cError(out.Error), stepIndex: C.int(out.StepIndex), serviceId: C.uint32_t(out.ServiceId)}
+}
+
+func freeMassageProgramOutput(out *C.VapiMassageProgramOutput) {
+	freeError(out.error)
+	*out = C.VapiMassageProgramOutput{}
+}
+
+func cSeatClimateOutput(out vapi.SeatClimateOutput) C.VapiSeatClimateOutput {
+	return C.VapiSeatClimateOutput{status: C.VapiProcedureStatus(out.Status), error: cError(out.Error), serviceId: C.uint32_t(out.ServiceId)}
+}
+
+func freeSeatClimateOutput(out *C.VapiSeatClimateOutput) {
+	freeError(out.error)
+	*out = C.VapiSeatClimateOutput{}
+}
+
+func cSupportData(support []vapi.SupportData) (*C.VapiSupportData, C.int) {
+	ptr, array := cArray[C.VapiSupportData](len(support))
+	for i := 0; i < len(support); i++ {
+		array[i].name = C.CString(support[i].Name)
+		array[i].description = C.CString(support[i].Description)
+	}
+	return ptr, C.int(len(support))
+}
+
+func freeSupportData(ptr *C.VapiSupportData, length C.int) {
+	for _, support := range goArray(ptr, length) {
+		C.free(unsafe.Pointer(support.name))
+		C.free(unsafe.Pointer(support.description))
+	}
+	cFree(ptr)
+}
+
+func cGetPropertiesSeatingOutput(out vapi.GetPropertiesSeatingOutput) C.VapiGetPropertiesSeatingOutput {
+	var cOut C.VapiGetPropertiesSeatingOutput
+	cOut.status = C.VapiProcedureStatus(out.Status)
+	cOut.error = cError(out.Error)
+	var rows []C.VapiRowDef
+	cOut.properties, rows = cArray[C.VapiRowDef](len(out.Properties))
+	cOut.propertiesCount = C.int(len(out.Properties))
+	for i := 0; i < len(out.Properties); i++ {
+		rows[i].rowName = C.CString(out.Properties[i].RowName)
+		var columns []C.VapiColumnData
+		rows[i].column, columns = cArray[C.VapiColumnData](len(out.Properties[i].Column))
+		rows[i].columnCount = C.int(len(out.Properties[i].Column))
+		for j := 0; j < len(out.Properties[i].Column); j++ {
+			column := out.Properties[i].Column[j]
+			columns[j].name = C.CString(column.Name)
+			columns[j].movementSupport, columns[j].movementSupportCount = cSupportData(column.MovementSupport)
+			columns[j].massageSupport, columns[j].massageSupportCount = cSupportData(column.MassageSupport)
+			columns[j].climateSupport, columns[j].climateSupportCount = cSupportData(column.ClimateSupport)
+		}
+	}
+	return cOut
+}
+
+func freeGetPropertiesSeatingOutput(out *C.VapiGetPropertiesSeatingOutput) {
+	freeError(out.error)
+	for _, row := range goArray(out.properties, out.propertiesCount) {
+		C.free(unsafe.Pointer(row.rowName))
+		for _, column := range goArray(row.column, row.columnCount) {
+			C.free(unsafe.Pointer(column.name))
+			freeSupportData(column.movementSupport, column.movementSupportCount)
+			freeSupportData(column.massageSupport, column.massageSupportCount)
+			freeSupportData(column.climateSupport, column.climateSupportCount)
+		}
+		cFree(row.column)
+	}
+	cFree(out.properties)
+	*out = C.VapiGetPropertiesSeatingOutput{}
+}
+
+// ****************** Callbacks ***************
+/* The callback functions return a Go callback that converts the output, calls the C callback with it, and releases it.
+*  A NULL C callback is allowed, the callbacks are then ignored. */
+func invokeCallback(callback C.VapiInvokeCallback, context unsafe.Pointer) func(vapi.InvokeOutput) {
+	return func(out vapi.InvokeOutput) {
+		if callback != nil {
+			cOut := cInvokeOutput(out)
+			C.callInvokeCallback(callback, &cOut, context)
+			freeInvokeOutput(&cOut)
+		}
+	}
+}
+
+func subscribeCallback(callback C.VapiSubscribeCallback, context unsafe.Pointer) func(vapi.SubscribeOutput) {
+	return func(out vapi.SubscribeOutput) {
+		if callback != nil {
+			cOut := cSubscribeOutput(out)
+			C.callSubscribeCallback(callback, &cOut, context)
+			freeSubscribeOutput(&cOut)
+		}
+	}
+}
+
+func moveSeatCallback(callback C.VapiMoveSeatCallback, context unsafe.Pointer) func(vapi.MoveSeatOutput) {
+	return func(out vapi.MoveSeatOutput) {
+		if callback != nil {
+			cOut := cMoveSeatOutput(out)
+			C.callMoveSeatCallback(callback, &cOut, context)
+			freeMoveSeatOutput(&cOut)
+		}
+	}
+}
+
+func configureSeatCallback(callback C.VapiConfigureSeatCallback, context unsafe.Pointer) func(vapi.ConfigureSeatOutput) {
+	return func(out vapi.ConfigureSeatOutput) {
+		if callback != nil {
+			cOut := cConfigureSeatOutput(out)
+			C.callConfigureSeatCallback(callback, &cOut, context)
+			freeConfigureSeatOutput(&cOut)
+		}
+	}
+}
+
+func massageCallback(callback C.VapiMassageCallback, context unsafe.Pointer) func(vapi.MassageOutput) {
+	return func(out vapi.MassageOutput) {
+		if callback != nil {
+			cOut := cMassageOutput(out)
+			C.callMassageCallback(callback, &cOut, context)
+			freeMassageOutput(&cOut)
+		}
+	}
+}
+
+func massageProgramCallback(callback C.VapiMassageProgramCallback, context unsafe.Pointer) func(vapi.MassageProgramOutput) {
+	return func(out vapi.MassageProgramOutput) {
+		if callback != nil {
+			cOut := cMassageProgramOutput(out)
+			C.callMassageProgramCallback(callback, &cOut, context)
+			freeMassageProgramOutput(&cOut)
+		}
+	}
+}
+
+func seatClimateCallback(callback C.VapiSeatClimateCallback, context unsafe.Pointer) func(vapi.SeatClimateOutput) {
+	return func(out vapi.SeatClimateOutput) {
+		if callback != nil {
+			cOut := cSeatClimateOutput(out)
+			C.callSeatClimateCallback(callback, &cOut, context)
+			freeSeatClimateOutput(&cOut)
+		}
+	}
+}
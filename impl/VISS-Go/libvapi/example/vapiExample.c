@@ -0,0 +1,284 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+/* The former vapiTest demo written in C on libvapi, it is built in the impl/VISS-Go directory by
+*  $ go build -buildmode=c-shared -o libvapi.so ./libvapi
+*  $ gcc -I. -Ilibvapi -o vapiExample libvapi/example/vapiExample.c -L. -lvapi -lpthread
+*  and run by
+*  $ LD_LIBRARY_PATH=. ./vapiExample [vehicleGuid [ipAddress portNo]]
+*  where a vehicle that is not known by VapiViss is registered with the VISSv3.0-ws protocol at ipAddress:portNo. */
+
+#include <stdio.h>
+#include <string.h>
+#include <time.h>
+#include <unistd.h>
+#include <pthread.h>
+#include "libvapi.h"
+
+// ****************** Service tracking ***************
+/* A service is followed by a callback that counts its callbacks, and records if it has terminated,
+*  the main thread waits on the condition variable. */
+typedef struct {
+	pthread_mutex_t mutex;
+	pthread_cond_t cond;
+	int callbacks;
+	int done;
+	VapiProcedureStatus status;
+} ServiceTracker;
+
+static void initTracker(ServiceTracker* tracker) {
+	pthread_mutex_init(&tracker->mutex, NULL);
+	pthread_cond_init(&tracker->cond, NULL);
+	tracker->callbacks = 0;
+	tracker->done = 0;
+	tracker->status = VAPI_ONGOING;
+}
+
+static void trackCallback(ServiceTracker* tracker, VapiProcedureStatus status, int isFinal) {
+	pthread_mutex_lock(&tracker->mutex);
+	tracker->callbacks++;
+	tracker->status = status;
+	if (isFinal) {
+		tracker->done = 1;
+	}
+	pthread_cond_broadcast(&tracker->cond);
+	pthread_mutex_unlock(&tracker->mutex);
+}
+
+// waitForService waits until the service has made callbacks number of callbacks, or has terminated, or timeout seconds have passed.
+static int waitForService(ServiceTracker* tracker, int callbacks, int timeout) {
+	struct timespec deadline;
+	clock_gettime(CLOCK_REALTIME, &deadline);
+	deadline.tv_sec += timeout;
+	pthread_mutex_lock(&tracker->mutex);
+	while (tracker->callbacks < callbacks && !tracker->done) {
+		if (pthread_cond_timedwait(&tracker->cond, &tracker->mutex, &deadline) != 0) {
+			break;
+		}
+	}
+	int reached = tracker->callbacks >= callbacks || tracker->done;
+	pthread_mutex_unlock(&tracker->mutex);
+	return reached;
+}
+
+// ****************** Output printing ***************
+static const char* translateStatus(VapiProcedureStatus status) {
+	switch (status) {
+		case VAPI_SUCCESSFUL: return "SUCCESSFUL";
+		case VAPI_ONGOING: return "ONGOING";
+		case VAPI_FAILED: return "FAILED";
+	}
+	return "UNKNOWN";
+}
+
+static void showServiceStatus(VapiProcedureStatus status, const VapiErrorData* error) {
+	printf("Call status=%s\n", translateStatus(status));
+	if (error != NULL) {
+		printf("Error code=%d\n", error->code);
+		printf("Error reason=%s\n", error->reason);
+		printf("Error status=%s\n", error->description);
+	}
+}
+
+static void showData(const VapiDataContainer* data, int dataCount) {
+	for (int i = 0; i < dataCount; i++) {
+		printf("Path=%s\n", data[i].path);
+		for (int j = 0; j < data[i].dpCount; j++) {
+			printf("  Value=%s Ts=%s\n", data[i].dp[j].value, data[i].dp[j].timestamp);
+		}
+	}
+}
+
+static void subscribeOutUnpack(const VapiSubscribeOutput* out, void* context) {
+	showServiceStatus(out->status, out->error);
+	if (out->status == VAPI_SUCCESSFUL) {
+		printf("ServiceId=%u\n", out->serviceId);
+		showData(out->data, out->dataCount);
+	}
+	trackCallback((ServiceTracker*)context, out->status, out->status == VAPI_FAILED);
+}
+
+static void moveSeatOutUnpack(const VapiMoveSeatOutput* out, void* context) {
+	showServiceStatus(out->status, out->error);
+	if (out->status != VAPI_FAILED) {
+		printf("Position=%f\n", out->position);
+	}
+	trackCallback((ServiceTracker*)context, out->status, out->status != VAPI_ONGOING);
+}
+
+static void seatConfigOutUnpack(const VapiConfigureSeatOutput* out, void* context) {
+	printf("ConfigureSeat:");
+	showServiceStatus(out->status, out->error);
+	for (int i = 0; i < out->configuredCount; i++) {
+		printf("Configured:%s, Position:%f\n", out->configured[i].movementType, out->configured[i].position);
+	}
+	trackCallback((ServiceTracker*)context, out->status, out->status != VAPI_ONGOING);
+}
+
+static void massageOutUnpack(const VapiMassageOutput* out, void* context) {
+	printf("\nMassage execution status:\n");
+	showServiceStatus(out->status, out->error);
+	trackCallback((ServiceTracker*)context, out->status, out->status != VAPI_ONGOING);
+}
+
+static void showSupport(const char* name, const VapiSupportData* support, int supportCount) {
+	printf("%s support: ", name);
+	for (int i = 0; i < supportCount; i++) {
+		printf("%s, ", support[i].name);
+	}
+	printf("\n");
+}
+
+int main(int argc, char** argv) {
+	char* vehicleGuid1 = "pseudoVin1";
+	if (argc > 1) {
+		vehicleGuid1 = argv[1];
+	}
+	if (argc > 3) {
+		VapiRegisterVehicle(vehicleGuid1, argv[2], argv[3], "VISSv3.0-ws");
+	}
+
+	char protocol[64] = "";
+	VapiGetVehicleOutput initOut = VapiGetVehicle(vehicleGuid1);
+	printf("Initiated connection to vehicle id =%s\nSupported protocols= [", vehicleGuid1);
+	for (int i = 0; i < initOut.protocolCount; i++) {
+		printf("%s ", initOut.protocol[i]);
+	}
+	printf("]\n");
+	for (int i = 0; i < initOut.protocolCount; i++) {
+		if (strstr(initOut.protocol[i], "ws") != NULL) {
+			snprintf(protocol, sizeof(protocol), "%s", initOut.protocol[i]);
+		}
+	}
+	printf("protocol =%s\n", protocol);
+	VapiVehicleHandle vehicle1 = initOut.vehicleId;
+	VapiFreeGetVehicleOutput(&initOut);
+	VapiConnectOutput out = VapiConnect(vehicle1, protocol, "");
+	if (out.status != VAPI_SUCCESSFUL) {
+		printf("Could not connect to vehicle id =%s. Error = %s.\n", vehicleGuid1, out.error != NULL ? out.error->reason : "");
+		VapiFreeConnectOutput(&out);
+		return 1;
+	}
+	printf("Connected to vehicle id =%s\n", vehicleGuid1);
+	VapiFreeConnectOutput(&out);
+
+	char* path = "Vehicle.CurrentLocation";
+	char* filter = "{\"variant\":\"paths\",\"parameter\":[\"Latitude\", \"Longitude\"]}";
+	VapiGeneralOutput generalOut = VapiSelectProtocol(vehicle1, protocol);
+	VapiFreeGeneralOutput(&generalOut);
+	printf("Get(vehicle1, %s, %s, \"\")\n", path, filter);
+	VapiGetOutput getOut = VapiGet(vehicle1, path, filter, "");
+	showServiceStatus(getOut.status, getOut.error);
+	if (getOut.status == VAPI_SUCCESSFUL) {
+		showData(getOut.data, getOut.dataCount);
+	}
+	VapiFreeGetOutput(&getOut);
+
+	filter = "[{\"variant\":\"paths\",\"parameter\":[\"Latitude\", \"Longitude\"]}, {\"variant\":\"timebased\",\"parameter\":{\"period\":\"1000\"}}]";
+	printf("Subscribe(vehicle1, %s, %s, \"\", subscribeOutUnpack)\n", path, filter);
+	ServiceTracker subscription;
+	initTracker(&subscription);
+	VapiSubscribeOutput subscribeOut = VapiSubscribe(vehicle1, path, filter, "", subscribeOutUnpack, &subscription);
+	showServiceStatus(subscribeOut.status, subscribeOut.error);
+	uint32_t subscriptionId = subscribeOut.serviceId;
+	VapiFreeSubscribeOutput(&subscribeOut);
+
+	printf("Wait for a few events...\n");
+	waitForService(&subscription, 3, 10);
+
+	printf("Unsubscribe(vehicle1, %u)\n", subscriptionId);
+	generalOut = VapiUnsubscribe(vehicle1, subscriptionId);
+	showServiceStatus(generalOut.status, generalOut.error);
+	VapiFreeGeneralOutput(&generalOut);
+
+	printf("GetPropertiesSeating(vehicle1)\n");
+	VapiGetPropertiesSeatingOutput propertiesOut = VapiGetPropertiesSeating(vehicle1);
+	showServiceStatus(propertiesOut.status, propertiesOut.error);
+	if (propertiesOut.status != VAPI_SUCCESSFUL || propertiesOut.propertiesCount == 0 || propertiesOut.properties[0].columnCount == 0) {
+		VapiFreeGetPropertiesSeatingOutput(&propertiesOut);
+		return 1;
+	}
+	printf("Seating properties:\n");
+	for (int i = 0; i < propertiesOut.propertiesCount; i++) {
+		for (int j = 0; j < propertiesOut.properties[i].columnCount; j++) {
+			VapiColumnData* column = &propertiesOut.properties[i].column[j];
+			printf("Seat Id= %s, %s\n", propertiesOut.properties[i].rowName, column->name);
+			showSupport("Movement", column->movementSupport, column->movementSupportCount);
+			showSupport("Massage", column->massageSupport, column->massageSupportCount);
+		}
+	}
+
+	// to simulate an execution duration for MoveSeat, set it to an initial value different from what MoveSeat invokes
+	char* longitudinalPath = "Vehicle.Cabin.Seat.Row1.DriverSide.Position";
+	printf("Set(vehicle1, %s, 2, \"\")\n", longitudinalPath);
+	generalOut = VapiSet(vehicle1, longitudinalPath, "2", "");
+	showServiceStatus(generalOut.status, generalOut.error);
+	VapiFreeGeneralOutput(&generalOut);
+
+	printf("Wait for the execution duration from Set to finish..\n");
+	for (int i = 0; i < 30; i++) {
+		getOut = VapiGet(vehicle1, longitudinalPath, "", "");
+		int isSet = getOut.status == VAPI_SUCCESSFUL && getOut.dataCount > 0 && getOut.data[0].dpCount > 0 && strcmp(getOut.data[0].dp[0].value, "2") == 0;
+		VapiFreeGetOutput(&getOut);
+		if (isSet) {
+			break;
+		}
+		sleep(1);
+	}
+
+	VapiMatrixId seatId;
+	seatId.rowName = propertiesOut.properties[0].rowName;
+	seatId.columnName = propertiesOut.properties[0].column[0].name;
+	printf("MoveSeat(vehicle1, seatId, LONGITUDINAL, %d, moveSeatOutUnpack)\n", VAPI_BACKWARD);
+	ServiceTracker move;
+	initTracker(&move);
+	VapiMoveSeatOutput moveSeatOut = VapiMoveSeat(vehicle1, seatId, VAPI_LONGITUDINAL, VAPI_BACKWARD, "", moveSeatOutUnpack, &move);
+	showServiceStatus(moveSeatOut.status, moveSeatOut.error);
+	uint32_t moveId = moveSeatOut.serviceId;
+	VapiFreeMoveSeatOutput(&moveSeatOut);
+
+	printf("Let the seat movement get about half way..\n");
+	waitForService(&move, 3, 5);
+
+	printf("CancelService(vehicle1, %u)\n", moveId);
+	generalOut = VapiCancelService(vehicle1, moveId);
+	showServiceStatus(generalOut.status, generalOut.error);
+	VapiFreeGeneralOutput(&generalOut);
+
+	VapiSeatConfig seatConfig[2];
+	seatConfig[0].movementType = VAPI_LONGITUDINAL;
+	seatConfig[0].position = 10;
+	seatConfig[1].movementType = VAPI_LUMBAR;
+	seatConfig[1].position = 50;
+	printf("ConfigureSeat(vehicle1, seatId, seatConfig, '', seatConfigOutUnpack)\n");
+	ServiceTracker configuration;
+	initTracker(&configuration);
+	VapiConfigureSeatOutput configSeatOut = VapiConfigureSeat(vehicle1, seatId, seatConfig, 2, "", seatConfigOutUnpack, &configuration);
+	showServiceStatus(configSeatOut.status, configSeatOut.error);
+	VapiFreeConfigureSeatOutput(&configSeatOut);
+
+	printf("Wait for the seat configuration execution to finish\n");
+	waitForService(&configuration, 1000, 20);
+
+	printf("ActivateMassage(vehicle1, seatId, ROLL, 50, 5, '', massageOutUnpack)\n");
+	ServiceTracker massage;
+	initTracker(&massage);
+	VapiMassageOutput massageOut = VapiActivateMassage(vehicle1, seatId, VAPI_ROLL, 50, 5, "", massageOutUnpack, &massage);
+	showServiceStatus(massageOut.status, massageOut.error);
+	VapiFreeMassageOutput(&massageOut);
+
+	printf("Wait for the massage execution duration=5s to finish\n");
+	waitForService(&massage, 1000, 10);
+	VapiFreeGetPropertiesSeatingOutput(&propertiesOut);
+
+	printf("Disconnect(vehicle1, %s)\n", protocol);
+	generalOut = VapiDisconnect(vehicle1, protocol);
+	showServiceStatus(generalOut.status, generalOut.error);
+	VapiFreeGeneralOutput(&generalOut);
+	return 0;
+}
@@ -0,0 +1,225 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+/* libvapi exports the VAPI procedures of the VapiViss backend as a C library, it is built by
+*  $ go build -buildmode=c-shared -o libvapi.so ./libvapi
+*  which also generates the header libvapi.h, the C types of the procedures are declared in vapi.h. */
+
+/*
+#include "vapi.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"VISS-Go/VapiViss"
+	"VISS-Go/vapi"
+)
+
+var api vapi.Vapi = VapiViss.NewBackend()
+
+func main() {}
+
+// ****************** Vehicle registration ***************
+//export VapiRegisterVehicle
+func VapiRegisterVehicle(vehicleGuid *C.char, ipAddress *C.char, portNo *C.char, protocol *C.char) {
+	VapiViss.RegisterVehicle(goString(vehicleGuid), goString(ipAddress), []VapiViss.ConnectivityData{{PortNo: goString(portNo), Protocol: goString(protocol)}})
+}
+
+// ****************** Common procedures ***************
+//export VapiGetVehicle
+func VapiGetVehicle(vehicleGuid *C.char) C.VapiGetVehicleOutput {
+	return cGetVehicleOutput(api.GetVehicle(goString(vehicleGuid)))
+}
+
+//export VapiReleaseVehicle
+func VapiReleaseVehicle(vehicleId C.VapiVehicleHandle) C.VapiGeneralOutput {
+	return cGeneralOutput(api.ReleaseVehicle(vapi.VehicleHandle(vehicleId)))
+}
+
+//export VapiConnect
+func VapiConnect(vehicleId C.VapiVehicleHandle, protocol *C.char, clientCredentials *C.char) C.VapiConnectOutput {
+	return cConnectOutput(api.Connect(vapi.VehicleHandle(vehicleId), goString(protocol), goString(clientCredentials)))
+}
+
+//export VapiDisconnect
+func VapiDisconnect(vehicleId C.VapiVehicleHandle, protocol *C.char) C.VapiGeneralOutput {
+	return cGeneralOutput(api.Disconnect(vapi.VehicleHandle(vehicleId), goString(protocol)))
+}
+
+//export VapiSelectProtocol
+func VapiSelectProtocol(vehicleId C.VapiVehicleHandle, protocol *C.char) C.VapiGeneralOutput {
+	return cGeneralOutput(api.SelectProtocol(vapi.VehicleHandle(vehicleId), goString(protocol)))
+}
+
+//export VapiCancelService
+func VapiCancelService(vehicleId C.VapiVehicleHandle, serviceId C.uint32_t) C.VapiGeneralOutput {
+	return cGeneralOutput(api.CancelService(vapi.VehicleHandle(vehicleId), uint32(serviceId)))
+}
+
+//export VapiServiceInquiry
+func VapiServiceInquiry(vehicleId C.VapiVehicleHandle) C.VapiServiceInquiryOutput {
+	return cServiceInquiryOutput(api.ServiceInquiry(vapi.VehicleHandle(vehicleId)))
+}
+
+//export VapiGetStCredentials
+func VapiGetStCredentials(vehicleId C.VapiVehicleHandle, ltCredentials *C.char, purpose *C.char) C.VapiGetStCredentialsOutput {
+	return cGetStCredentialsOutput(api.GetStCredentials(vapi.VehicleHandle(vehicleId), goString(ltCredentials), goString(purpose)))
+}
+
+//export VapiInvoke
+func VapiInvoke(vehicleId C.VapiVehicleHandle, serviceName *C.char, procedureInput *C.char, stCredentials *C.char, callback C.VapiInvokeCallback, context unsafe.Pointer) C.VapiInvokeOutput {
+	return cInvokeOutput(api.Invoke(vapi.VehicleHandle(vehicleId), goString(serviceName), goString(procedureInput), goString(stCredentials), invokeCallback(callback, context)))
+}
+
+//export VapiGetMetadata
+func VapiGetMetadata(vehicleId C.VapiVehicleHandle, path *C.char, stCredentials *C.char) C.VapiGetMetadataOutput {
+	return cGetMetadataOutput(api.GetMetadata(vapi.VehicleHandle(vehicleId), goString(path), goString(stCredentials)))
+}
+
+// ****************** Data procedures ***************
+//export VapiGet
+func VapiGet(vehicleId C.VapiVehicleHandle, path *C.char, filter *C.char, stCredentials *C.char) C.VapiGetOutput {
+	return cGetOutput(api.Get(vapi.VehicleHandle(vehicleId), goString(path), goString(filter), goString(stCredentials)))
+}
+
+//export VapiSet
+func VapiSet(vehicleId C.VapiVehicleHandle, path *C.char, value *C.char, stCredentials *C.char) C.VapiGeneralOutput {
+	return cGeneralOutput(api.Set(vapi.VehicleHandle(vehicleId), goString(path), goString(value), goString(stCredentials)))
+}
+
+//export VapiSubscribe
+func VapiSubscribe(vehicleId C.VapiVehicleHandle, path *C.char, filter *C.char, stCredentials *C.char, callback C.VapiSubscribeCallback, context unsafe.Pointer) C.VapiSubscribeOutput {
+	return cSubscribeOutput(api.Subscribe(vapi.VehicleHandle(vehicleId), goString(path), goString(filter), goString(stCredentials), subscribeCallback(callback, context)))
+}
+
+//export VapiUnsubscribe
+func VapiUnsubscribe(vehicleId C.VapiVehicleHandle, serviceId C.uint32_t) C.VapiGeneralOutput {
+	return cGeneralOutput(api.Unsubscribe(vapi.VehicleHandle(vehicleId), uint32(serviceId)))
+}
+
+// ****************** Seating service group ***************
+//export VapiMoveSeat
+func VapiMoveSeat(vehicleId C.VapiVehicleHandle, seatId C.VapiMatrixId, movementType *C.char, position C.VapiPercentage, stCredentials *C.char, callback C.VapiMoveSeatCallback, context unsafe.Pointer) C.VapiMoveSeatOutput {
+	return cMoveSeatOutput(api.MoveSeat(vapi.VehicleHandle(vehicleId), goMatrixId(seatId), goString(movementType), vapi.Percentage(position), goString(stCredentials), moveSeatCallback(callback, context)))
+}
+
+//export VapiConfigureSeat
+func VapiConfigureSeat(vehicleId C.VapiVehicleHandle, seatId C.VapiMatrixId, configuration *C.VapiSeatConfig, configurationCount C.int, stCredentials *C.char, callback C.VapiConfigureSeatCallback, context unsafe.Pointer) C.VapiConfigureSeatOutput {
+	return cConfigureSeatOutput(api.ConfigureSeat(vapi.VehicleHandle(vehicleId), goMatrixId(seatId), goSeatConfigs(configuration, configurationCount), goString(stCredentials), configureSeatCallback(callback, context)))
+}
+
+//export VapiActivateMassage
+func VapiActivateMassage(vehicleId C.VapiVehicleHandle, seatId C.VapiMatrixId, massageType *C.char, intensity C.VapiPercentage, duration C.uint32_t, stCredentials *C.char, callback C.VapiMassageCallback, context unsafe.Pointer) C.VapiMassageOutput {
+	return cMassageOutput(api.ActivateMassage(vapi.VehicleHandle(vehicleId), goMatrixId(seatId), goString(massageType), vapi.Percentage(intensity), uint32(duration), goString(stCredentials), massageCallback(callback, context)))
+}
+
+//export VapiActivateMassageProgram
+func VapiActivateMassageProgram(vehicleId C.VapiVehicleHandle, seatId C.VapiMatrixId, steps *C.VapiMassageStep, stepsCount C.int, stCredentials *C.char, callback C.VapiMassageProgramCallback, context unsafe.Pointer) C.VapiMassageProgramOutput {
+	return cMassageProgramOutput(api.ActivateMassageProgram(vapi.VehicleHandle(vehicleId), goMatrixId(seatId), goMassageSteps(steps, stepsCount), goString(stCredentials), massageProgramCallback(callback, context)))
+}
+
+//export VapiActivateSeatHeating
+func VapiActivateSeatHeating(vehicleId C.VapiVehicleHandle, seatId C.VapiMatrixId, level C.VapiPercentage, duration C.uint32_t, stCredentials *C.char, callback C.VapiSeatClimateCallback, context unsafe.Pointer) C.VapiSeatClimateOutput {
+	return cSeatClimateOutput(api.ActivateSeatHeating(vapi.VehicleHandle(vehicleId), goMatrixId(seatId), vapi.Percentage(level), uint32(duration), goString(stCredentials), seatClimateCallback(callback, context)))
+}
+
+//export VapiActivateSeatVentilation
+func VapiActivateSeatVentilation(vehicleId C.VapiVehicleHandle, seatId C.VapiMatrixId, level C.VapiPercentage, duration C.uint32_t, stCredentials *C.char, callback C.VapiSeatClimateCallback, context unsafe.Pointer) C.VapiSeatClimateOutput {
+	return cSeatClimateOutput(api.ActivateSeatVentilation(vapi.VehicleHandle(vehicleId), goMatrixId(seatId), vapi.Percentage(level), uint32(duration), goString(stCredentials), seatClimateCallback(callback, context)))
+}
+
+//export VapiGetPropertiesSeating
+func VapiGetPropertiesSeating(vehicleId C.VapiVehicleHandle) C.VapiGetPropertiesSeatingOutput {
+	return cGetPropertiesSeatingOutput(api.GetPropertiesSeating(vapi.VehicleHandle(vehicleId)))
+}
+
+// ****************** HVAC service group ***************
+//export VapiHvacService1
+func VapiHvacService1(vehicleId C.VapiVehicleHandle) C.VapiGeneralOutput {
+	return cGeneralOutput(api.HvacService1(vapi.VehicleHandle(vehicleId)))
+}
+
+// ****************** Output release ***************
+//export VapiFreeGeneralOutput
+func VapiFreeGeneralOutput(out *C.VapiGeneralOutput) {
+	freeGeneralOutput(out)
+}
+
+//export VapiFreeGetVehicleOutput
+func VapiFreeGetVehicleOutput(out *C.VapiGetVehicleOutput) {
+	freeGetVehicleOutput(out)
+}
+
+//export VapiFreeConnectOutput
+func VapiFreeConnectOutput(out *C.VapiConnectOutput) {
+	freeConnectOutput(out)
+}
+
+//export VapiFreeServiceInquiryOutput
+func VapiFreeServiceInquiryOutput(out *C.VapiServiceInquiryOutput) {
+	freeServiceInquiryOutput(out)
+}
+
+//export VapiFreeGetStCredentialsOutput
+func VapiFreeGetStCredentialsOutput(out *C.VapiGetStCredentialsOutput) {
+	freeGetStCredentialsOutput(out)
+}
+
+//export VapiFreeInvokeOutput
+func VapiFreeInvokeOutput(out *C.VapiInvokeOutput) {
+	freeInvokeOutput(out)
+}
+
+//export VapiFreeGetMetadataOutput
+func VapiFreeGetMetadataOutput(out *C.VapiGetMetadataOutput) {
+	freeGetMetadataOutput(out)
+}
+
+//export VapiFreeGetOutput
+func VapiFreeGetOutput(out *C.VapiGetOutput) {
+	freeGetOutput(out)
+}
+
+//export VapiFreeSubscribeOutput
+func VapiFreeSubscribeOutput(out *C.VapiSubscribeOutput) {
+	freeSubscribeOutput(out)
+}
+
+//export VapiFreeMoveSeatOutput
+func VapiFreeMoveSeatOutput(out *C.VapiMoveSeatOutput) {
+	freeMoveSeatOutput(out)
+}
+
+//export VapiFreeConfigureSeatOutput
+func VapiFreeConfigureSeatOutput(out *C.VapiConfigureSeatOutput) {
+	freeConfigureSeatOutput(out)
+}
+
+//export VapiFreeMassageOutput
+func VapiFreeMassageOutput(out *C.VapiMassageOutput) {
+	freeMassageOutput(out)
+}
+
+//export VapiFreeMassageProgramOutput
+func VapiFreeMassageProgramOutput(out *C.VapiMassageProgramOutput) {
+	freeMassageProgramOutput(out)
+}
+
+//export VapiFreeSeatClimateOutput
+func VapiFreeSeatClimateOutput(out *C.VapiSeatClimateOutput) {
+	freeSeatClimateOutput(out)
+}
+
+//export VapiFreeGetPropertiesSeatingOutput
+func VapiFreeGetPropertiesSeatingOutput(out *C.VapiGetPropertiesSeatingOutput) {
+	freeGetPropertiesSeatingOutput(out)
+}
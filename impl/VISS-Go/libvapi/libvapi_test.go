@@ -0,0 +1,69 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"VISS-Go/VissMock"
+)
+
+// TestExample builds the shared library and the C example, and runs the example against a mock VISS server.
+func TestExample(t *testing.T) {
+	if _, err := exec.LookPath("gcc"); err != nil {
+		t.Skip("gcc is not installed")
+	}
+	signals := VissMock.DefaultSignals()
+	for i := 0; i < len(signals); i++ {
+		if signals[i].Rate > 0 {
+			signals[i].Rate = 100
+		}
+	}
+	server := VissMock.NewServer(signals)
+	_, err := server.StartWs("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("StartWs: %s", err)
+	}
+	defer server.Close()
+
+	dir := t.TempDir()
+	build := exec.Command("go", "build", "-buildmode=c-shared", "-o", filepath.Join(dir, "libvapi.so"), ".")
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %s\n%s", err, output)
+	}
+	example := filepath.Join(dir, "vapiExample")
+	compile := exec.Command("gcc", "-Wall", "-I" + dir, "-I.", "-o", example, "example/vapiExample.c", "-L" + dir, "-lvapi", "-lpthread")
+	if output, err := compile.CombinedOutput(); err != nil {
+		t.Fatalf("gcc: %s\n%s", err, output)
+	}
+	run := exec.Command(example, "mockVin", "127.0.0.1", server.Port())
+	run.Env = append(os.Environ(), "LD_LIBRARY_PATH=" + dir)
+	output, err := run.CombinedOutput()
+	if err != nil {
+		t.Fatalf("vapiExample: %s\n%s", err, output)
+	}
+	for _, expected := range []string{
+		"Connected to vehicle id =mockVin",
+		"Path=Vehicle.CurrentLocation.Latitude",
+		"Seat Id= Row1, DriverSide",
+		"ConfigureSeat:Call status=SUCCESSFUL",
+		"Massage execution status:\nCall status=SUCCESSFUL",
+	} {
+		if !strings.Contains(string(output), expected) {
+			t.Errorf("the output has no %q:\n%s", expected, output)
+		}
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType"); value != "roll" {
+		t.Errorf("MassageType=%s", value)
+	}
+}
@@ -0,0 +1,221 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+#ifndef VAPI_H
+#define VAPI_H
+
+#include <stdint.h>
+
+/* The C types of the VAPI procedures, they mirror the types of the vapi Go package.
+*  The outputs that a procedure returns are owned by the caller, and are released by the VapiFree function of the output type.
+*  The outputs that are passed to a callback are owned by the library, and are only valid until the callback returns.
+*  Callbacks are called on a thread of the library. */
+
+typedef int8_t VapiProcedureStatus;
+#define VAPI_ONGOING 1     // in execution of latest call
+#define VAPI_SUCCESSFUL 0  // terminated successfully in latest call
+#define VAPI_FAILED -1     // terminated due to failure in latest call
+
+typedef uint32_t VapiVehicleHandle;
+typedef float VapiPercentage;  // min = 0, max = 100
+
+typedef struct {
+	int32_t code;
+	char* reason;
+	char* description;
+} VapiErrorData;
+
+// ****************** Common procedures ***************
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;  // NULL if the procedure did not fail
+} VapiGeneralOutput;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	VapiVehicleHandle vehicleId;
+	char** protocol;
+	int protocolCount;
+} VapiGetVehicleOutput;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	char* ltCredential;
+} VapiConnectOutput;
+
+typedef struct {
+	char* name;
+	char* input;
+	char* output;
+} VapiServiceSignature;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	VapiServiceSignature* service;
+	int serviceCount;
+} VapiServiceInquiryOutput;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	char* stCredentials;
+} VapiGetStCredentialsOutput;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	char* serviceOutput;
+	uint32_t serviceId;
+} VapiInvokeOutput;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	char* metadata;
+} VapiGetMetadataOutput;
+
+typedef void (*VapiInvokeCallback)(const VapiInvokeOutput* out, void* context);
+
+// ****************** Data procedures ***************
+typedef struct {
+	char* value;
+	char* timestamp;
+} VapiDataPoint;
+
+typedef struct {
+	char* path;
+	VapiDataPoint* dp;
+	int dpCount;
+} VapiDataContainer;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	VapiDataContainer* data;
+	int dataCount;
+} VapiGetOutput;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	VapiDataContainer* data;
+	int dataCount;
+	uint32_t serviceId;
+} VapiSubscribeOutput;
+
+typedef void (*VapiSubscribeCallback)(const VapiSubscribeOutput* out, void* context);
+
+// ****************** Seating service group ***************
+#define VAPI_LONGITUDINAL "longitudinal"  // Forward-backward direction of the vehicle
+#define VAPI_VERTICAL "vertical"          // Up-down direction of the vehicle
+#define VAPI_BACKREST "backrest"          // Seat backrest angular
+#define VAPI_LUMBAR "lumbar"              // Seat inflate-deflate lumbar
+
+#define VAPI_FORWARD 0            // longitudinal movement
+#define VAPI_BACKWARD 100         // longitudinal movement
+#define VAPI_UP 100               // vertical movement
+#define VAPI_DOWN 0               // vertical movement
+#define VAPI_INFLATE 100          // lumbar movement
+#define VAPI_DEFLATE 0            // lumbar movement
+#define VAPI_FORWARD_RECLINE 0    // backrest movement
+#define VAPI_BACKWARD_RECLINE 100 // backrest movement
+
+#define VAPI_ROLL "roll"
+#define VAPI_PULSE "pulse"
+#define VAPI_WAVE "wave"
+
+typedef struct {
+	char* rowName;
+	char* columnName;
+} VapiMatrixId;
+
+typedef struct {
+	char* movementType;
+	VapiPercentage position;
+} VapiSeatConfig;
+
+typedef struct {
+	char* massageType;
+	VapiPercentage intensity;
+	uint32_t duration;  // seconds
+} VapiMassageStep;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	VapiPercentage position;
+	uint32_t serviceId;
+} VapiMoveSeatOutput;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	VapiSeatConfig* configured;
+	int configuredCount;
+	char** unconfigured;
+	int unconfiguredCount;
+	uint32_t serviceId;
+} VapiConfigureSeatOutput;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	uint32_t serviceId;
+} VapiMassageOutput;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	int stepIndex;  // index of the step in execution
+	uint32_t serviceId;
+} VapiMassageProgramOutput;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	uint32_t serviceId;
+} VapiSeatClimateOutput;
+
+typedef struct {
+	char* name;
+	char* description;
+} VapiSupportData;
+
+typedef struct {
+	char* name;
+	VapiSupportData* movementSupport;
+	int movementSupportCount;
+	VapiSupportData* massageSupport;
+	int massageSupportCount;
+	VapiSupportData* climateSupport;
+	int climateSupportCount;
+} VapiColumnData;
+
+typedef struct {
+	char* rowName;
+	VapiColumnData* column;
+	int columnCount;
+} VapiRowDef;
+
+typedef struct {
+	VapiProcedureStatus status;
+	VapiErrorData* error;
+	VapiRowDef* properties;
+	int propertiesCount;
+} VapiGetPropertiesSeatingOutput;
+
+typedef void (*VapiMoveSeatCallback)(const VapiMoveSeatOutput* out, void* context);
+typedef void (*VapiConfigureSeatCallback)(const VapiConfigureSeatOutput* out, void* context);
+typedef void (*VapiMassageCallback)(const VapiMassageOutput* out, void* context);
+typedef void (*VapiMassageProgramCallback)(const VapiMassageProgramOutput* out, void* context);
+typedef void (*VapiSeatClimateCallback)(const VapiSeatClimateOutput* out, void* context);
+
+#endif
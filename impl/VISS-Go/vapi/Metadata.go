@@ -0,0 +1,310 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package vapi
+
+import (
+	"encoding/json"
+	"fmt"
+	pathpkg "path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ****************** Metadata ***************
+/* The Metadata of a GetMetadataOutput is the HIM metadata tree in JSON, where each node is keyed by its name, e.g.
+*  {"Vehicle":{"type":"branch","description":"High-level vehicle data.","children":{
+*    "Speed":{"type":"sensor","datatype":"float","unit":"km/h","min":0,"max":250,"description":"Vehicle speed."}}}}
+*  ParseMetadata turns it into a MetadataTree of typed nodes. */
+
+// node types
+const (
+	BRANCH = "branch"
+	SENSOR = "sensor"
+	ACTUATOR = "actuator"
+	ATTRIBUTE = "attribute"
+)
+
+type MetadataNode struct {
+	Name string
+	Path string
+	Type string  // BRANCH, SENSOR, ACTUATOR, or ATTRIBUTE
+	Datatype string  // HIM datatype of a leaf, e.g. uint8, float, string[]
+	Unit string
+	Min *float64
+	Max *float64
+	Allowed []string
+	Description string
+	Children []*MetadataNode  // ordered by name
+}
+
+type MetadataTree struct {
+	Roots []*MetadataNode
+}
+
+/* ParseMetadata parses the metadata of a GetMetadata call on path. A server may return the tree from the root of the path,
+*  or from the node that path addresses, the node paths are full paths in both cases. */
+func ParseMetadata(path string, metadata string) (*MetadataTree, error) {
+	var metadataMap map[string]interface{}
+	err := json.Unmarshal([]byte(metadata), &metadataMap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata: %s", err)
+	}
+	var parentPath string
+	segments := strings.Split(path, ".")
+	if len(segments) > 1 && metadataMap[segments[0]] == nil && metadataMap[segments[len(segments)-1]] != nil {
+		parentPath = strings.Join(segments[:len(segments)-1], ".")
+	}
+	var tree MetadataTree
+	tree.Roots, err = parseMetadataNodes(parentPath, metadataMap)
+	if err != nil {
+		return nil, err
+	}
+	return &tree, nil
+}
+
+func parseMetadataNodes(parentPath string, nodesMap map[string]interface{}) ([]*MetadataNode, error) {
+	var nodes []*MetadataNode
+	for name, value := range nodesMap {
+		nodeMap, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid metadata: the node %s is not an object", name)
+		}
+		node := &MetadataNode{Name: name, Path: name}
+		if parentPath != "" {
+			node.Path = parentPath + "." + name
+		}
+		node.Type, _ = nodeMap["type"].(string)
+		node.Datatype, _ = nodeMap["datatype"].(string)
+		node.Unit, _ = nodeMap["unit"].(string)
+		node.Description, _ = nodeMap["description"].(string)
+		node.Min = getMetadataNumber(nodeMap["min"])
+		node.Max = getMetadataNumber(nodeMap["max"])
+		if allowed, ok := nodeMap["allowed"].([]interface{}); ok {
+			for _, value := range allowed {
+				node.Allowed = append(node.Allowed, fmt.Sprint(value))
+			}
+		}
+		if children, ok := nodeMap["children"].(map[string]interface{}); ok {
+			var err error
+			node.Children, err = parseMetadataNodes(node.Path, children)
+			if err != nil {
+				return nil, err
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes, nil
+}
+
+func getMetadataNumber(value interface{}) *float64 {
+	switch number := value.(type) {
+		case float64:
+			return &number
+		case string:
+			if parsed, err := strconv.ParseFloat(number, 64); err == nil {
+				return &parsed
+			}
+	}
+	return nil
+}
+
+// Lookup returns the node of a path, or nil if the tree has no such node.
+func (tree *MetadataTree) Lookup(path string) *MetadataNode {
+	for _, root := range tree.Roots {
+		if path == root.Path {
+			return root
+		}
+		if !strings.HasPrefix(path, root.Path + ".") {
+			continue
+		}
+		node := root
+		for _, name := range strings.Split(path[len(root.Path)+1:], ".") {
+			node = node.getChild(name)
+			if node == nil {
+				return nil
+			}
+		}
+		return node
+	}
+	return nil
+}
+
+func (node *MetadataNode) getChild(name string) *MetadataNode {
+	for _, child := range node.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// Match returns the nodes of a path pattern in walk order, where a name of the pattern may contain the wildcard *, e.g. Vehicle.Cabin.Seat.*.*.Position.
+func (tree *MetadataTree) Match(pattern string) []*MetadataNode {
+	var matches []*MetadataNode
+	patternNames := strings.Split(pattern, ".")
+	tree.Walk(func(node *MetadataNode) bool {
+		names := strings.Split(node.Path, ".")
+		if len(names) > len(patternNames) {
+			return false
+		}
+		for i, name := range names {
+			if ok, _ := pathpkg.Match(patternNames[i], name); !ok {
+				return false
+			}
+		}
+		if len(names) == len(patternNames) {
+			matches = append(matches, node)
+			return false
+		}
+		return true
+	})
+	return matches
+}
+
+/* Insert adds a node to the tree at its path, the parent branch of the node must be in the tree. The name and the children of the node are set by Insert,
+*  and a node that is already in the tree is replaced. */
+func (tree *MetadataTree) Insert(node *MetadataNode) error {
+	parentPath, name := "", node.Path
+	if index := strings.LastIndex(node.Path, "."); index >= 0 {
+		parentPath, name = node.Path[:index], node.Path[index+1:]
+	}
+	siblings := &tree.Roots
+	if parentPath != "" {
+		parent := tree.Lookup(parentPath)
+		if parent == nil || parent.IsLeaf() {
+			return fmt.Errorf("%s: the parent branch %s is not in the tree", node.Path, parentPath)
+		}
+		siblings = &parent.Children
+	}
+	node.Name = name
+	node.Children = nil
+	index := sort.Search(len(*siblings), func(i int) bool { return (*siblings)[i].Name >= name })
+	if index < len(*siblings) && (*siblings)[index].Name == name {
+		node.Children = (*siblings)[index].Children
+		(*siblings)[index] = node
+		return nil
+	}
+	*siblings = append(*siblings, nil)
+	copy((*siblings)[index+1:], (*siblings)[index:])
+	(*siblings)[index] = node
+	return nil
+}
+
+// Walk visits the nodes depth first in name order, the children of a node are skipped if visit returns false.
+func (tree *MetadataTree) Walk(visit func(node *MetadataNode) bool) {
+	walkMetadataNodes(tree.Roots, visit)
+}
+
+func walkMetadataNodes(nodes []*MetadataNode, visit func(node *MetadataNode) bool) {
+	for _, node := range nodes {
+		if visit(node) {
+			walkMetadataNodes(node.Children, visit)
+		}
+	}
+}
+
+// Leaves returns the paths of the leaf nodes below path, or path itself if it is a leaf.
+func (tree *MetadataTree) Leaves(path string) []string {
+	var leaves []string
+	tree.Walk(func(node *MetadataNode) bool {
+		if node.Path != path && !strings.HasPrefix(node.Path, path + ".") && !strings.HasPrefix(path, node.Path + ".") {
+			return false
+		}
+		if node.IsLeaf() && (node.Path == path || strings.HasPrefix(node.Path, path + ".")) {
+			leaves = append(leaves, node.Path)
+		}
+		return true
+	})
+	return leaves
+}
+
+func (node *MetadataNode) IsLeaf() bool {
+	return node.Type != BRANCH
+}
+
+// CheckValue returns an error if value is not a valid value of the leaf node, a value of an array datatype is a JSON array.
+func (node *MetadataNode) CheckValue(value string) error {
+	if !node.IsLeaf() {
+		return fmt.Errorf("%s is a branch", node.Path)
+	}
+	elementType, isArray := strings.CutSuffix(node.Datatype, "[]")
+	if !isArray {
+		return node.checkElement(elementType, value)
+	}
+	var elements []interface{}
+	err := json.Unmarshal([]byte(value), &elements)
+	if err != nil {
+		return fmt.Errorf("%s: the value is not an array", node.Path)
+	}
+	for _, element := range elements {
+		elementValue, ok := element.(string)
+		if !ok {
+			elementValue = fmt.Sprint(element)
+		}
+		err = node.checkElement(elementType, elementValue)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (node *MetadataNode) checkElement(datatype string, value string) error {
+	var number float64
+	var err error
+	isNumber := true
+	switch datatype {
+		case "uint8", "uint16", "uint32", "uint64":
+			var parsed uint64
+			parsed, err = strconv.ParseUint(value, 10, getBitSize(datatype))
+			number = float64(parsed)
+		case "int8", "int16", "int32", "int64":
+			var parsed int64
+			parsed, err = strconv.ParseInt(value, 10, getBitSize(datatype))
+			number = float64(parsed)
+		case "float", "double":
+			bitSize := 64
+			if datatype == "float" {
+				bitSize = 32
+			}
+			number, err = strconv.ParseFloat(value, bitSize)
+		case "boolean":
+			isNumber = false
+			if value != "true" && value != "false" {
+				err = fmt.Errorf("not a boolean")
+			}
+		default: // string, and datatypes that are not known
+			isNumber = false
+	}
+	if err != nil {
+		return fmt.Errorf("%s: the value %s is not a valid %s", node.Path, value, datatype)
+	}
+	if isNumber && node.Min != nil && number < *node.Min {
+		return fmt.Errorf("%s: the value %s is below the min %g", node.Path, value, *node.Min)
+	}
+	if isNumber && node.Max != nil && number > *node.Max {
+		return fmt.Errorf("%s: the value %s is above the max %g", node.Path, value, *node.Max)
+	}
+	if len(node.Allowed) > 0 {
+		for _, allowed := range node.Allowed {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: the value %s is not allowed", node.Path, value)
+	}
+	return nil
+}
+
+func getBitSize(datatype string) int {
+	bitSize, _ := strconv.Atoi(strings.TrimLeft(datatype, "uint"))
+	return bitSize
+}
@@ -0,0 +1,165 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package vapi
+
+import (
+	"strings"
+	"testing"
+)
+
+const testMetadata = `{"Vehicle":{"type":"branch","description":"High-level vehicle data.","children":{
+	"Speed":{"type":"sensor","datatype":"float","unit":"km/h","min":0,"max":"250","description":"Vehicle speed."},
+	"Cabin":{"type":"branch","description":"All in-cabin components.","children":{
+		"Seat":{"type":"branch","description":"All seats.","children":{
+			"Row1":{"type":"branch","description":"Seat row 1.","children":{
+				"DriverSide":{"type":"branch","description":"Driver side seat.","children":{
+					"Position":{"type":"actuator","datatype":"uint16","unit":"mm","min":0,"max":1000,"description":"Seat position."},
+					"Heating":{"type":"actuator","datatype":"int8","unit":"percent","min":-100,"max":100,"description":"Seat heating."}}}}}}}}},
+	"LowVoltageSystemState":{"type":"sensor","datatype":"string","allowed":["UNDEFINED","LOCK","OFF","ACC","ON","START"],"description":"State of the supply voltage."},
+	"VehicleIdentification":{"type":"branch","description":"Attributes that identify a vehicle.","children":{
+		"VIN":{"type":"attribute","datatype":"string","description":"17-character Vehicle Identification Number."}}},
+	"Powertrain":{"type":"branch","description":"Powertrain data.","children":{
+		"TractionBattery":{"type":"branch","description":"Battery.","children":{
+			"CellVoltages":{"type":"sensor","datatype":"float[]","unit":"V","min":0,"max":5,"description":"Cell voltages."},
+			"IsCharging":{"type":"sensor","datatype":"boolean","description":"Charging state."}}}}}}}}`
+
+func TestParseMetadata(t *testing.T) {
+	tree, err := ParseMetadata("Vehicle", testMetadata)
+	if err != nil {
+		t.Fatalf("ParseMetadata: %s", err)
+	}
+	speed := tree.Lookup("Vehicle.Speed")
+	if speed == nil || speed.Type != SENSOR || speed.Datatype != "float" || speed.Unit != "km/h" || *speed.Min != 0 || *speed.Max != 250 || speed.Description != "Vehicle speed." {
+		t.Errorf("Vehicle.Speed = %+v", speed)
+	}
+	vin := tree.Lookup("Vehicle.VehicleIdentification.VIN")
+	if vin == nil || vin.Type != ATTRIBUTE || !vin.IsLeaf() || vin.Path != "Vehicle.VehicleIdentification.VIN" {
+		t.Errorf("Vehicle.VehicleIdentification.VIN = %+v", vin)
+	}
+	if seat := tree.Lookup("Vehicle.Cabin.Seat"); seat == nil || seat.IsLeaf() || len(seat.Children) != 1 {
+		t.Errorf("Vehicle.Cabin.Seat = %+v", seat)
+	}
+	for _, path := range []string{"Vehicle.Unknown", "Vehicle.Speed.Unknown", "Cabin"} {
+		if node := tree.Lookup(path); node != nil {
+			t.Errorf("Lookup(%s) = %+v, want nil", path, node)
+		}
+	}
+
+	var paths []string
+	tree.Walk(func(node *MetadataNode) bool {
+		paths = append(paths, node.Path)
+		return node.Name != "Cabin"
+	})
+	want := "Vehicle Vehicle.Cabin Vehicle.LowVoltageSystemState Vehicle.Powertrain Vehicle.Powertrain.TractionBattery " +
+		"Vehicle.Powertrain.TractionBattery.CellVoltages Vehicle.Powertrain.TractionBattery.IsCharging Vehicle.Speed " +
+		"Vehicle.VehicleIdentification Vehicle.VehicleIdentification.VIN"
+	if strings.Join(paths, " ") != want {
+		t.Errorf("Walk visited %v", paths)
+	}
+	leaves := tree.Leaves("Vehicle.Cabin.Seat")
+	if strings.Join(leaves, " ") != "Vehicle.Cabin.Seat.Row1.DriverSide.Heating Vehicle.Cabin.Seat.Row1.DriverSide.Position" {
+		t.Errorf("Leaves(Vehicle.Cabin.Seat) = %v", leaves)
+	}
+	if leaves = tree.Leaves("Vehicle.Speed"); len(leaves) != 1 || leaves[0] != "Vehicle.Speed" {
+		t.Errorf("Leaves(Vehicle.Speed) = %v", leaves)
+	}
+
+	subtree, err := ParseMetadata("Vehicle.Cabin.Seat", `{"Seat":{"type":"branch","children":{"Row1":{"type":"branch"}}}}`)
+	if err != nil || subtree.Lookup("Vehicle.Cabin.Seat.Row1") == nil {
+		t.Errorf("the subtree of Vehicle.Cabin.Seat has no Vehicle.Cabin.Seat.Row1, error=%v", err)
+	}
+	for _, metadata := range []string{`"Vehicle"`, `{"Vehicle":"branch"}`, `{"Vehicle":{"type":"branch","children":{"Speed":0}}}`} {
+		if _, err := ParseMetadata("Vehicle", metadata); err == nil {
+			t.Errorf("ParseMetadata(%s) has no error", metadata)
+		}
+	}
+}
+
+func TestCheckValue(t *testing.T) {
+	tree, err := ParseMetadata("Vehicle", testMetadata)
+	if err != nil {
+		t.Fatalf("ParseMetadata: %s", err)
+	}
+	tests := []struct {
+		path string
+		value string
+		valid bool
+	}{
+		{"Vehicle.Speed", "120.5", true},
+		{"Vehicle.Speed", "-1", false},
+		{"Vehicle.Speed", "251", false},
+		{"Vehicle.Speed", "fast", false},
+		{"Vehicle.Cabin.Seat.Row1.DriverSide.Position", "1000", true},
+		{"Vehicle.Cabin.Seat.Row1.DriverSide.Position", "1001", false},
+		{"Vehicle.Cabin.Seat.Row1.DriverSide.Position", "-5", false},
+		{"Vehicle.Cabin.Seat.Row1.DriverSide.Position", "2.5", false},
+		{"Vehicle.Cabin.Seat.Row1.DriverSide.Heating", "-100", true},
+		{"Vehicle.Cabin.Seat.Row1.DriverSide.Heating", "200", false},
+		{"Vehicle.LowVoltageSystemState", "ON", true},
+		{"Vehicle.LowVoltageSystemState", "on", false},
+		{"Vehicle.VehicleIdentification.VIN", "anything", true},
+		{"Vehicle.Powertrain.TractionBattery.IsCharging", "true", true},
+		{"Vehicle.Powertrain.TractionBattery.IsCharging", "1", false},
+		{"Vehicle.Powertrain.TractionBattery.CellVoltages", `[3.7, "3.8"]`, true},
+		{"Vehicle.Powertrain.TractionBattery.CellVoltages", "[3.7, 5.1]", false},
+		{"Vehicle.Powertrain.TractionBattery.CellVoltages", "3.7", false},
+		{"Vehicle.Cabin", "1", false},
+	}
+	for _, test := range tests {
+		err := tree.Lookup(test.path).CheckValue(test.value)
+		if (err == nil) != test.valid {
+			t.Errorf("CheckValue(%s, %s) = %v, want valid=%t", test.path, test.value, err, test.valid)
+		}
+	}
+}
+
+func TestMatchAndInsert(t *testing.T) {
+	tree, err := ParseMetadata("Vehicle", testMetadata)
+	if err != nil {
+		t.Fatalf("ParseMetadata: %s", err)
+	}
+	tests := []struct {
+		pattern string
+		expected string
+	}{
+		{"Vehicle.Cabin.Seat.*.*.Position", "Vehicle.Cabin.Seat.Row1.DriverSide.Position"},
+		{"Vehicle.Cabin.Seat.Row1.DriverSide.*", "Vehicle.Cabin.Seat.Row1.DriverSide.Heating Vehicle.Cabin.Seat.Row1.DriverSide.Position"},
+		{"Vehicle.Powertrain.TractionBattery.Is*", "Vehicle.Powertrain.TractionBattery.IsCharging"},
+		{"Vehicle.Speed", "Vehicle.Speed"},
+		{"Vehicle.*.Width", ""},
+		{"Vehicle.Speed.*", ""},
+	}
+	for _, test := range tests {
+		var paths []string
+		for _, node := range tree.Match(test.pattern) {
+			paths = append(paths, node.Path)
+		}
+		if strings.Join(paths, " ") != test.expected {
+			t.Errorf("Match(%s) = %v, want %s", test.pattern, paths, test.expected)
+		}
+	}
+
+	err = tree.Insert(&MetadataNode{Path: "Vehicle.Cabin.Seat.Row1.DriverSide.Height", Type: ACTUATOR, Datatype: "uint16"})
+	if err != nil {
+		t.Fatalf("Insert: %s", err)
+	}
+	seat := tree.Lookup("Vehicle.Cabin.Seat.Row1.DriverSide")
+	if len(seat.Children) != 3 || seat.Children[1].Name != "Height" {
+		t.Errorf("the children of the seat are not ordered after Insert: %+v", seat.Children)
+	}
+	err = tree.Insert(&MetadataNode{Path: "Vehicle.Cabin", Type: BRANCH, Description: "Cabin."})
+	if err != nil || tree.Lookup("Vehicle.Cabin").Description != "Cabin." || tree.Lookup("Vehicle.Cabin.Seat") == nil {
+		t.Errorf("a replaced branch lost its children, error=%v", err)
+	}
+	for _, path := range []string{"Vehicle.Body.Hood", "Vehicle.Speed.Unit"} {
+		if err := tree.Insert(&MetadataNode{Path: path, Type: SENSOR}); err == nil {
+			t.Errorf("Insert(%s) has no error", path)
+		}
+	}
+}
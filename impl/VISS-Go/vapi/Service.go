@@ -0,0 +1,143 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package vapi
+
+// ****************** Service procedures ***************
+// Service is the union of the service groups, see the spec/Service directory.
+type Service interface {
+	Seating
+	Hvac
+}
+
+// ****************** Seating service group ***************
+type Percentage float32  // min = 0, max = 100
+
+type MatrixId struct {
+	RowName string
+	ColumnName string
+}
+
+type SeatConfig struct {
+	MovementType string
+	Position Percentage
+}
+
+// constants for the different seat movement types
+const (
+	LONGITUDINAL = "longitudinal" //Forward-backward direction of the vehicle
+	VERTICAL = "vertical"         // Up-down direction of the vehicle
+	BACKREST = "backrest"         // Seat backrest angular
+	LUMBAR = "lumbar"             // Seat inflate-deflate lumbar
+)
+
+/* constants for asynchronous seat movements; invoke MoveSeat using one of the constants together with its associated movement type,
+*  then terminate the movement by invoking CancelService */
+const (
+	FORWARD = 0            //longitudinal movement
+	BACKWARD = 100         //longitudinal movement
+	UP = 100               //vertical movement
+	DOWN = 0               //vertical movement
+	INFLATE = 100          //lumbar movement
+	DEFLATE = 0            //lumbar movement
+	FORWARD_RECLINE = 0    //backrest movement
+	BACKWARD_RECLINE = 100 //backrest movement
+)
+
+// constants for massage support
+const (
+	ROLL = "roll"
+	PULSE = "pulse"
+	WAVE = "wave"
+)
+
+// constants for seat climate support
+const (
+	HEATING = "heating"
+	VENTILATION = "ventilation"
+)
+
+type MassageOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	ServiceId uint32
+}
+
+type SeatClimateOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	ServiceId uint32
+}
+
+type MassageStep struct {
+	MassageType string
+	Intensity Percentage
+	Duration uint32 // seconds
+}
+
+type MassageProgramOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	StepIndex int // index of the step in execution
+	ServiceId uint32
+}
+
+type MoveSeatOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Position Percentage
+	ServiceId uint32
+}
+
+type ConfigureSeatOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Configured []SeatConfig
+	Unconfigured []string
+	ServiceId uint32
+}
+
+type SupportData struct {
+	Name string
+	Description string
+}
+
+type ColumnData struct {
+	Name string
+	MovementSupport []SupportData
+	MassageSupport []SupportData
+	ClimateSupport []SupportData
+}
+
+type RowDef struct {
+	RowName string
+	Column []ColumnData
+}
+
+type RaggedMatrix []RowDef
+
+type GetPropertiesSeatingOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Properties RaggedMatrix
+}
+
+type Seating interface {
+	MoveSeat(vehicleId VehicleHandle, seatId MatrixId, movementType string, position Percentage, stCredentials string, callback func(MoveSeatOutput)) MoveSeatOutput
+	ConfigureSeat(vehicleId VehicleHandle, seatId MatrixId, configuration []SeatConfig, stCredentials string, callback func(ConfigureSeatOutput)) ConfigureSeatOutput
+	ActivateMassage(vehicleId VehicleHandle, seatId MatrixId, massageType string, intensity Percentage, duration uint32, stCredentials string, callback func(MassageOutput)) MassageOutput
+	ActivateMassageProgram(vehicleId VehicleHandle, seatId MatrixId, steps []MassageStep, stCredentials string, callback func(MassageProgramOutput)) MassageProgramOutput
+	ActivateSeatHeating(vehicleId VehicleHandle, seatId MatrixId, level Percentage, duration uint32, stCredentials string, callback func(SeatClimateOutput)) SeatClimateOutput
+	ActivateSeatVentilation(vehicleId VehicleHandle, seatId MatrixId, level Percentage, duration uint32, stCredentials string, callback func(SeatClimateOutput)) SeatClimateOutput
+	GetPropertiesSeating(vehicleId VehicleHandle) GetPropertiesSeatingOutput
+}
+
+// ****************** HVAC service group ***************
+type Hvac interface {
+	HvacService1(vehicleId VehicleHandle) GeneralOutput
+}
@@ -0,0 +1,220 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package vapi
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"sync"
+)
+
+// ****************** Event streams ***************
+/* A Stream delivers the events of a subscription or a service on a channel, or by an iterator, instead of by a callback.
+*  Its Send method is passed as the callback of the procedure, and puts the events in the buffered channel C. The policy of a full buffer
+*  decides whether Send drops the oldest event, blocks until the consumer has received an event, or coalesces the new event with the newest
+*  buffered one, so that a slow consumer does not stall the thread of the callback unless it is asked to.
+*  The channel is closed after the final event, or when the stream is closed. */
+type Backpressure int8
+const (
+	DROP_OLDEST Backpressure = iota
+	BLOCK
+	COALESCE
+)
+
+type Stream[T any] struct {
+	mutex sync.Mutex  // serializes the senders
+	c chan T  // the buffer
+	policy Backpressure
+	isFinal func(T) bool
+	merge func(older T, newer T) T
+	ended bool  // the channel is closed
+	closeOnce sync.Once
+	closeChan chan struct{}
+	onCloseMutex sync.Mutex  // not the mutex of the senders, which a blocked Send holds
+	onClose func()
+	dropped uint64
+}
+
+/* NewStream returns a stream with a buffer of size events. isFinal reports the final event, after which no events are accepted,
+*  and merge returns the coalesced event of two events with the COALESCE policy, the newer event replaces the older if it is nil. */
+func NewStream[T any](size int, policy Backpressure, isFinal func(T) bool, merge func(older T, newer T) T) *Stream[T] {
+	if size < 1 {
+		size = 1
+	}
+	return &Stream[T]{c: make(chan T, size), policy: policy, isFinal: isFinal, merge: merge, closeChan: make(chan struct{})}
+}
+
+// NewSubscribeStream returns a stream of the events of Subscribe, which ends after an event with the status FAILED. Coalesced events have the newest data point of each path.
+func NewSubscribeStream(size int, policy Backpressure) *Stream[SubscribeOutput] {
+	return NewStream(size, policy, func(event SubscribeOutput) bool { return event.Status == FAILED }, mergeSubscribeOutput)
+}
+
+// NewServiceStream returns a stream of the events of a service, e.g. MoveSeatOutput, which ends after an event with a status other than ONGOING.
+func NewServiceStream[T any](size int, policy Backpressure) *Stream[T] {
+	var event T
+	if field, ok := reflect.TypeOf(event).FieldByName("Status"); !ok || field.Type != reflect.TypeOf(ProcedureStatus(0)) {
+		panic(fmt.Sprintf("NewServiceStream: %T has no Status", event))
+	}
+	isFinal := func(event T) bool {
+		return ProcedureStatus(reflect.ValueOf(event).FieldByName("Status").Int()) != ONGOING
+	}
+	return NewStream[T](size, policy, isFinal, nil)
+}
+
+/* SubscribeStream subscribes by the backend api with a stream as the callback, and returns the output of Subscribe and the stream,
+*  or no stream if the subscription failed. The subscription is unsubscribed when the stream is closed, e.g. by leaving a range loop over All. */
+func SubscribeStream(api Data, vehicleId VehicleHandle, path string, filter string, stCredentials string, size int, policy Backpressure) (SubscribeOutput, *Stream[SubscribeOutput]) {
+	stream := NewSubscribeStream(size, policy)
+	out := api.Subscribe(vehicleId, path, filter, stCredentials, stream.Send)
+	if out.Status == FAILED {
+		stream.Close()
+		return out, nil
+	}
+	stream.OnClose(func() { api.Unsubscribe(vehicleId, out.ServiceId) })
+	return out, stream
+}
+
+func mergeSubscribeOutput(older SubscribeOutput, newer SubscribeOutput) SubscribeOutput {
+	merged := newer
+	merged.Data = append([]DataContainer(nil), newer.Data...)
+	for _, olderContainer := range older.Data {
+		found := false
+		for _, newerContainer := range newer.Data {
+			if newerContainer.Path == olderContainer.Path {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged.Data = append(merged.Data, olderContainer)
+		}
+	}
+	return merged
+}
+
+// Send is the callback of the procedure that puts an event in the stream.
+func (stream *Stream[T]) Send(event T) {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	if stream.ended || stream.isClosed() {
+		return
+	}
+	switch stream.policy {
+		case BLOCK:
+			select {
+				case stream.c <- event:
+				case <- stream.closeChan:
+					return
+			}
+		case COALESCE:
+			select {
+				case stream.c <- event:
+				default:
+					var buffered []T
+					for len(stream.c) > 0 {
+						buffered = append(buffered, <- stream.c)
+					}
+					if len(buffered) > 0 {
+						if stream.merge != nil {
+							event = stream.merge(buffered[len(buffered)-1], event)
+						}
+						buffered = buffered[:len(buffered)-1]
+						stream.dropped++
+					}
+					for _, bufferedEvent := range append(buffered, event) {
+						stream.c <- bufferedEvent
+					}
+			}
+		default:
+			for sent := false; !sent; {
+				select {
+					case stream.c <- event:
+						sent = true
+					default:
+						select {
+							case <- stream.c:
+								stream.dropped++
+							default:
+						}
+				}
+			}
+	}
+	if stream.isFinal != nil && stream.isFinal(event) {
+		stream.ended = true
+		close(stream.c)
+	}
+}
+
+func (stream *Stream[T]) isClosed() bool {
+	select {
+		case <- stream.closeChan:
+			return true
+		default:
+			return false
+	}
+}
+
+// C returns the channel of the events.
+func (stream *Stream[T]) C() <-chan T {
+	return stream.c
+}
+
+// All returns an iterator over the events, the stream is closed if the loop is left before the final event.
+func (stream *Stream[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for event := range stream.c {
+			if !yield(event) {
+				stream.Close()
+				return
+			}
+		}
+	}
+}
+
+// OnClose sets a function that is called when the stream is closed, e.g. one that unsubscribes.
+func (stream *Stream[T]) OnClose(onClose func()) {
+	stream.onCloseMutex.Lock()
+	stream.onClose = onClose
+	stream.onCloseMutex.Unlock()
+}
+
+// Close stops the delivery, the buffered events are discarded and the channel is closed.
+func (stream *Stream[T]) Close() {
+	closing := false
+	stream.closeOnce.Do(func() {
+		close(stream.closeChan)  // releases a blocked Send
+		closing = true
+	})
+	if !closing {
+		return
+	}
+	stream.mutex.Lock()
+	if !stream.ended {
+		stream.ended = true
+		for len(stream.c) > 0 {
+			<- stream.c
+		}
+		close(stream.c)
+	}
+	stream.mutex.Unlock()
+	stream.onCloseMutex.Lock()
+	onClose := stream.onClose
+	stream.onCloseMutex.Unlock()
+	if onClose != nil {
+		onClose()
+	}
+}
+
+// Dropped returns the number of events that were dropped or coalesced because the buffer was full.
+func (stream *Stream[T]) Dropped() uint64 {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	return stream.dropped
+}
@@ -0,0 +1,129 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package vapi
+
+import (
+	"testing"
+	"time"
+)
+
+func subscribeEvent(path string, value string) SubscribeOutput {
+	return SubscribeOutput{Status: SUCCESSFUL, Data: []DataContainer{{Path: path, Dp: []DataPoint{{Value: value}}}}}
+}
+
+// receiveEvents receives the events of a stream until its channel is closed.
+func receiveEvents(t *testing.T, stream *Stream[SubscribeOutput]) []SubscribeOutput {
+	t.Helper()
+	var events []SubscribeOutput
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+			case event, ok := <- stream.C():
+				if !ok {
+					return events
+				}
+				events = append(events, event)
+			case <- timeout:
+				t.Fatalf("the stream was not closed, received %v", events)
+		}
+	}
+}
+
+func TestStreamPolicies(t *testing.T) {
+	final := SubscribeOutput{Status: FAILED, Error: &ErrorData{Code: 404}}
+
+	stream := NewSubscribeStream(2, DROP_OLDEST)
+	for _, value := range []string{"1", "2", "3", "4"} {
+		stream.Send(subscribeEvent("Vehicle.Speed", value))
+	}
+	stream.Send(final)
+	stream.Send(subscribeEvent("Vehicle.Speed", "6"))
+	events := receiveEvents(t, stream)
+	if len(events) != 2 || events[0].Data[0].Dp[0].Value != "4" || events[1].Status != FAILED || stream.Dropped() != 3 {
+		t.Errorf("DROP_OLDEST delivered %+v, dropped %d", events, stream.Dropped())
+	}
+
+	stream = NewSubscribeStream(2, COALESCE)
+	stream.Send(subscribeEvent("Vehicle.Speed", "1"))
+	stream.Send(subscribeEvent("Vehicle.Speed", "2"))
+	stream.Send(subscribeEvent("Vehicle.CurrentLocation.Latitude", "57.7"))
+	stream.Send(subscribeEvent("Vehicle.Speed", "3"))
+	stream.Close()
+	if _, ok := <- stream.C(); ok {
+		t.Errorf("an event was delivered after Close")
+	}
+	merged := mergeSubscribeOutput(subscribeEvent("Vehicle.CurrentLocation.Latitude", "57.7"), subscribeEvent("Vehicle.Speed", "3"))
+	merged = mergeSubscribeOutput(merged, subscribeEvent("Vehicle.Speed", "4"))
+	if len(merged.Data) != 2 || merged.Data[0].Dp[0].Value != "4" || merged.Data[1].Path != "Vehicle.CurrentLocation.Latitude" {
+		t.Errorf("the merged event is %+v", merged)
+	}
+
+	stream = NewSubscribeStream(2, COALESCE)
+	for _, event := range []SubscribeOutput{subscribeEvent("Vehicle.Speed", "1"), subscribeEvent("Vehicle.Speed", "2"),
+		subscribeEvent("Vehicle.CurrentLocation.Latitude", "57.7"), subscribeEvent("Vehicle.Speed", "3"), final} {
+		stream.Send(event)
+	}
+	events = receiveEvents(t, stream)
+	if len(events) != 2 || events[0].Data[0].Dp[0].Value != "1" || events[1].Status != FAILED || len(events[1].Data) != 2 ||
+		events[1].Data[0].Dp[0].Value != "3" || stream.Dropped() != 3 {
+		t.Errorf("COALESCE delivered %+v, dropped %d", events, stream.Dropped())
+	}
+}
+
+func TestStreamBlock(t *testing.T) {
+	stream := NewServiceStream[MoveSeatOutput](1, BLOCK)
+	sent := make(chan bool)
+	go func() {
+		for _, status := range []ProcedureStatus{ONGOING, ONGOING, ONGOING, SUCCESSFUL, ONGOING} {
+			stream.Send(MoveSeatOutput{Status: status})
+		}
+		sent <- true
+	}()
+	select {
+		case <- sent:
+			t.Fatalf("Send did not block on a full buffer")
+		case <- time.After(50 * time.Millisecond):
+	}
+	var statuses []ProcedureStatus
+	for event := range stream.All() {
+		statuses = append(statuses, event.Status)
+	}
+	<- sent
+	if len(statuses) != 4 || statuses[3] != SUCCESSFUL || stream.Dropped() != 0 {
+		t.Errorf("BLOCK delivered %v", statuses)
+	}
+
+	closed := false
+	stream = NewServiceStream[MoveSeatOutput](1, BLOCK)
+	stream.OnClose(func() { closed = true })
+	go func() {
+		for i := 0; i < 10; i++ {
+			stream.Send(MoveSeatOutput{Status: ONGOING})
+		}
+		sent <- true
+	}()
+	for range stream.All() {
+		break
+	}
+	select {
+		case <- sent:
+		case <- time.After(5 * time.Second):
+			t.Fatalf("Send is blocked after the stream was closed")
+	}
+	if !closed {
+		t.Errorf("leaving the loop did not close the stream")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewServiceStream of a type without Status did not panic")
+		}
+	}()
+	NewServiceStream[DataPoint](1, BLOCK)
+}
@@ -0,0 +1,129 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package vapi
+
+/* The vapi package defines the VAPI procedure sets as interfaces that are independent of the connectivity to the vehicle.
+*  A backend, e.g. VapiViss.Backend, implements them on a connectivity solution, and applications that use the interfaces
+*  can swap in other backends, or test doubles. */
+
+type ProcedureStatus int8
+const (
+	ONGOING = 1     // in execution of latest call
+	SUCCESSFUL = 0  // terminated successfully in latest call
+	FAILED = -1      // terminated due to failure in latest call
+)
+
+type ErrorData struct {
+	Code int32
+	Reason string
+	Description string
+}
+
+type VehicleHandle uint32
+
+type GetVehicleOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	VehicleId VehicleHandle
+	Protocol []string
+}
+
+type ConnectOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	LtCredential string
+}
+
+type ServiceSignature struct {
+	Name string
+	Input string
+	Output string
+}
+
+type ServiceInquiryOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Service []ServiceSignature
+}
+
+type GetStCredentialsOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	StCredentials string
+}
+
+type InvokeOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	ServiceOutput string
+	ServiceId uint32
+}
+
+type GetMetadataOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Metadata string
+}
+
+type GeneralOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+}
+
+type DataPoint struct {
+	Value string
+	Timestamp string
+}
+
+type DataContainer struct {
+	Path string
+	Dp []DataPoint
+}
+
+type GetOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Data []DataContainer
+}
+
+type SubscribeOutput struct {
+	Status ProcedureStatus
+	Error *ErrorData
+	Data []DataContainer
+	ServiceId uint32
+}
+
+// ****************** Common procedures ***************
+type Common interface {
+	GetVehicle(vehicleGuid string) GetVehicleOutput
+	ReleaseVehicle(vehicleId VehicleHandle) GeneralOutput
+	Connect(vehicleId VehicleHandle, protocol string, clientCredentials string) ConnectOutput
+	Disconnect(vehicleId VehicleHandle, protocol string) GeneralOutput
+	SelectProtocol(vehicleId VehicleHandle, protocol string) GeneralOutput
+	CancelService(vehicleId VehicleHandle, serviceId uint32) GeneralOutput
+	ServiceInquiry(vehicleId VehicleHandle) ServiceInquiryOutput
+	GetStCredentials(vehicleId VehicleHandle, ltCredentials string, purpose string) GetStCredentialsOutput
+	Invoke(vehicleId VehicleHandle, serviceName string, procedureInput string, stCredentials string, callback func(InvokeOutput)) InvokeOutput
+	GetMetadata(vehicleId VehicleHandle, path string, stCredentials string) GetMetadataOutput
+}
+
+// ****************** Data procedures ***************
+type Data interface {
+	Get(vehicleId VehicleHandle, path string, filter string, stCredentials string) GetOutput
+	Set(vehicleId VehicleHandle, path string, value string, stCredentials string) GeneralOutput
+	Subscribe(vehicleId VehicleHandle, path string, filter string, stCredentials string, callback func(SubscribeOutput)) SubscribeOutput
+	Unsubscribe(vehicleId VehicleHandle, serviceId uint32) GeneralOutput
+}
+
+// Vapi is the complete set of procedures that a backend implements.
+type Vapi interface {
+	Common
+	Data
+	Service
+}
@@ -0,0 +1,27 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"VISS-Go/VapiViss"
+)
+
+// vapiArbiter is the local arbitration daemon that VAPI clients in the same vehicle connect to by VapiViss.DialArbiter.
+func main() {
+	socketPath := flag.String("socket", "/tmp/vapiArbiter.sock", "Unix socket path of the arbiter")
+	flag.Parse()
+	fmt.Printf("vapiArbiter listening on %s\n", *socketPath)
+	err := VapiViss.ServeArbiter(*socketPath, VapiViss.NewLocalArbiter())
+	if err != nil {
+		fmt.Printf("vapiArbiter:error=%s\n", err)
+	}
+}
@@ -0,0 +1,149 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"VISS-Go/vapi"
+)
+
+func runVehicle(sess *session, args []string) {
+	sess.print("GetVehicle", sess.api.GetVehicle(sess.options.vehicleGuid))
+}
+
+func runConnect(sess *session, args []string) {
+	sess.print("Connect", sess.api.Connect(sess.vehicleId, sess.options.protocol, ""))
+}
+
+func runGet(sess *session, args []string) {
+	sess.print("Get", sess.api.Get(sess.vehicleId, args[0], sess.options.filter, sess.options.credentials))
+}
+
+func runSet(sess *session, args []string) {
+	sess.print("Set", sess.api.Set(sess.vehicleId, args[0], args[1], sess.options.credentials))
+}
+
+func runSubscribe(sess *session, args []string) {
+	eventChan := make(chan vapi.SubscribeOutput, 100)
+	subscribeOut := sess.api.Subscribe(sess.vehicleId, args[0], sess.options.filter, sess.options.credentials, func(out vapi.SubscribeOutput) {
+		eventChan <- out
+	})
+	sess.print("Subscribe", subscribeOut)
+	if subscribeOut.Status == vapi.ONGOING {
+		awaitService(sess, "Subscribe", subscribeOut.ServiceId, eventChan, func(out vapi.SubscribeOutput) bool { return out.Status == vapi.FAILED })
+	}
+}
+
+func runMetadata(sess *session, args []string) {
+	sess.print("GetMetadata", sess.api.GetMetadata(sess.vehicleId, args[0], sess.options.credentials))
+}
+
+func runSeat(sess *session, args []string) {
+	switch args[0] {
+		case "properties":
+			sess.print("GetPropertiesSeating", sess.api.GetPropertiesSeating(sess.vehicleId))
+		case "move":
+			if len(args) != 3 {
+				sess.usageError("seat move type position")
+				return
+			}
+			position, err := strconv.ParseFloat(args[2], 32)
+			if err != nil {
+				sess.usageError("seat move type position, position must be a percentage")
+				return
+			}
+			eventChan := make(chan vapi.MoveSeatOutput, 100)
+			moveSeatOut := sess.api.MoveSeat(sess.vehicleId, sess.seatId(), args[1], vapi.Percentage(position), sess.options.credentials, func(out vapi.MoveSeatOutput) {
+				eventChan <- out
+			})
+			sess.print("MoveSeat", moveSeatOut)
+			if moveSeatOut.Status == vapi.ONGOING {
+				awaitService(sess, "MoveSeat", moveSeatOut.ServiceId, eventChan, func(out vapi.MoveSeatOutput) bool { return out.Status != vapi.ONGOING })
+			}
+		case "configure":
+			configuration, ok := parseSeatConfiguration(args[1:])
+			if !ok {
+				sess.usageError("seat configure type=position...")
+				return
+			}
+			eventChan := make(chan vapi.ConfigureSeatOutput, 100)
+			configureSeatOut := sess.api.ConfigureSeat(sess.vehicleId, sess.seatId(), configuration, sess.options.credentials, func(out vapi.ConfigureSeatOutput) {
+				eventChan <- out
+			})
+			sess.print("ConfigureSeat", configureSeatOut)
+			if configureSeatOut.Status == vapi.ONGOING {
+				awaitService(sess, "ConfigureSeat", configureSeatOut.ServiceId, eventChan, func(out vapi.ConfigureSeatOutput) bool { return out.Status != vapi.ONGOING })
+			}
+		case "massage":
+			if len(args) != 4 {
+				sess.usageError("seat massage type intensity duration")
+				return
+			}
+			intensity, err1 := strconv.ParseFloat(args[2], 32)
+			duration, err2 := strconv.ParseUint(args[3], 10, 32)
+			if err1 != nil || err2 != nil {
+				sess.usageError("seat massage type intensity duration, intensity must be a percentage and duration in seconds")
+				return
+			}
+			eventChan := make(chan vapi.MassageOutput, 100)
+			massageOut := sess.api.ActivateMassage(sess.vehicleId, sess.seatId(), args[1], vapi.Percentage(intensity), uint32(duration), sess.options.credentials, func(out vapi.MassageOutput) {
+				eventChan <- out
+			})
+			sess.print("ActivateMassage", massageOut)
+			if massageOut.Status == vapi.ONGOING {
+				awaitService(sess, "ActivateMassage", massageOut.ServiceId, eventChan, func(out vapi.MassageOutput) bool { return out.Status != vapi.ONGOING })
+			}
+		default:
+			sess.usageError("seat properties | move type position | configure type=position... | massage type intensity duration")
+	}
+}
+
+func parseSeatConfiguration(args []string) ([]vapi.SeatConfig, bool) {
+	if len(args) == 0 {
+		return nil, false
+	}
+	configuration := make([]vapi.SeatConfig, len(args))
+	for i := 0; i < len(args); i++ {
+		movementType, position, found := strings.Cut(args[i], "=")
+		value, err := strconv.ParseFloat(position, 32)
+		if !found || err != nil {
+			return nil, false
+		}
+		configuration[i].MovementType = movementType
+		configuration[i].Position = vapi.Percentage(value)
+	}
+	return configuration, true
+}
+
+func runHvac(sess *session, args []string) {
+	sess.print("HvacService1", sess.api.HvacService1(sess.vehicleId))
+}
+
+func runInvoke(sess *session, args []string) {
+	procedureInput := ""
+	if len(args) > 1 {
+		procedureInput = args[1]
+	}
+	eventChan := make(chan vapi.InvokeOutput, 100)
+	invokeOut := sess.api.Invoke(sess.vehicleId, args[0], procedureInput, sess.options.credentials, func(out vapi.InvokeOutput) {
+		eventChan <- out
+	})
+	sess.print("Invoke", invokeOut)
+	if invokeOut.Status == vapi.ONGOING {
+		awaitService(sess, "Invoke", invokeOut.ServiceId, eventChan, func(out vapi.InvokeOutput) bool { return out.Status != vapi.ONGOING })
+	}
+}
+
+func (sess *session) usageError(usage string) {
+	fmt.Fprintf(sess.out, "usage: vapi %s\n", usage)
+	sess.setStatus(vapi.FAILED)
+}
@@ -0,0 +1,147 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"VISS-Go/vapi"
+)
+
+/* print writes the output of a procedure call or callback, in the text format one line per item,
+*  and in the json format one line per output: {"procedure":"Get", "output":{"Status":0, "Error":null, ...}} */
+func (sess *session) print(procedure string, output interface{}) {
+	status, errorData := getStatus(output)
+	sess.setStatus(status)
+	var buf bytes.Buffer // written at once, as callbacks may print concurrently
+	if sess.options.output == "json" {
+		data, err := json.Marshal(map[string]interface{}{"procedure": procedure, "output": output})
+		if err != nil {
+			fmt.Fprintf(sess.out, "vapi: output error=%s\n", err)
+			return
+		}
+		fmt.Fprintf(&buf, "%s\n", data)
+	} else {
+		fmt.Fprintf(&buf, "%s: status=%s\n", procedure, translateStatus(status))
+		if errorData != nil {
+			fmt.Fprintf(&buf, "  Error code=%d reason=%s description=%s\n", errorData.Code, errorData.Reason, errorData.Description)
+		}
+		printText(&buf, output)
+	}
+	sess.out.Write(buf.Bytes())
+}
+
+func (sess *session) setStatus(status vapi.ProcedureStatus) {
+	sess.statusMutex.Lock()
+	sess.status = status
+	sess.statusMutex.Unlock()
+}
+
+func (sess *session) getStatus() vapi.ProcedureStatus {
+	sess.statusMutex.Lock()
+	defer sess.statusMutex.Unlock()
+	return sess.status
+}
+
+func getStatus(output interface{}) (vapi.ProcedureStatus, *vapi.ErrorData) {
+	switch out := output.(type) {
+		case vapi.GetVehicleOutput: return out.Status, out.Error
+		case vapi.ConnectOutput: return out.Status, out.Error
+		case vapi.GeneralOutput: return out.Status, out.Error
+		case vapi.GetOutput: return out.Status, out.Error
+		case vapi.SubscribeOutput: return out.Status, out.Error
+		case vapi.GetMetadataOutput: return out.Status, out.Error
+		case vapi.GetPropertiesSeatingOutput: return out.Status, out.Error
+		case vapi.MoveSeatOutput: return out.Status, out.Error
+		case vapi.ConfigureSeatOutput: return out.Status, out.Error
+		case vapi.MassageOutput: return out.Status, out.Error
+		case vapi.SeatClimateOutput: return out.Status, out.Error
+		case vapi.InvokeOutput: return out.Status, out.Error
+	}
+	return vapi.FAILED, nil
+}
+
+func printText(w io.Writer, output interface{}) {
+	switch out := output.(type) {
+		case vapi.GetVehicleOutput:
+			fmt.Fprintf(w, "  VehicleId=%d\n  Protocols=%v\n", out.VehicleId, out.Protocol)
+		case vapi.ConnectOutput:
+			if out.LtCredential != "" {
+				fmt.Fprintf(w, "  LtCredential=%s\n", out.LtCredential)
+			}
+		case vapi.GetOutput:
+			printData(w, out.Data)
+		case vapi.SubscribeOutput:
+			if out.ServiceId != 0 {
+				fmt.Fprintf(w, "  ServiceId=%d\n", out.ServiceId)
+			}
+			printData(w, out.Data)
+		case vapi.GetMetadataOutput:
+			if out.Metadata != "" {
+				fmt.Fprintf(w, "  Metadata=%s\n", out.Metadata)
+			}
+		case vapi.GetPropertiesSeatingOutput:
+			for i := 0; i < len(out.Properties); i++ {
+				for j := 0; j < len(out.Properties[i].Column); j++ {
+					column := out.Properties[i].Column[j]
+					fmt.Fprintf(w, "  Seat Id=%s, %s\n", out.Properties[i].RowName, column.Name)
+					fmt.Fprintf(w, "    Movement support=%s\n", getSupportNames(column.MovementSupport))
+					fmt.Fprintf(w, "    Massage support=%s\n", getSupportNames(column.MassageSupport))
+					fmt.Fprintf(w, "    Climate support=%s\n", getSupportNames(column.ClimateSupport))
+				}
+			}
+		case vapi.MoveSeatOutput:
+			fmt.Fprintf(w, "  ServiceId=%d Position=%.1f\n", out.ServiceId, out.Position)
+		case vapi.ConfigureSeatOutput:
+			fmt.Fprintf(w, "  ServiceId=%d\n", out.ServiceId)
+			for i := 0; i < len(out.Configured); i++ {
+				fmt.Fprintf(w, "  Configured=%s Position=%.1f\n", out.Configured[i].MovementType, out.Configured[i].Position)
+			}
+			for i := 0; i < len(out.Unconfigured); i++ {
+				fmt.Fprintf(w, "  Unconfigured=%s\n", out.Unconfigured[i])
+			}
+		case vapi.MassageOutput:
+			fmt.Fprintf(w, "  ServiceId=%d\n", out.ServiceId)
+		case vapi.SeatClimateOutput:
+			fmt.Fprintf(w, "  ServiceId=%d\n", out.ServiceId)
+		case vapi.InvokeOutput:
+			if out.ServiceOutput != "" {
+				fmt.Fprintf(w, "  ServiceId=%d ServiceOutput=%s\n", out.ServiceId, out.ServiceOutput)
+			}
+	}
+}
+
+func printData(w io.Writer, data []vapi.DataContainer) {
+	for i := 0; i < len(data); i++ {
+		fmt.Fprintf(w, "  Path=%s\n", data[i].Path)
+		for j := 0; j < len(data[i].Dp); j++ {
+			fmt.Fprintf(w, "    Value=%s Ts=%s\n", data[i].Dp[j].Value, data[i].Dp[j].Timestamp)
+		}
+	}
+}
+
+func getSupportNames(support []vapi.SupportData) []string {
+	names := make([]string, len(support))
+	for i := 0; i < len(support); i++ {
+		names[i] = support[i].Name
+	}
+	return names
+}
+
+func translateStatus(status vapi.ProcedureStatus) string {
+	switch status {
+		case vapi.SUCCESSFUL: return "SUCCESSFUL"
+		case vapi.ONGOING: return "ONGOING"
+		case vapi.FAILED: return "FAILED"
+	}
+	return "UNKNOWN"
+}
@@ -0,0 +1,514 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"VISS-Go/VapiViss"
+	"VISS-Go/vapi"
+	"gopkg.in/yaml.v3"
+)
+
+// ****************** Scenarios ***************
+/* A scenario is a YAML or JSON file with a list of steps that are executed in order on the connected vehicle, e.g.
+*  name: Seat demo
+*  steps:
+*    - call: Set
+*      path: Vehicle.Cabin.Seat.Row1.DriverSide.Position
+*      value: "2"
+*    - call: MoveSeat
+*      movementType: longitudinal
+*      position: 50
+*      await: true          # wait for the final callback, expect then applies to it
+*      expect: {status: SUCCESSFUL}
+*    - call: Subscribe
+*      path: Vehicle.Speed
+*      filter: {variant: timebased, parameter: {period: "1000"}}
+*      id: speed            # names the service for waits and cancellation
+*    - wait: {service: speed, events: 3, timeout: 10s}
+*    - call: CancelService
+*      service: speed
+*    - wait: {condition: {path: Vehicle.Speed, operator: lt, value: "10"}, timeout: 1m}
+*    - sleep: 2s
+*  A step executes its sleep, call, expect, wait, and check parts in that order. The scenario stops at the first failed step. */
+type scenario struct {
+	Name string `json:"name" yaml:"name"`
+	Steps []scenarioStep `json:"steps" yaml:"steps"`
+}
+
+type scenarioStep struct {
+	Name string `json:"name" yaml:"name"`
+	Sleep string `json:"sleep" yaml:"sleep"`
+	Call string `json:"call" yaml:"call"`  // name of the VAPI procedure
+	Path string `json:"path" yaml:"path"`
+	Value string `json:"value" yaml:"value"`
+	Filter interface{} `json:"filter" yaml:"filter"`  // a JSON string, or a filter object
+	Row string `json:"row" yaml:"row"`
+	Column string `json:"column" yaml:"column"`
+	MovementType string `json:"movementType" yaml:"movementType"`
+	Position float32 `json:"position" yaml:"position"`
+	Configuration []seatConfigSpec `json:"configuration" yaml:"configuration"`
+	MassageType string `json:"massageType" yaml:"massageType"`
+	Intensity float32 `json:"intensity" yaml:"intensity"`
+	Level float32 `json:"level" yaml:"level"`
+	Duration uint32 `json:"duration" yaml:"duration"`  // seconds
+	ServiceName string `json:"serviceName" yaml:"serviceName"`
+	Input string `json:"input" yaml:"input"`
+	Protocol string `json:"protocol" yaml:"protocol"`
+	Service string `json:"service" yaml:"service"`  // id of a service started by a previous step
+	Id string `json:"id" yaml:"id"`
+	Await bool `json:"await" yaml:"await"`
+	Timeout string `json:"timeout" yaml:"timeout"`  // of await, default 30s
+	Expect *expectation `json:"expect" yaml:"expect"`
+	Wait *waitSpec `json:"wait" yaml:"wait"`
+	Check []conditionSpec `json:"check" yaml:"check"`
+}
+
+type seatConfigSpec struct {
+	MovementType string `json:"movementType" yaml:"movementType"`
+	Position float32 `json:"position" yaml:"position"`
+}
+
+type conditionSpec struct {
+	Path string `json:"path" yaml:"path"`
+	Operator string `json:"operator" yaml:"operator"`  // one of the VISS logic-op values eq, ne, gt, gte, lt, lte
+	Value string `json:"value" yaml:"value"`
+}
+
+type expectation struct {
+	Status string `json:"status" yaml:"status"`  // SUCCESSFUL, ONGOING, or FAILED
+	ErrorCode int32 `json:"errorCode" yaml:"errorCode"`
+	Value string `json:"value" yaml:"value"`  // the first value of the output data
+	Values map[string]string `json:"values" yaml:"values"`  // path -> value
+	Position *float32 `json:"position" yaml:"position"`
+}
+
+type waitSpec struct {
+	Service string `json:"service" yaml:"service"`
+	Events int `json:"events" yaml:"events"`  // number of callbacks of the service, default 1
+	Status string `json:"status" yaml:"status"`  // a callback of the service with this status
+	Condition *conditionSpec `json:"condition" yaml:"condition"`  // polled by Get
+	Interval string `json:"interval" yaml:"interval"`  // of the condition polling, default 500ms
+	Timeout string `json:"timeout" yaml:"timeout"`  // default 30s
+}
+
+// stepResult is the procedure independent part of an output or callback that the expectations are evaluated on.
+type stepResult struct {
+	status vapi.ProcedureStatus
+	errorData *vapi.ErrorData
+	values []vapi.DataContainer
+	position *float32
+	serviceId uint32
+}
+
+type scenarioService struct {
+	serviceId uint32
+	mutex sync.Mutex
+	results []stepResult
+	notifyChan chan struct{}  // closed and replaced when a result is added
+}
+
+type scenarioRunner struct {
+	sess *session
+	services map[string]*scenarioService
+}
+
+func runScenario(sess *session, args []string) {
+	scen, err := readScenario(args[0])
+	if err != nil {
+		fmt.Fprintf(sess.out, "Scenario %s could not be read: %s\n", args[0], err)
+		sess.setStatus(vapi.FAILED)
+		return
+	}
+	runner := scenarioRunner{sess, make(map[string]*scenarioService)}
+	name := scen.Name
+	if name == "" {
+		name = args[0]
+	}
+	for i := 0; i < len(scen.Steps); i++ {
+		fmt.Fprintf(sess.out, "Step %d: %s\n", i+1, getStepName(scen.Steps[i]))
+		err = runner.runStep(scen.Steps[i])
+		if err != nil {
+			fmt.Fprintf(sess.out, "Step %d FAILED: %s\nScenario %s: FAILED\n", i+1, err, name)
+			sess.setStatus(vapi.FAILED)
+			return
+		}
+	}
+	fmt.Fprintf(sess.out, "Scenario %s: PASSED, %d steps\n", name, len(scen.Steps))
+	sess.setStatus(vapi.SUCCESSFUL)
+}
+
+func readScenario(fileName string) (*scenario, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var scen scenario
+	if strings.HasSuffix(fileName, ".json") {
+		err = json.Unmarshal(data, &scen)
+	} else {
+		err = yaml.Unmarshal(data, &scen)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scen, nil
+}
+
+func getStepName(step scenarioStep) string {
+	if step.Name != "" {
+		return step.Name
+	}
+	var parts []string
+	if step.Sleep != "" {
+		parts = append(parts, "sleep " + step.Sleep)
+	}
+	if step.Call != "" {
+		parts = append(parts, strings.TrimSpace(step.Call + " " + step.Path))
+	}
+	if step.Wait != nil {
+		parts = append(parts, "wait")
+	}
+	if len(step.Check) > 0 {
+		parts = append(parts, "check")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (runner *scenarioRunner) runStep(step scenarioStep) error {
+	if step.Sleep != "" {
+		sleep, err := time.ParseDuration(step.Sleep)
+		if err != nil {
+			return fmt.Errorf("invalid sleep %s", step.Sleep)
+		}
+		time.Sleep(sleep)
+	}
+	if step.Call != "" {
+		result, service, err := runner.call(step)
+		if err != nil {
+			return err
+		}
+		if step.Await {
+			if service == nil || result.status != vapi.ONGOING {
+				return fmt.Errorf("%s is not an ongoing service, status=%s", step.Call, translateStatus(result.status))
+			}
+			timeout, err := parseDuration(step.Timeout, 30 * time.Second)
+			if err != nil {
+				return err
+			}
+			result, err = service.await(func(results []stepResult) (stepResult, bool) {
+				for i := 0; i < len(results); i++ {
+					if results[i].status != vapi.ONGOING {
+						return results[i], true
+					}
+				}
+				return stepResult{}, false
+			}, timeout)
+			if err != nil {
+				return err
+			}
+		}
+		if step.Expect != nil {
+			err = step.Expect.evaluate(result)
+			if err != nil {
+				return err
+			}
+		}
+	} else if step.Expect != nil {
+		return fmt.Errorf("expect without call")
+	}
+	if step.Wait != nil {
+		err := runner.wait(*step.Wait)
+		if err != nil {
+			return err
+		}
+	}
+	if len(step.Check) > 0 {
+		errorData := VapiViss.CheckPreconditions(runner.sess.api, runner.sess.vehicleId, getPreconditions(step.Check), runner.sess.options.credentials)
+		if errorData != nil {
+			return fmt.Errorf("check: %s", errorData.Description)
+		}
+	}
+	return nil
+}
+
+func (runner *scenarioRunner) call(step scenarioStep) (stepResult, *scenarioService, error) {
+	sess := runner.sess
+	vehicleId := sess.vehicleId
+	credentials := sess.options.credentials
+	seatId := sess.seatId()
+	if step.Row != "" {
+		seatId.RowName = step.Row
+	}
+	if step.Column != "" {
+		seatId.ColumnName = step.Column
+	}
+	filter, err := getFilterString(step.Filter)
+	if err != nil {
+		return stepResult{}, nil, err
+	}
+	service := newScenarioService()
+	var output interface{}
+	switch step.Call {
+		case "Get":
+			output = sess.api.Get(vehicleId, step.Path, filter, credentials)
+		case "Set":
+			output = sess.api.Set(vehicleId, step.Path, step.Value, credentials)
+		case "GetMetadata":
+			output = sess.api.GetMetadata(vehicleId, step.Path, credentials)
+		case "SelectProtocol":
+			output = sess.api.SelectProtocol(vehicleId, step.Protocol)
+		case "Subscribe":
+			output = sess.api.Subscribe(vehicleId, step.Path, filter, credentials, getCallback[vapi.SubscribeOutput](sess, service, step.Call))
+		case "Unsubscribe", "CancelService":
+			target := runner.services[step.Service]
+			if target == nil {
+				return stepResult{}, nil, fmt.Errorf("unknown service %s", step.Service)
+			}
+			if step.Call == "Unsubscribe" {
+				output = sess.api.Unsubscribe(vehicleId, target.serviceId)
+			} else {
+				output = sess.api.CancelService(vehicleId, target.serviceId)
+			}
+		case "GetPropertiesSeating":
+			output = sess.api.GetPropertiesSeating(vehicleId)
+		case "MoveSeat":
+			output = sess.api.MoveSeat(vehicleId, seatId, step.MovementType, vapi.Percentage(step.Position), credentials, getCallback[vapi.MoveSeatOutput](sess, service, step.Call))
+		case "ConfigureSeat":
+			configuration := make([]vapi.SeatConfig, len(step.Configuration))
+			for i := 0; i < len(step.Configuration); i++ {
+				configuration[i] = vapi.SeatConfig{MovementType: step.Configuration[i].MovementType, Position: vapi.Percentage(step.Configuration[i].Position)}
+			}
+			output = sess.api.ConfigureSeat(vehicleId, seatId, configuration, credentials, getCallback[vapi.ConfigureSeatOutput](sess, service, step.Call))
+		case "ActivateMassage":
+			output = sess.api.ActivateMassage(vehicleId, seatId, step.MassageType, vapi.Percentage(step.Intensity), step.Duration, credentials, getCallback[vapi.MassageOutput](sess, service, step.Call))
+		case "ActivateSeatHeating":
+			output = sess.api.ActivateSeatHeating(vehicleId, seatId, vapi.Percentage(step.Level), step.Duration, credentials, getCallback[vapi.SeatClimateOutput](sess, service, step.Call))
+		case "ActivateSeatVentilation":
+			output = sess.api.ActivateSeatVentilation(vehicleId, seatId, vapi.Percentage(step.Level), step.Duration, credentials, getCallback[vapi.SeatClimateOutput](sess, service, step.Call))
+		case "HvacService1":
+			output = sess.api.HvacService1(vehicleId)
+		case "Invoke":
+			output = sess.api.Invoke(vehicleId, step.ServiceName, step.Input, credentials, getCallback[vapi.InvokeOutput](sess, service, step.Call))
+		default:
+			return stepResult{}, nil, fmt.Errorf("unknown call %s", step.Call)
+	}
+	sess.print(step.Call, output)
+	result := getStepResult(output)
+	if result.serviceId == 0 {
+		return result, nil, nil
+	}
+	service.mutex.Lock()
+	service.serviceId = result.serviceId
+	service.mutex.Unlock()
+	if step.Id != "" {
+		runner.services[step.Id] = service
+	}
+	return result, service, nil
+}
+
+func getCallback[T any](sess *session, service *scenarioService, procedure string) func(T) {
+	return func(out T) {
+		sess.print(procedure, out)
+		service.add(getStepResult(out))
+	}
+}
+
+func getFilterString(filter interface{}) (string, error) {
+	switch value := filter.(type) {
+		case nil:
+			return "", nil
+		case string:
+			return value, nil
+	}
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return "", fmt.Errorf("invalid filter: %s", err)
+	}
+	return string(data), nil
+}
+
+func getStepResult(output interface{}) stepResult {
+	var result stepResult
+	result.status, result.errorData = getStatus(output)
+	switch out := output.(type) {
+		case vapi.GetOutput:
+			result.values = out.Data
+		case vapi.SubscribeOutput:
+			result.values = out.Data
+			result.serviceId = out.ServiceId
+		case vapi.MoveSeatOutput:
+			position := float32(out.Position)
+			result.position = &position
+			result.serviceId = out.ServiceId
+		case vapi.ConfigureSeatOutput:
+			result.serviceId = out.ServiceId
+		case vapi.MassageOutput:
+			result.serviceId = out.ServiceId
+		case vapi.SeatClimateOutput:
+			result.serviceId = out.ServiceId
+		case vapi.InvokeOutput:
+			result.serviceId = out.ServiceId
+	}
+	return result
+}
+
+func (expect *expectation) evaluate(result stepResult) error {
+	if expect.Status != "" && expect.Status != translateStatus(result.status) {
+		return fmt.Errorf("status=%s, expected %s%s", translateStatus(result.status), expect.Status, getErrorText(result.errorData))
+	}
+	if expect.ErrorCode != 0 && (result.errorData == nil || result.errorData.Code != expect.ErrorCode) {
+		return fmt.Errorf("expected error code %d%s", expect.ErrorCode, getErrorText(result.errorData))
+	}
+	if expect.Value != "" {
+		if len(result.values) == 0 || len(result.values[0].Dp) == 0 {
+			return fmt.Errorf("no value, expected %s", expect.Value)
+		}
+		if result.values[0].Dp[0].Value != expect.Value {
+			return fmt.Errorf("value=%s, expected %s", result.values[0].Dp[0].Value, expect.Value)
+		}
+	}
+	for path, expectedValue := range expect.Values {
+		value, ok := getResultValue(result.values, path)
+		if !ok {
+			return fmt.Errorf("no value of %s, expected %s", path, expectedValue)
+		}
+		if value != expectedValue {
+			return fmt.Errorf("value of %s=%s, expected %s", path, value, expectedValue)
+		}
+	}
+	if expect.Position != nil {
+		if result.position == nil {
+			return fmt.Errorf("no position, expected %.1f", *expect.Position)
+		}
+		if *result.position != *expect.Position {
+			return fmt.Errorf("position=%.1f, expected %.1f", *result.position, *expect.Position)
+		}
+	}
+	return nil
+}
+
+func getErrorText(errorData *vapi.ErrorData) string {
+	if errorData == nil {
+		return ""
+	}
+	return fmt.Sprintf(", error=%d %s", errorData.Code, errorData.Description)
+}
+
+func getResultValue(data []vapi.DataContainer, path string) (string, bool) {
+	for i := 0; i < len(data); i++ {
+		if data[i].Path == path && len(data[i].Dp) > 0 {
+			return data[i].Dp[0].Value, true
+		}
+	}
+	return "", false
+}
+
+func (runner *scenarioRunner) wait(wait waitSpec) error {
+	timeout, err := parseDuration(wait.Timeout, 30 * time.Second)
+	if err != nil {
+		return err
+	}
+	if wait.Condition != nil {
+		interval, err := parseDuration(wait.Interval, 500 * time.Millisecond)
+		if err != nil {
+			return err
+		}
+		preconditions := getPreconditions([]conditionSpec{*wait.Condition})
+		deadline := time.Now().Add(timeout)
+		for {
+			errorData := VapiViss.CheckPreconditions(runner.sess.api, runner.sess.vehicleId, preconditions, runner.sess.options.credentials)
+			if errorData == nil {
+				return nil
+			}
+			if time.Now().Add(interval).After(deadline) {
+				return fmt.Errorf("timeout waiting for %s", errorData.Description)
+			}
+			time.Sleep(interval)
+		}
+	}
+	service := runner.services[wait.Service]
+	if service == nil {
+		return fmt.Errorf("unknown service %s", wait.Service)
+	}
+	events := wait.Events
+	if events == 0 {
+		events = 1
+	}
+	_, err = service.await(func(results []stepResult) (stepResult, bool) {
+		if wait.Status == "" {
+			return stepResult{}, len(results) >= events
+		}
+		for i := 0; i < len(results); i++ {
+			if translateStatus(results[i].status) == wait.Status {
+				return results[i], true
+			}
+		}
+		return stepResult{}, false
+	}, timeout)
+	return err
+}
+
+func getPreconditions(conditions []conditionSpec) []VapiViss.Precondition {
+	preconditions := make([]VapiViss.Precondition, len(conditions))
+	for i := 0; i < len(conditions); i++ {
+		preconditions[i] = VapiViss.Precondition{Path: conditions[i].Path, Operator: conditions[i].Operator, Value: conditions[i].Value, Description: "condition not met"}
+	}
+	return preconditions
+}
+
+func parseDuration(duration string, defaultDuration time.Duration) (time.Duration, error) {
+	if duration == "" {
+		return defaultDuration, nil
+	}
+	parsed, err := time.ParseDuration(duration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %s", duration)
+	}
+	return parsed, nil
+}
+
+func newScenarioService() *scenarioService {
+	var service scenarioService
+	service.notifyChan = make(chan struct{})
+	return &service
+}
+
+func (service *scenarioService) add(result stepResult) {
+	service.mutex.Lock()
+	service.results = append(service.results, result)
+	close(service.notifyChan)
+	service.notifyChan = make(chan struct{})
+	service.mutex.Unlock()
+}
+
+// await waits until isDone returns true for the callbacks of the service.
+func (service *scenarioService) await(isDone func([]stepResult) (stepResult, bool), timeout time.Duration) (stepResult, error) {
+	timeoutChan := time.After(timeout)
+	for {
+		service.mutex.Lock()
+		result, done := isDone(service.results)
+		notifyChan := service.notifyChan
+		service.mutex.Unlock()
+		if done {
+			return result, nil
+		}
+		select {
+			case <- notifyChan:
+			case <- timeoutChan:
+				return stepResult{}, fmt.Errorf("timeout waiting for callbacks of service %d", service.serviceId)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeScenario(t *testing.T, fileName string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), fileName)
+	err := os.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	return path
+}
+
+func TestScenarioYaml(t *testing.T) {
+	_, vehicleGuid := startMockVehicle(t)
+	scenarioFile := writeScenario(t, "scenario.yaml", `
+name: yaml test
+steps:
+  - call: Set
+    path: Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType
+    value: wave
+  - call: Get
+    path: Vehicle.Cabin.Seat.Row1.DriverSide
+    filter: {variant: paths, parameter: [Switch.Massage.MassageType]}
+    expect:
+      status: SUCCESSFUL
+      values: {Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType: wave}
+  - call: Get
+    path: Vehicle.No.Such.Path
+    expect: {status: FAILED, errorCode: 404}
+  - call: Subscribe
+    path: Vehicle.Speed
+    filter: '{"variant":"timebased","parameter":{"period":"100"}}'
+    id: speed
+    expect: {status: ONGOING}
+  - wait: {service: speed, events: 2, timeout: 5s}
+  - call: CancelService
+    service: speed
+  - call: MoveSeat
+    movementType: longitudinal
+    position: 50
+    await: true
+    expect: {status: SUCCESSFUL, position: 50}
+  - wait: {condition: {path: Vehicle.Cabin.Seat.Row1.DriverSide.Position, operator: eq, value: "150"}, interval: 10ms, timeout: 2s}
+    check: [{path: Vehicle.Speed, operator: eq, value: "0"}]
+`)
+	exitCode, out := runCommand("run", "-vin", vehicleGuid, scenarioFile)
+	if exitCode != 0 || !strings.Contains(out, "Scenario yaml test: PASSED, 8 steps") {
+		t.Errorf("exitCode=%d, output=%s", exitCode, out)
+	}
+}
+
+func TestScenarioJson(t *testing.T) {
+	server, vehicleGuid := startMockVehicle(t)
+	server.SetValue("Vehicle.Speed", "25")
+	scenarioFile := writeScenario(t, "scenario.json", `{"name":"json test", "steps":[
+		{"call":"Get", "path":"Vehicle.Speed", "expect":{"value":"25"}},
+		{"name":"wrong value", "call":"Get", "path":"Vehicle.Speed", "expect":{"value":"30"}},
+		{"call":"Get", "path":"Vehicle.Speed"}]}`)
+	exitCode, out := runCommand("run", "-vin", vehicleGuid, scenarioFile)
+	if exitCode != 1 || !strings.Contains(out, "Step 2 FAILED: value=25, expected 30") || strings.Contains(out, "Step 3") {
+		t.Errorf("exitCode=%d, output=%s", exitCode, out)
+	}
+}
+
+func TestScenarioErrors(t *testing.T) {
+	_, vehicleGuid := startMockVehicle(t)
+	tests := []struct {
+		content string
+		message string
+	}{
+		{`steps: [{call: NoSuchProcedure}]`, "unknown call NoSuchProcedure"},
+		{`steps: [{call: CancelService, service: unknown}]`, "unknown service unknown"},
+		{`steps: [{wait: {service: unknown}}]`, "unknown service unknown"},
+		{`steps: [{sleep: forever}]`, "invalid sleep forever"},
+		{`steps: [{call: Get, path: Vehicle.Speed, await: true}]`, "Get is not an ongoing service"},
+		{`steps: [{wait: {condition: {path: Vehicle.Speed, operator: gt, value: "1000"}, interval: 10ms, timeout: 50ms}}]`, "timeout waiting for condition not met"},
+		{`steps: [{expect: {status: SUCCESSFUL}}]`, "expect without call"},
+		{`steps: [`, "could not be read"},
+	}
+	for _, test := range tests {
+		scenarioFile := writeScenario(t, "scenario.yaml", test.content)
+		exitCode, out := runCommand("run", "-vin", vehicleGuid, scenarioFile)
+		if exitCode != 1 || !strings.Contains(out, test.message) {
+			t.Errorf("scenario %s: exitCode=%d, output=%s", test.content, exitCode, out)
+		}
+	}
+}
+
+func TestReadDemoScenario(t *testing.T) {
+	scen, err := readScenario("scenarios/demo.yaml")
+	if err != nil || len(scen.Steps) == 0 {
+		t.Fatalf("readScenario: %v", err)
+	}
+	filter, err := getFilterString(scen.Steps[1].Filter)
+	if err != nil || !strings.HasPrefix(filter, `[{"parameter":["Latitude","Longitude"],"variant":"paths"}`) {
+		t.Errorf("filter=%s, err=%v", filter, err)
+	}
+}
@@ -0,0 +1,351 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"VISS-Go/vapi"
+	"golang.org/x/term"
+)
+
+// ****************** Interactive shell ***************
+/* The shell executes one command per input line on the vehicle connection of the session.
+*  Subscription events are printed when they arrive, and on a terminal the VSS paths are completed by the Tab key
+*  from the metadata of the Vehicle tree, which is read when the shell starts. */
+type shell struct {
+	sess *session
+	mutex sync.Mutex
+	services map[uint32]string  // active services started in the shell, serviceId -> command line
+	connected map[string]bool   // protocols connected in the shell
+	paths []string  // sorted VSS paths, branches included
+}
+
+type shellCommand struct {
+	name string
+	arguments string
+	description string
+}
+
+var shellCommands = []shellCommand{
+	{"get", "path [filter]", "read the value(s) of the path, optionally filtered"},
+	{"set", "path value", "write the value to the path"},
+	{"subscribe", "path [filter]", "subscribe to the path, the events are printed when they arrive"},
+	{"metadata", "path", "show the metadata of the path"},
+	{"services", "", "list the active services"},
+	{"cancel", "serviceId", "cancel an active service"},
+	{"protocol", "[protocol]", "show the protocols, or connect and select a protocol"},
+	{"help", "", "show the commands"},
+	{"exit", "", "leave the shell"},
+}
+
+func runShell(sess *session, args []string) {
+	sh := newShell(sess)
+	sh.loadPaths()
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		oldState, err := term.MakeRaw(fd)
+		if err == nil {
+			sh.runTerminal()
+			term.Restore(fd, oldState)
+			sh.disconnect()
+			return
+		}
+	}
+	sh.runLines(os.Stdin)
+	sh.disconnect()
+}
+
+func newShell(sess *session) *shell {
+	var sh shell
+	sh.sess = sess
+	sh.services = make(map[uint32]string)
+	sh.connected = map[string]bool{sess.options.protocol: true}
+	return &sh
+}
+
+func (sh *shell) runTerminal() {
+	stdout := os.Stdout
+	terminal := term.NewTerminal(struct{io.Reader; io.Writer}{os.Stdin, stdout}, "vapi> ")
+	terminal.AutoCompleteCallback = sh.autoComplete
+	sh.sess.out = terminal
+	// the VapiViss logs are written to os.Stdout, which is redirected to the terminal to keep the prompt and line endings intact
+	reader, writer, err := os.Pipe()
+	if err == nil {
+		os.Stdout = writer
+		go io.Copy(terminal, reader)
+		defer func() {
+			os.Stdout = stdout
+			writer.Close()
+		}()
+	}
+	for {
+		line, err := terminal.ReadLine()
+		if err != nil || !sh.execute(line) {
+			return
+		}
+	}
+}
+
+func (sh *shell) runLines(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if !sh.execute(scanner.Text()) {
+			return
+		}
+	}
+}
+
+// execute executes one command line, and returns false if the shell shall be left.
+func (sh *shell) execute(line string) bool {
+	fields := splitCommandLine(line, 3)
+	if len(fields) == 0 {
+		return true
+	}
+	sess := sh.sess
+	switch fields[0] {
+		case "get":
+			if len(fields) < 2 {
+				sh.usage("get")
+				return true
+			}
+			sess.print("Get", sess.api.Get(sess.vehicleId, fields[1], getField(fields, 2), sess.options.credentials))
+		case "set":
+			if len(fields) < 3 {
+				sh.usage("set")
+				return true
+			}
+			sess.print("Set", sess.api.Set(sess.vehicleId, fields[1], fields[2], sess.options.credentials))
+		case "subscribe":
+			if len(fields) < 2 {
+				sh.usage("subscribe")
+				return true
+			}
+			sh.subscribe(fields[1], getField(fields, 2), strings.TrimSpace(line))
+		case "metadata":
+			if len(fields) < 2 {
+				sh.usage("metadata")
+				return true
+			}
+			sess.print("GetMetadata", sess.api.GetMetadata(sess.vehicleId, fields[1], sess.options.credentials))
+		case "services":
+			sh.listServices()
+		case "cancel":
+			serviceId, err := strconv.ParseUint(getField(fields, 1), 10, 32)
+			if err != nil {
+				sh.usage("cancel")
+				return true
+			}
+			sess.print("CancelService", sess.api.CancelService(sess.vehicleId, uint32(serviceId)))
+			sh.removeService(uint32(serviceId))
+		case "protocol":
+			sh.selectProtocol(getField(fields, 1))
+		case "help":
+			for i := 0; i < len(shellCommands); i++ {
+				fmt.Fprintf(sess.out, "  %s %s\n      %s\n", shellCommands[i].name, shellCommands[i].arguments, shellCommands[i].description)
+			}
+		case "exit", "quit":
+			return false
+		default:
+			fmt.Fprintf(sess.out, "unknown command %s, help shows the commands\n", fields[0])
+	}
+	return true
+}
+
+// splitCommandLine splits the line in at most n fields separated by white space, where the last field is the rest of the line, e.g. a JSON filter.
+func splitCommandLine(line string, n int) []string {
+	var fields []string
+	line = strings.TrimSpace(line)
+	for line != "" {
+		if len(fields) == n-1 {
+			return append(fields, line)
+		}
+		index := strings.IndexAny(line, " \t")
+		if index < 0 {
+			return append(fields, line)
+		}
+		fields = append(fields, line[:index])
+		line = strings.TrimSpace(line[index:])
+	}
+	return fields
+}
+
+func getField(fields []string, index int) string {
+	if index < len(fields) {
+		return fields[index]
+	}
+	return ""
+}
+
+func (sh *shell) usage(name string) {
+	for i := 0; i < len(shellCommands); i++ {
+		if shellCommands[i].name == name {
+			fmt.Fprintf(sh.sess.out, "usage: %s %s\n", name, shellCommands[i].arguments)
+		}
+	}
+}
+
+func (sh *shell) subscribe(path string, filter string, commandLine string) {
+	sess := sh.sess
+	subscribeOut := sess.api.Subscribe(sess.vehicleId, path, filter, sess.options.credentials, func(out vapi.SubscribeOutput) {
+		sess.print("Subscribe", out)
+		if out.Status == vapi.FAILED {
+			sh.removeService(out.ServiceId)
+		}
+	})
+	if subscribeOut.Status == vapi.ONGOING {
+		sh.mutex.Lock()
+		sh.services[subscribeOut.ServiceId] = commandLine
+		sh.mutex.Unlock()
+	}
+	sess.print("Subscribe", subscribeOut)
+}
+
+func (sh *shell) removeService(serviceId uint32) {
+	sh.mutex.Lock()
+	delete(sh.services, serviceId)
+	sh.mutex.Unlock()
+}
+
+func (sh *shell) listServices() {
+	sh.mutex.Lock()
+	serviceIds := make([]uint32, 0, len(sh.services))
+	for serviceId := range sh.services {
+		serviceIds = append(serviceIds, serviceId)
+	}
+	sort.Slice(serviceIds, func(i, j int) bool { return serviceIds[i] < serviceIds[j] })
+	var buf strings.Builder
+	for _, serviceId := range serviceIds {
+		fmt.Fprintf(&buf, "  ServiceId=%d %s\n", serviceId, sh.services[serviceId])
+	}
+	sh.mutex.Unlock()
+	if len(serviceIds) == 0 {
+		buf.WriteString("  no active services\n")
+	}
+	io.WriteString(sh.sess.out, buf.String())
+}
+
+func (sh *shell) selectProtocol(protocol string) {
+	sess := sh.sess
+	if protocol == "" {
+		for i := 0; i < len(sess.protocols); i++ {
+			state := ""
+			if sh.connected[sess.protocols[i]] {
+				state = " (connected)"
+			}
+			fmt.Fprintf(sess.out, "  %s%s\n", sess.protocols[i], state)
+		}
+		return
+	}
+	if !sh.connected[protocol] {
+		connectOut := sess.api.Connect(sess.vehicleId, protocol, "")
+		if connectOut.Status != vapi.SUCCESSFUL {
+			sess.print("Connect", connectOut)
+			return
+		}
+		sh.connected[protocol] = true
+	}
+	sess.print("SelectProtocol", sess.api.SelectProtocol(sess.vehicleId, protocol))
+}
+
+func (sh *shell) disconnect() { // the protocol of the session is disconnected by the session
+	for protocol := range sh.connected {
+		if protocol != sh.sess.options.protocol {
+			sh.sess.api.Disconnect(sh.sess.vehicleId, protocol)
+		}
+	}
+}
+
+func (sh *shell) loadPaths() {
+	metadataOut := sh.sess.api.GetMetadata(sh.sess.vehicleId, "Vehicle", sh.sess.options.credentials)
+	if metadataOut.Status != vapi.SUCCESSFUL {
+		fmt.Fprintf(sh.sess.out, "Path completion not available, the metadata could not be read\n")
+		return
+	}
+	tree, err := vapi.ParseMetadata("Vehicle", metadataOut.Metadata)
+	if err != nil {
+		fmt.Fprintf(sh.sess.out, "Path completion not available, the metadata could not be parsed\n")
+		return
+	}
+	var paths []string
+	tree.Walk(func(node *vapi.MetadataNode) bool {
+		paths = append(paths, node.Path)
+		return true
+	})
+	sort.Strings(paths)
+	sh.paths = paths
+}
+
+// autoComplete completes the command name, or the VSS path under the cursor one path segment at a time.
+func (sh *shell) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+	wordStart := strings.LastIndexAny(line[:pos], " \t") + 1
+	word := line[wordStart:pos]
+	var candidates []string
+	if strings.TrimSpace(line[:wordStart]) == "" {
+		for i := 0; i < len(shellCommands); i++ {
+			if strings.HasPrefix(shellCommands[i].name, word) {
+				candidates = append(candidates, shellCommands[i].name + " ")
+			}
+		}
+	} else {
+		candidates = sh.completePath(word)
+	}
+	if len(candidates) == 0 {
+		return "", 0, false
+	}
+	completion := getCommonPrefix(candidates)
+	if completion == word && len(candidates) > 1 {
+		fmt.Fprintf(sh.sess.out, "%s\n", strings.Join(candidates, "  "))
+		return "", 0, false
+	}
+	return line[:wordStart] + completion + line[pos:], wordStart + len(completion), true
+}
+
+// completePath returns the paths that extend prefix by one path segment, where a branch is returned with a trailing dot.
+func (sh *shell) completePath(prefix string) []string {
+	var candidates []string
+	index := sort.SearchStrings(sh.paths, prefix)
+	for ; index < len(sh.paths) && strings.HasPrefix(sh.paths[index], prefix); index++ {
+		path := sh.paths[index]
+		if strings.Contains(path[len(prefix):], ".") {
+			continue // below the next segment, it is represented by its branch
+		}
+		candidate := path
+		if sh.isBranch(path) {
+			candidate += "."
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+func (sh *shell) isBranch(path string) bool {
+	index := sort.SearchStrings(sh.paths, path + ".")
+	return index < len(sh.paths) && strings.HasPrefix(sh.paths[index], path + ".")
+}
+
+func getCommonPrefix(words []string) string {
+	prefix := words[0]
+	for i := 1; i < len(words); i++ {
+		for !strings.HasPrefix(words[i], prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,160 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"VISS-Go/VapiViss"
+)
+
+type syncBuffer struct {
+	mutex sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.String()
+}
+
+func startMockShell(t *testing.T) (*shell, *syncBuffer) {
+	t.Helper()
+	_, vehicleGuid := startMockVehicle(t)
+	out := &syncBuffer{}
+	sess := &session{options: options{vehicleGuid: vehicleGuid, output: "text"}, api: VapiViss.NewBackend(), out: out}
+	if !sess.open(true) {
+		t.Fatalf("open: %s", out.String())
+	}
+	sh := newShell(sess)
+	t.Cleanup(func() {
+		sh.disconnect()
+		sess.close(true)
+	})
+	sh.loadPaths()
+	return sh, out
+}
+
+func TestShellCommands(t *testing.T) {
+	sh, out := startMockShell(t)
+	sh.runLines(strings.NewReader(`
+set Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType pulse
+get Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType
+get Vehicle.Cabin.Seat.Row1.DriverSide {"variant":"paths","parameter":["Switch.Massage.MassageType"]}
+get
+nosuchcommand
+protocol
+exit
+get Vehicle.Speed
+`))
+	for _, expected := range []string{"Set: status=SUCCESSFUL", "Value=pulse", "usage: get path [filter]", "unknown command nosuchcommand", "VISSv3.0-ws (connected)"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("output lacks %q: %s", expected, out.String())
+		}
+	}
+	if strings.Count(out.String(), "Value=pulse") != 2 {
+		t.Errorf("filtered get failed: %s", out.String())
+	}
+	if strings.Contains(out.String(), "Path=Vehicle.Speed") {
+		t.Errorf("command after exit was executed: %s", out.String())
+	}
+}
+
+func TestShellServices(t *testing.T) {
+	sh, out := startMockShell(t)
+	sh.execute(`subscribe Vehicle.Speed {"variant":"timebased","parameter":{"period":"100"}}`)
+	if len(sh.services) != 1 {
+		t.Fatalf("services=%v, output=%s", sh.services, out.String())
+	}
+	var serviceId uint32
+	for serviceId = range sh.services {
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for strings.Count(out.String(), "Path=Vehicle.Speed") < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if strings.Count(out.String(), "Path=Vehicle.Speed") < 2 {
+		t.Errorf("no asynchronous events: %s", out.String())
+	}
+	sh.execute("services")
+	if !strings.Contains(out.String(), "subscribe Vehicle.Speed") {
+		t.Errorf("services lacks the subscription: %s", out.String())
+	}
+	sh.execute("cancel " + strings.TrimSpace(strings.Split(strings.Split(out.String(), "ServiceId=")[1], "\n")[0]))
+	if !strings.Contains(out.String(), "CancelService: status=SUCCESSFUL") || len(sh.services) != 0 {
+		t.Errorf("cancel of serviceId=%d failed: %s", serviceId, out.String())
+	}
+	sh.execute("services")
+	if !strings.Contains(out.String(), "no active services") {
+		t.Errorf("services after cancel: %s", out.String())
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		line string
+		fields []string
+	}{
+		{"", nil},
+		{"  services ", []string{"services"}},
+		{"get  Vehicle.Speed", []string{"get", "Vehicle.Speed"}},
+		{`get Vehicle {"variant":"paths", "parameter":["Speed"]}`, []string{"get", "Vehicle", `{"variant":"paths", "parameter":["Speed"]}`}},
+	}
+	for _, test := range tests {
+		fields := splitCommandLine(test.line, 3)
+		if !reflect.DeepEqual(fields, test.fields) {
+			t.Errorf("splitCommandLine(%q)=%q, want %q", test.line, fields, test.fields)
+		}
+	}
+}
+
+func TestAutoComplete(t *testing.T) {
+	out := &syncBuffer{}
+	sh := newShell(&session{out: out})
+	sh.paths = []string{"Vehicle", "Vehicle.Cabin", "Vehicle.Cabin.Seat", "Vehicle.Cabin.Seat.Row1", "Vehicle.CurrentLocation", "Vehicle.CurrentLocation.Latitude", "Vehicle.Speed"}
+	tests := []struct {
+		line string
+		newLine string
+		ok bool
+	}{
+		{"su", "subscribe ", true},
+		{"s", "", false},  // set, services, subscribe
+		{"get Veh", "get Vehicle.", true},
+		{"get Vehicle.S", "get Vehicle.Speed", true},
+		{"get Vehicle.C", "", false},  // Cabin, CurrentLocation
+		{"get Vehicle.Ca", "get Vehicle.Cabin.", true},
+		{"get Vehicle.Cabin.Seat.Row1", "get Vehicle.Cabin.Seat.Row1", true},
+		{"get Vehicle.Cu", "get Vehicle.CurrentLocation.", true},
+		{"get Vehicle.X", "", false},
+	}
+	for _, test := range tests {
+		newLine, newPos, ok := sh.autoComplete(test.line, len(test.line), '\t')
+		if ok != test.ok || newLine != test.newLine || (ok && newPos != len(newLine)) {
+			t.Errorf("autoComplete(%q)=%q, %d, %t, want %q, %t", test.line, newLine, newPos, ok, test.newLine, test.ok)
+		}
+	}
+	if _, _, ok := sh.autoComplete("get Veh", 7, 'a'); ok {
+		t.Errorf("autoComplete handled a key other than Tab")
+	}
+	if !strings.Contains(out.String(), "Vehicle.Cabin.  Vehicle.CurrentLocation.") {
+		t.Errorf("candidates were not listed: %s", out.String())
+	}
+}
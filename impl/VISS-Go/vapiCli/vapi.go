@@ -0,0 +1,245 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"VISS-Go/VapiKuksa"
+	"VISS-Go/VapiViss"
+	"VISS-Go/vapi"
+)
+
+// vapi is a command-line client that executes one VAPI procedure per invocation, e.g. vapi get -vin pseudoVin1 Vehicle.Speed
+type options struct {
+	vehicleGuid string
+	protocol string
+	filter string
+	credentials string
+	output string
+	duration time.Duration
+	row string
+	column string
+	kuksa string  // address of a KUKSA databroker that the vehicle is connected to
+	catalogue string  // VSS export file that the requests are validated against
+}
+
+type session struct {
+	options options
+	api vapi.Vapi
+	vehicleId vapi.VehicleHandle
+	protocols []string  // protocols supported by the vehicle
+	out io.Writer
+	status vapi.ProcedureStatus  // status of the latest printed output, sets the exit code
+	statusMutex sync.Mutex  // the callbacks of ongoing services print from their own threads
+}
+
+type command struct {
+	name string
+	arguments string
+	description string
+	minArgs int
+	connect bool  // false if the command only needs GetVehicle
+	run func(sess *session, args []string)
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"vehicle", "", "show the protocols supported by the vehicle", 0, false, runVehicle},
+		{"connect", "", "connect to the vehicle and show the long term credentials", 0, true, runConnect},
+		{"get", "path", "read the value(s) of the path, optionally filtered", 1, true, runGet},
+		{"set", "path value", "write the value to the path", 2, true, runSet},
+		{"subscribe", "path", "print the events of a subscription of the path until interrupted or -duration expires", 1, true, runSubscribe},
+		{"metadata", "path", "show the metadata of the path", 1, true, runMetadata},
+		{"seat", "properties | move type position | configure type=position... | massage type intensity duration", "execute a seating service on the seat given by -row and -column", 1, true, runSeat},
+		{"hvac", "", "execute HvacService1", 0, true, runHvac},
+		{"invoke", "serviceName [input]", "invoke the named service with the JSON input", 1, true, runInvoke},
+		{"shell", "", "start an interactive shell on the connected vehicle", 0, true, runShell},
+		{"run", "scenario", "execute the steps of a YAML or JSON scenario file", 1, true, runScenario},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout))
+}
+
+// run executes the command line, and returns the exit code: 0 on success, 1 if the procedure failed, and 2 on usage errors.
+func run(args []string, out io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(out)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+	cmd := getCommand(args[0])
+	if cmd == nil {
+		fmt.Fprintf(out, "vapi: unknown command %s\n", args[0])
+		printUsage(out)
+		return 2
+	}
+	var opts options
+	flagSet := newFlagSet(cmd.name, &opts, out)
+	if flagSet.Parse(args[1:]) != nil {
+		return 2
+	}
+	if opts.output != "text" && opts.output != "json" {
+		fmt.Fprintf(out, "vapi: unknown output format %s\n", opts.output)
+		return 2
+	}
+	if flagSet.NArg() < cmd.minArgs {
+		fmt.Fprintf(out, "usage: vapi %s [flags] %s\n", cmd.name, cmd.arguments)
+		return 2
+	}
+	if opts.catalogue != "" {
+		err := VapiViss.LoadCatalogue(opts.catalogue)
+		if err != nil {
+			fmt.Fprintf(out, "vapi: %s\n", err)
+			return 2
+		}
+	}
+	sess := &session{options: opts, api: VapiViss.NewBackend(), out: out}
+	if opts.kuksa != "" {
+		host, port, err := net.SplitHostPort(opts.kuksa)
+		if err != nil {
+			fmt.Fprintf(out, "vapi: invalid databroker address %s\n", opts.kuksa)
+			return 2
+		}
+		VapiKuksa.RegisterVehicle(opts.vehicleGuid, host, port)
+		sess.api = VapiKuksa.NewBackend()
+	}
+	if !sess.open(cmd.connect) {
+		return 1
+	}
+	defer sess.close(cmd.connect)
+	cmd.run(sess, flagSet.Args())
+	if sess.getStatus() == vapi.FAILED {
+		return 1
+	}
+	return 0
+}
+
+func newFlagSet(name string, opts *options, out io.Writer) *flag.FlagSet {
+	flagSet := flag.NewFlagSet("vapi " + name, flag.ContinueOnError)
+	flagSet.SetOutput(out)
+	flagSet.StringVar(&opts.vehicleGuid, "vin", "pseudoVin1", "GUID of the vehicle")
+	flagSet.StringVar(&opts.protocol, "protocol", "", "protocol of the connection, default is the first websocket protocol of the vehicle")
+	flagSet.StringVar(&opts.filter, "filter", "", "VISS filter expression in JSON")
+	flagSet.StringVar(&opts.credentials, "credentials", "", "short term credentials of the requests")
+	flagSet.StringVar(&opts.output, "output", "text", "output format, text or json")
+	flagSet.DurationVar(&opts.duration, "duration", 0, "maximum time to wait for subscription events and service completion, 0 is no limit")
+	flagSet.StringVar(&opts.row, "row", "Row1", "seat row name")
+	flagSet.StringVar(&opts.column, "column", "DriverSide", "seat column name")
+	flagSet.StringVar(&opts.kuksa, "kuksa", "", "host:port of a KUKSA databroker, the vehicle is then connected to it with the protocol " + VapiKuksa.KUKSA_PROTOCOL)
+	flagSet.StringVar(&opts.catalogue, "catalogue", "", "VSS export file, .json, .csv, or .yaml, that the paths and set values are validated against before they are sent")
+	return flagSet
+}
+
+func printUsage(out io.Writer) {
+	fmt.Fprintf(out, "usage: vapi command [flags] [arguments]\ncommands:\n")
+	for i := 0; i < len(commands); i++ {
+		fmt.Fprintf(out, "  %s %s\n      %s\n", commands[i].name, commands[i].arguments, commands[i].description)
+	}
+	fmt.Fprintf(out, "flags:\n")
+	var opts options
+	flagSet := newFlagSet("", &opts, out)
+	flagSet.PrintDefaults()
+}
+
+func getCommand(name string) *command {
+	for i := 0; i < len(commands); i++ {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+func (sess *session) open(connect bool) bool {
+	getVehicleOut := sess.api.GetVehicle(sess.options.vehicleGuid)
+	if getVehicleOut.Status != vapi.SUCCESSFUL {
+		sess.print("GetVehicle", getVehicleOut)
+		return false
+	}
+	sess.vehicleId = getVehicleOut.VehicleId
+	sess.protocols = getVehicleOut.Protocol
+	if !connect {
+		return true
+	}
+	if sess.options.protocol == "" {
+		sess.options.protocol = selectDefaultProtocol(getVehicleOut.Protocol)
+	}
+	connectOut := sess.api.Connect(sess.vehicleId, sess.options.protocol, "")
+	if connectOut.Status != vapi.SUCCESSFUL {
+		sess.print("Connect", connectOut)
+		sess.api.ReleaseVehicle(sess.vehicleId)
+		return false
+	}
+	return true
+}
+
+func (sess *session) close(connect bool) {
+	if connect {
+		sess.api.Disconnect(sess.vehicleId, sess.options.protocol)
+	}
+	sess.api.ReleaseVehicle(sess.vehicleId)
+}
+
+func selectDefaultProtocol(protocols []string) string {
+	for i := 0; i < len(protocols); i++ {
+		if strings.HasSuffix(protocols[i], "-ws") {
+			return protocols[i]
+		}
+	}
+	if len(protocols) > 0 {
+		return protocols[0]
+	}
+	return ""
+}
+
+func (sess *session) seatId() vapi.MatrixId {
+	return vapi.MatrixId{RowName: sess.options.row, ColumnName: sess.options.column}
+}
+
+/* awaitService prints the callbacks of an ongoing service until a final one is received.
+*  The service is cancelled on an interrupt, or when -duration expires. */
+func awaitService[T any](sess *session, procedure string, serviceId uint32, eventChan chan T, isFinal func(T) bool) {
+	interruptChan := make(chan os.Signal, 1)
+	signal.Notify(interruptChan, os.Interrupt)
+	defer signal.Stop(interruptChan)
+	var timeout <-chan time.Time
+	if sess.options.duration > 0 {
+		timeout = time.After(sess.options.duration)
+	}
+	for {
+		select {
+			case event := <- eventChan:
+				sess.print(procedure, event)
+				if isFinal(event) {
+					return
+				}
+			case <- interruptChan:
+				sess.print("CancelService", sess.api.CancelService(sess.vehicleId, serviceId))
+				return
+			case <- timeout:
+				sess.print("CancelService", sess.api.CancelService(sess.vehicleId, serviceId))
+				return
+		}
+	}
+}
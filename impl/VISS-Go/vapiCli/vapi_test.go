@@ -0,0 +1,161 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"VISS-Go/KuksaMock"
+	"VISS-Go/VapiViss"
+	"VISS-Go/VissMock"
+)
+
+// startMockVehicle starts a mock VISS server, and returns the GUID of a vehicle that connects to it.
+func startMockVehicle(t *testing.T) (*VissMock.Server, string) {
+	t.Helper()
+	signals := VissMock.DefaultSignals()
+	for i := 0; i < len(signals); i++ {
+		if signals[i].Rate > 0 {
+			signals[i].Rate = 10000
+		}
+	}
+	server := VissMock.NewServer(signals)
+	_, err := server.StartWs("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("StartWs: %s", err)
+	}
+	t.Cleanup(server.Close)
+	vehicleGuid := "mockVin-" + t.Name()
+	VapiViss.RegisterVehicle(vehicleGuid, "127.0.0.1", []VapiViss.ConnectivityData{{PortNo: server.Port(), Protocol: "VISSv3.0-ws"}})
+	return server, vehicleGuid
+}
+
+func runCommand(args ...string) (int, string) {
+	out := &syncBuffer{}  // written by the callbacks of ongoing services as well
+	exitCode := run(args, out)
+	return exitCode, out.String()
+}
+
+func TestUsage(t *testing.T) {
+	tests := []struct {
+		args []string
+		exitCode int
+	}{
+		{nil, 2},
+		{[]string{"help"}, 0},
+		{[]string{"unknown"}, 2},
+		{[]string{"get"}, 2},
+		{[]string{"set", "Vehicle.Speed"}, 2},
+		{[]string{"get", "-output", "xml", "Vehicle.Speed"}, 2},
+		{[]string{"get", "-nosuchflag", "Vehicle.Speed"}, 2},
+	}
+	for _, test := range tests {
+		exitCode, out := runCommand(test.args...)
+		if exitCode != test.exitCode {
+			t.Errorf("args=%v: exitCode=%d, want %d, output=%s", test.args, exitCode, test.exitCode, out)
+		}
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	_, vehicleGuid := startMockVehicle(t)
+	exitCode, out := runCommand("set", "-vin", vehicleGuid, "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType", "wave")
+	if exitCode != 0 || !strings.Contains(out, "Set: status=SUCCESSFUL") {
+		t.Fatalf("set: exitCode=%d, output=%s", exitCode, out)
+	}
+	exitCode, out = runCommand("get", "-vin", vehicleGuid, "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType")
+	if exitCode != 0 || !strings.Contains(out, "Value=wave") {
+		t.Errorf("get: exitCode=%d, output=%s", exitCode, out)
+	}
+	exitCode, out = runCommand("get", "-vin", vehicleGuid, "Vehicle.No.Such.Path")
+	if exitCode != 1 || !strings.Contains(out, "status=FAILED") {
+		t.Errorf("get unknown path: exitCode=%d, output=%s", exitCode, out)
+	}
+}
+
+func TestJsonOutput(t *testing.T) {
+	server, vehicleGuid := startMockVehicle(t)
+	server.SetValue("Vehicle.Speed", "42")
+	exitCode, out := runCommand("get", "-vin", vehicleGuid, "-output", "json", "Vehicle.Speed")
+	if exitCode != 0 {
+		t.Fatalf("get: exitCode=%d, output=%s", exitCode, out)
+	}
+	var line struct {
+		Procedure string `json:"procedure"`
+		Output VapiViss.GetOutput `json:"output"`
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	err := json.Unmarshal([]byte(lines[len(lines)-1]), &line)
+	if err != nil {
+		t.Fatalf("Unmarshal: %s, output=%s", err, out)
+	}
+	if line.Procedure != "Get" || line.Output.Status != VapiViss.SUCCESSFUL || line.Output.Data[0].Dp[0].Value != "42" {
+		t.Errorf("unexpected output: %+v", line)
+	}
+}
+
+func TestSubscribeDuration(t *testing.T) {
+	server, vehicleGuid := startMockVehicle(t)
+	server.SetValue("Vehicle.Speed", "25")
+	exitCode, out := runCommand("subscribe", "-vin", vehicleGuid, "-duration", "500ms", "-filter", `{"variant":"timebased","parameter":{"period":"100"}}`, "Vehicle.Speed")
+	if exitCode != 0 || !strings.Contains(out, "Value=25") || !strings.Contains(out, "CancelService: status=SUCCESSFUL") {
+		t.Errorf("subscribe: exitCode=%d, output=%s", exitCode, out)
+	}
+}
+
+func TestSeat(t *testing.T) {
+	server, vehicleGuid := startMockVehicle(t)
+	exitCode, out := runCommand("seat", "-vin", vehicleGuid, "properties")
+	if exitCode != 0 || !strings.Contains(out, "Seat Id=Row1, DriverSide") {
+		t.Errorf("seat properties: exitCode=%d, output=%s", exitCode, out)
+	}
+	exitCode, out = runCommand("seat", "-vin", vehicleGuid, "move", "longitudinal", "50")
+	if exitCode != 0 || !strings.Contains(out, "MoveSeat: status=SUCCESSFUL") {
+		t.Errorf("seat move: exitCode=%d, output=%s", exitCode, out)
+	}
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Position"); value != "150" {
+		t.Errorf("seat position=%s, want 150", value)
+	}
+	exitCode, out = runCommand("seat", "-vin", vehicleGuid, "configure", "longitudinal=10", "lumbar")
+	if exitCode != 1 || !strings.Contains(out, "usage:") {
+		t.Errorf("seat configure with invalid configuration: exitCode=%d, output=%s", exitCode, out)
+	}
+}
+
+func TestKuksa(t *testing.T) {
+	server := KuksaMock.NewServer(VissMock.DefaultSignals())
+	address, err := server.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Start: %s", err)
+	}
+	defer server.Close()
+	server.SetValue("Vehicle.Speed", "42")
+	exitCode, out := runCommand("get", "-vin", "kuksaVin", "-kuksa", address, "Vehicle.Speed")
+	if exitCode != 0 || !strings.Contains(out, "Value=42") {
+		t.Errorf("get: exitCode=%d, output=%s", exitCode, out)
+	}
+	exitCode, out = runCommand("get", "-vin", "kuksaVin", "-kuksa", "localhost", "Vehicle.Speed")
+	if exitCode != 2 {
+		t.Errorf("get with invalid databroker address: exitCode=%d, output=%s", exitCode, out)
+	}
+}
+
+func TestParseSeatConfiguration(t *testing.T) {
+	configuration, ok := parseSeatConfiguration([]string{"longitudinal=10", "lumbar=50.5"})
+	if !ok || len(configuration) != 2 || configuration[0] != (VapiViss.SeatConfig{MovementType: "longitudinal", Position: 10}) || configuration[1] != (VapiViss.SeatConfig{MovementType: "lumbar", Position: 50.5}) {
+		t.Errorf("parseSeatConfiguration=%v, %t", configuration, ok)
+	}
+	for _, args := range [][]string{nil, {"longitudinal"}, {"longitudinal=x"}} {
+		if _, ok := parseSeatConfiguration(args); ok {
+			t.Errorf("parseSeatConfiguration(%v) did not fail", args)
+		}
+	}
+}
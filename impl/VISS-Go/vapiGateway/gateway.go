@@ -0,0 +1,456 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"VISS-Go/VapiViss"
+	"VISS-Go/vapi"
+)
+
+/* vapiGateway exposes the VAPI procedures as a language neutral HTTP API.
+*  - POST /vapi/{procedure} calls a procedure, with the procedure inputs as the members of a JSON object, and responds with the procedure output.
+*  - GET /vapi/services/{serviceId}/events streams the callbacks of a service that was started by a POST as server-sent events.
+*  - GET /vapi/ws is a websocket where a message {"id", "procedure", "input"} calls a procedure, and the server responds with
+*    {"id", "output"}, followed by {"id", "callback"} messages for the callbacks of a started service.
+*  - GET /vapi/openapi.json and GET /vapi/asyncapi.json describe the HTTP API and the websocket API. */
+func main() {
+	address := flag.String("address", ":8888", "address that the gateway listens on")
+	describe := flag.String("describe", "", "print the openapi or the asyncapi description and exit")
+	allowOrigin := flag.String("allow-origin", "", "comma separated origins of web applications that may call the gateway, e.g. http://localhost:3000, * allows any origin, by default only the same origin")
+	flag.Parse()
+	switch *describe {
+		case "":
+		case "openapi":
+			printDescription(getOpenApiDescription())
+			return
+		case "asyncapi":
+			printDescription(getAsyncApiDescription())
+			return
+		default:
+			fmt.Printf("vapiGateway: unknown description %s\n", *describe)
+			os.Exit(2)
+	}
+	fmt.Printf("vapiGateway listening on %s\n", *address)
+	err := http.ListenAndServe(*address, newGateway(VapiViss.NewBackend(), getAllowedOrigins(*allowOrigin)).handler())
+	if err != nil {
+		fmt.Printf("vapiGateway:error=%s\n", err)
+		os.Exit(1)
+	}
+}
+
+func printDescription(description map[string]interface{}) {
+	data, _ := json.MarshalIndent(description, "", "  ")
+	fmt.Println(string(data))
+}
+
+const maxQueuedEvents = 100  // callbacks that are queued per service, the oldest is dropped when a service has more
+
+const unreadTimeout = 60 * time.Second  // a service that was started by a POST is cancelled when its events are not read for this long
+
+type gateway struct {
+	api vapi.Vapi
+	allowedOrigins map[string]bool  // the origins other than the own that may call the gateway, "*" for any
+	mutex sync.Mutex
+	services map[uint32]*serviceEvents  // the ongoing services by ServiceId
+	unreadTimeout time.Duration
+}
+
+func newGateway(api vapi.Vapi, allowedOrigins []string) *gateway {
+	gw := &gateway{api: api, allowedOrigins: make(map[string]bool), services: make(map[uint32]*serviceEvents), unreadTimeout: unreadTimeout}
+	for _, origin := range allowedOrigins {
+		gw.allowedOrigins[origin] = true
+	}
+	return gw
+}
+
+func getAllowedOrigins(allowOrigin string) []string {
+	var origins []string
+	for _, origin := range strings.Split(allowOrigin, ",") {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// isAllowedOrigin returns whether a request from the origin, the Origin header of a browser, may call the gateway.
+func (gw *gateway) isAllowedOrigin(origin string, req *http.Request) bool {
+	if origin == "" {  // not sent by a browser
+		return true
+	}
+	if gw.allowedOrigins["*"] || gw.allowedOrigins[origin] {
+		return true
+	}
+	originUrl, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(originUrl.Host, req.Host)
+}
+
+func (gw *gateway) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /vapi/{procedure}", gw.serveProcedure)
+	mux.HandleFunc("GET /vapi/services/{serviceId}/events", gw.serveEvents)
+	mux.HandleFunc("GET /vapi/ws", gw.serveWs)
+	mux.HandleFunc("GET /vapi/openapi.json", func(w http.ResponseWriter, req *http.Request) {
+		writeJson(w, http.StatusOK, getOpenApiDescription())
+	})
+	mux.HandleFunc("GET /vapi/asyncapi.json", func(w http.ResponseWriter, req *http.Request) {
+		writeJson(w, http.StatusOK, getAsyncApiDescription())
+	})
+	mux.HandleFunc("OPTIONS /vapi/", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		origin := req.Header.Get("Origin")
+		w.Header().Add("Vary", "Origin")
+		if origin != "" && (gw.allowedOrigins["*"] || gw.allowedOrigins[origin]) {  // web applications on other origins that are allowed by -allow-origin
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		mux.ServeHTTP(w, req)
+	})
+}
+
+// ****************** Procedure calls ***************
+func (gw *gateway) serveProcedure(w http.ResponseWriter, req *http.Request) {
+	proc := getProcedure(req.PathValue("procedure"))
+	if proc == nil {
+		writeJson(w, http.StatusNotFound, getErrorOutput(404, "not_found", "Unknown procedure " + req.PathValue("procedure")))
+		return
+	}
+	if !gw.isAllowedOrigin(req.Header.Get("Origin"), req) {  // a browser sends a simple POST from any page without a preflight, and runs the procedure
+		writeJson(w, http.StatusForbidden, getErrorOutput(403, "forbidden", "Origin not allowed " + req.Header.Get("Origin")))
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != "application/json" {
+		writeJson(w, http.StatusUnsupportedMediaType, getErrorOutput(415, "unsupported_media_type", "The Content-Type must be application/json"))
+		return
+	}
+	var input map[string]json.RawMessage
+	err := json.NewDecoder(req.Body).Decode(&input)
+	if err != nil {
+		writeJson(w, http.StatusBadRequest, getErrorOutput(400, "bad_request", "Invalid JSON: " + err.Error()))
+		return
+	}
+	output, service, errorOutput := gw.callProcedure(proc, input)
+	if errorOutput != nil {
+		writeJson(w, http.StatusBadRequest, errorOutput)
+		return
+	}
+	if service != nil {
+		_, serviceId := getStatus(output)
+		var vehicleId vapi.VehicleHandle
+		json.Unmarshal(input["vehicleId"], &vehicleId)
+		go gw.expireUnread(vehicleId, serviceId, service)
+	}
+	writeJson(w, http.StatusOK, output)
+}
+
+/* expireUnread cancels a service that was started by a POST when no event stream has read its events for gw.unreadTimeout,
+*  as the callbacks of a client that never opens, or has left, the event stream would otherwise be queued until the gateway exits. */
+func (gw *gateway) expireUnread(vehicleId vapi.VehicleHandle, serviceId uint32, service *serviceEvents) {
+	ticker := time.NewTicker(gw.unreadTimeout / 4)
+	defer ticker.Stop()
+	for range ticker.C {
+		done, unread := service.isUnread(gw.unreadTimeout)
+		if done {
+			return
+		}
+		if unread {
+			gw.api.CancelService(vehicleId, serviceId)
+			gw.endService(serviceId)
+			return
+		}
+	}
+}
+
+/* callProcedure calls proc with the input members, and returns its output.
+*  If a service was started, its callbacks are queued by the returned serviceEvents until the final callback,
+*  and it is registered by its ServiceId so that CancelService and Unsubscribe end it. */
+func (gw *gateway) callProcedure(proc *procedure, input map[string]json.RawMessage) (interface{}, *serviceEvents, *vapi.GeneralOutput) {
+	for _, name := range getRequiredInputs(proc) {
+		if _, ok := input[name]; !ok {
+			return nil, nil, getErrorOutput(400, "bad_request", "Missing input " + name)
+		}
+	}
+	data, _ := json.Marshal(input)
+	var in procedureInput
+	err := json.Unmarshal(data, &in)
+	if err != nil {
+		return nil, nil, getErrorOutput(400, "bad_request", "Invalid input: " + err.Error())
+	}
+	var service *serviceEvents
+	if proc.isFinal != nil {
+		service = newServiceEvents(proc.isFinal)
+	}
+	output := proc.call(gw.api, in, func(callbackOutput interface{}) {
+		service.push(callbackOutput)
+	})
+	status, serviceId := getStatus(output)
+	if (proc.name == "CancelService" || proc.name == "Unsubscribe") && status == vapi.SUCCESSFUL {
+		gw.endService(in.ServiceId)
+	}
+	if service == nil || status != vapi.ONGOING || serviceId == 0 {
+		return output, nil, nil
+	}
+	gw.mutex.Lock()
+	gw.services[serviceId] = service
+	gw.mutex.Unlock()
+	return output, service, nil
+}
+
+func (gw *gateway) endService(serviceId uint32) {
+	gw.mutex.Lock()
+	service := gw.services[serviceId]
+	delete(gw.services, serviceId)
+	gw.mutex.Unlock()
+	if service != nil {
+		service.end()
+	}
+}
+
+// ****************** Server-sent events ***************
+func (gw *gateway) serveEvents(w http.ResponseWriter, req *http.Request) {
+	serviceId, _ := strconv.ParseUint(req.PathValue("serviceId"), 10, 32)
+	gw.mutex.Lock()
+	service := gw.services[uint32(serviceId)]
+	gw.mutex.Unlock()
+	if service == nil {
+		writeJson(w, http.StatusNotFound, getErrorOutput(404, "not_found", "Unknown service " + req.PathValue("serviceId")))
+		return
+	}
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	service.setReading(true)
+	defer service.setReading(false)
+	for {
+		events, done, notify := service.take()
+		for _, event := range events {
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(w, "event: callback\ndata: %s\n\n", data)
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if done {
+			gw.mutex.Lock()
+			if gw.services[uint32(serviceId)] == service {
+				delete(gw.services, uint32(serviceId))
+			}
+			gw.mutex.Unlock()
+			return
+		}
+		select {
+			case <- notify:
+			case <- req.Context().Done():
+				return
+		}
+	}
+}
+
+// serviceEvents queues the callbacks of a service until they are taken by the event stream or the websocket of the client.
+type serviceEvents struct {
+	mutex sync.Mutex
+	events []interface{}
+	done bool
+	notify chan struct{}  // closed when an event is queued or the service ends
+	isFinal func(status vapi.ProcedureStatus) bool
+	readers int  // the event streams that are open
+	lastRead time.Time
+}
+
+func newServiceEvents(isFinal func(status vapi.ProcedureStatus) bool) *serviceEvents {
+	return &serviceEvents{notify: make(chan struct{}), isFinal: isFinal, lastRead: time.Now()}
+}
+
+func (service *serviceEvents) push(output interface{}) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	if service.done {
+		return
+	}
+	if len(service.events) == maxQueuedEvents {
+		service.events = service.events[1:]
+	}
+	service.events = append(service.events, output)
+	status, _ := getStatus(output)
+	service.done = service.isFinal(status)
+	close(service.notify)
+	service.notify = make(chan struct{})
+}
+
+func (service *serviceEvents) end() {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	if !service.done {
+		service.done = true
+		close(service.notify)
+		service.notify = make(chan struct{})
+	}
+}
+
+// take returns the queued events, whether the service has ended, and the channel that is closed by the next change.
+func (service *serviceEvents) take() ([]interface{}, bool, chan struct{}) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	events := service.events
+	service.events = nil
+	service.lastRead = time.Now()
+	return events, service.done, service.notify
+}
+
+func (service *serviceEvents) setReading(isReading bool) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	if isReading {
+		service.readers++
+	} else {
+		service.readers--
+	}
+	service.lastRead = time.Now()
+}
+
+// isUnread returns whether the service has ended, and whether no event stream has read it for timeout.
+func (service *serviceEvents) isUnread(timeout time.Duration) (bool, bool) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	return service.done, service.readers == 0 && time.Since(service.lastRead) >= timeout
+}
+
+// ****************** Websocket ***************
+type wsRequest struct {
+	Id string `json:"id"`
+	Procedure string `json:"procedure"`
+	Input map[string]json.RawMessage `json:"input"`
+}
+
+type wsResponse struct {
+	Id string `json:"id"`
+	Output interface{} `json:"output,omitempty"`
+	Callback interface{} `json:"callback,omitempty"`
+}
+
+type wsSession struct {
+	gw *gateway
+	conn *websocket.Conn
+	writeMutex sync.Mutex
+	mutex sync.Mutex
+	services map[uint32]vapi.VehicleHandle  // the ongoing services, they are cancelled when the websocket is closed
+}
+
+func (gw *gateway) serveWs(w http.ResponseWriter, req *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return gw.isAllowedOrigin(r.Header.Get("Origin"), r) }}
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		return
+	}
+	session := &wsSession{gw: gw, conn: conn, services: make(map[uint32]vapi.VehicleHandle)}
+	defer session.close()
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var request wsRequest
+		err = json.Unmarshal(message, &request)
+		if err != nil {
+			session.write(wsResponse{Output: getErrorOutput(400, "bad_request", "Invalid JSON: " + err.Error())})
+			continue
+		}
+		go session.call(request)
+	}
+}
+
+func (session *wsSession) call(request wsRequest) {
+	proc := getProcedure(request.Procedure)
+	if proc == nil {
+		session.write(wsResponse{Id: request.Id, Output: getErrorOutput(404, "not_found", "Unknown procedure " + request.Procedure)})
+		return
+	}
+	output, service, errorOutput := session.gw.callProcedure(proc, request.Input)
+	if errorOutput != nil {
+		session.write(wsResponse{Id: request.Id, Output: errorOutput})
+		return
+	}
+	session.write(wsResponse{Id: request.Id, Output: output})
+	if service == nil {
+		return
+	}
+	_, serviceId := getStatus(output)
+	var vehicleId vapi.VehicleHandle
+	json.Unmarshal(request.Input["vehicleId"], &vehicleId)
+	session.mutex.Lock()
+	session.services[serviceId] = vehicleId
+	session.mutex.Unlock()
+	for {
+		events, done, notify := service.take()
+		for _, event := range events {
+			session.write(wsResponse{Id: request.Id, Callback: event})
+		}
+		if done {
+			session.mutex.Lock()
+			delete(session.services, serviceId)
+			session.mutex.Unlock()
+			session.gw.mutex.Lock()
+			if session.gw.services[serviceId] == service {
+				delete(session.gw.services, serviceId)
+			}
+			session.gw.mutex.Unlock()
+			return
+		}
+		<- notify
+	}
+}
+
+func (session *wsSession) write(response wsResponse) {
+	data, _ := json.Marshal(response)
+	session.writeMutex.Lock()
+	session.conn.WriteMessage(websocket.TextMessage, data)
+	session.writeMutex.Unlock()
+}
+
+func (session *wsSession) close() {
+	session.conn.Close()
+	session.mutex.Lock()
+	services := session.services
+	session.services = make(map[uint32]vapi.VehicleHandle)
+	session.mutex.Unlock()
+	for serviceId, vehicleId := range services {
+		session.gw.api.CancelService(vehicleId, serviceId)
+		session.gw.endService(serviceId)
+	}
+}
+
+func writeJson(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(value)
+}
+
+func getErrorOutput(code int32, reason string, description string) *vapi.GeneralOutput {
+	return &vapi.GeneralOutput{Status: vapi.FAILED, Error: &vapi.ErrorData{Code: code, Reason: reason, Description: description}}
+}
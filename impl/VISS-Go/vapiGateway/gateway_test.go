@@ -0,0 +1,324 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"VISS-Go/VapiViss"
+	"VISS-Go/VissMock"
+	"VISS-Go/vapi"
+)
+
+// startGateway starts a mock VISS server and a gateway, and returns the gateway URL and the VehicleId of a connected vehicle.
+func startGateway(t *testing.T) (*VissMock.Server, string, vapi.VehicleHandle) {
+	t.Helper()
+	return startGatewayOf(t, newGateway(VapiViss.NewBackend(), nil))
+}
+
+func startGatewayOf(t *testing.T, gw *gateway) (*VissMock.Server, string, vapi.VehicleHandle) {
+	t.Helper()
+	signals := VissMock.DefaultSignals()
+	for i := 0; i < len(signals); i++ {
+		if signals[i].Rate > 0 {
+			signals[i].Rate = 10000
+		}
+	}
+	server := VissMock.NewServer(signals)
+	_, err := server.StartWs("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("StartWs: %s", err)
+	}
+	vehicleGuid := "mockVin-" + t.Name()
+	VapiViss.RegisterVehicle(vehicleGuid, "127.0.0.1", []VapiViss.ConnectivityData{{PortNo: server.Port(), Protocol: "VISSv3.0-ws"}})
+	httpServer := httptest.NewServer(gw.handler())
+	var getVehicleOut vapi.GetVehicleOutput
+	post(t, httpServer.URL, "GetVehicle", map[string]interface{}{"vehicleGuid": vehicleGuid}, &getVehicleOut)
+	if getVehicleOut.Status != vapi.SUCCESSFUL {
+		t.Fatalf("GetVehicle: %v", getVehicleOut.Error)
+	}
+	var connectOut vapi.ConnectOutput
+	post(t, httpServer.URL, "Connect", map[string]interface{}{"vehicleId": getVehicleOut.VehicleId, "protocol": "VISSv3.0-ws"}, &connectOut)
+	if connectOut.Status != vapi.SUCCESSFUL {
+		t.Fatalf("Connect: %v", connectOut.Error)
+	}
+	t.Cleanup(func() {
+		var out vapi.GeneralOutput
+		post(t, httpServer.URL, "Disconnect", map[string]interface{}{"vehicleId": getVehicleOut.VehicleId, "protocol": "VISSv3.0-ws"}, &out)
+		post(t, httpServer.URL, "ReleaseVehicle", map[string]interface{}{"vehicleId": getVehicleOut.VehicleId}, &out)
+		httpServer.Close()
+		server.Close()
+	})
+	return server, httpServer.URL, getVehicleOut.VehicleId
+}
+
+func post(t *testing.T, url string, procedure string, input interface{}, output interface{}) int {
+	t.Helper()
+	data, _ := json.Marshal(input)
+	response, err := http.Post(url + "/vapi/" + procedure, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("POST %s: %s", procedure, err)
+	}
+	defer response.Body.Close()
+	err = json.NewDecoder(response.Body).Decode(output)
+	if err != nil {
+		t.Fatalf("POST %s: invalid response: %s", procedure, err)
+	}
+	return response.StatusCode
+}
+
+func TestProcedures(t *testing.T) {
+	server, url, vehicleId := startGateway(t)
+	server.SetValue("Vehicle.Speed", "12")
+	var getOut vapi.GetOutput
+	post(t, url, "Get", map[string]interface{}{"vehicleId": vehicleId, "path": "Vehicle.Speed"}, &getOut)
+	if getOut.Status != vapi.SUCCESSFUL || getOut.Data[0].Dp[0].Value != "12" {
+		t.Errorf("Get: %+v", getOut)
+	}
+	post(t, url, "Get", map[string]interface{}{"vehicleId": vehicleId, "path": "Vehicle.CurrentLocation",
+		"filter": map[string]interface{}{"variant": "paths", "parameter": []string{"Latitude", "Longitude"}}}, &getOut)
+	if getOut.Status != vapi.SUCCESSFUL || len(getOut.Data) != 2 {
+		t.Errorf("Get with a JSON filter: %+v", getOut)
+	}
+	var setOut vapi.GeneralOutput
+	post(t, url, "Set", map[string]interface{}{"vehicleId": vehicleId, "path": "Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType", "value": vapi.WAVE}, &setOut)
+	if value, _ := server.GetValue("Vehicle.Cabin.Seat.Row1.DriverSide.Switch.Massage.MassageType"); setOut.Status != vapi.SUCCESSFUL || value != vapi.WAVE {
+		t.Errorf("Set: status=%d, value=%s, error=%v", setOut.Status, value, setOut.Error)
+	}
+	var errorOut vapi.GeneralOutput
+	if statusCode := post(t, url, "NoSuchProcedure", map[string]interface{}{}, &errorOut); statusCode != http.StatusNotFound || errorOut.Error.Code != 404 {
+		t.Errorf("unknown procedure: statusCode=%d, output=%+v", statusCode, errorOut)
+	}
+	if statusCode := post(t, url, "Set", map[string]interface{}{"vehicleId": vehicleId, "path": "Vehicle.Speed"}, &errorOut); statusCode != http.StatusBadRequest || !strings.Contains(errorOut.Error.Description, "value") {
+		t.Errorf("missing input: statusCode=%d, output=%+v", statusCode, errorOut)
+	}
+}
+
+func TestServerSentEvents(t *testing.T) {
+	_, url, vehicleId := startGateway(t)
+	var moveSeatOut vapi.MoveSeatOutput
+	post(t, url, "MoveSeat", map[string]interface{}{"vehicleId": vehicleId, "seatId": vapi.MatrixId{RowName: "Row1", ColumnName: "DriverSide"},
+		"movementType": vapi.LONGITUDINAL, "position": 50}, &moveSeatOut)
+	if moveSeatOut.Status != vapi.ONGOING {
+		t.Fatalf("MoveSeat: %+v", moveSeatOut)
+	}
+	response, err := http.Get(fmt.Sprintf("%s/vapi/services/%d/events", url, moveSeatOut.ServiceId))
+	if err != nil {
+		t.Fatalf("events: %s", err)
+	}
+	defer response.Body.Close()
+	if response.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("events: Content-Type=%s", response.Header.Get("Content-Type"))
+	}
+	var final vapi.MoveSeatOutput
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {  // the stream ends after the final callback
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			json.Unmarshal([]byte(data), &final)
+		}
+	}
+	if final.Status != vapi.SUCCESSFUL || final.Position != 50 {
+		t.Errorf("final callback: %+v", final)
+	}
+	response, _ = http.Get(fmt.Sprintf("%s/vapi/services/%d/events", url, moveSeatOut.ServiceId))
+	if response.StatusCode != http.StatusNotFound {
+		t.Errorf("events of an ended service: statusCode=%d", response.StatusCode)
+	}
+}
+
+func TestUnreadServiceExpired(t *testing.T) {
+	gw := newGateway(VapiViss.NewBackend(), nil)
+	gw.unreadTimeout = 200 * time.Millisecond
+	_, url, vehicleId := startGatewayOf(t, gw)
+	subscribe := func() uint32 {
+		var subscribeOut vapi.SubscribeOutput
+		post(t, url, "Subscribe", map[string]interface{}{"vehicleId": vehicleId, "path": "Vehicle.Speed",
+			"filter": `{"variant":"timebased","parameter":{"period":"50"}}`}, &subscribeOut)
+		if subscribeOut.Status != vapi.ONGOING {
+			t.Fatalf("Subscribe: %+v", subscribeOut)
+		}
+		return subscribeOut.ServiceId
+	}
+	unreadId := subscribe()
+	readId := subscribe()
+	response, err := http.Get(fmt.Sprintf("%s/vapi/services/%d/events", url, readId))
+	if err != nil {
+		t.Fatalf("events: %s", err)
+	}
+	defer response.Body.Close()
+	go io.Copy(io.Discard, response.Body)
+	time.Sleep(4 * gw.unreadTimeout)
+	response, _ = http.Get(fmt.Sprintf("%s/vapi/services/%d/events", url, unreadId))
+	response.Body.Close()
+	if response.StatusCode != http.StatusNotFound {
+		t.Errorf("events of an unread service: statusCode=%d", response.StatusCode)
+	}
+	var cancelOut vapi.GeneralOutput
+	post(t, url, "CancelService", map[string]interface{}{"vehicleId": vehicleId, "serviceId": unreadId}, &cancelOut)
+	if cancelOut.Status != vapi.FAILED {
+		t.Errorf("CancelService of an expired service: %+v", cancelOut)
+	}
+	post(t, url, "CancelService", map[string]interface{}{"vehicleId": vehicleId, "serviceId": readId}, &cancelOut)
+	if cancelOut.Status != vapi.SUCCESSFUL {
+		t.Errorf("CancelService of a read service: %+v", cancelOut)
+	}
+}
+
+func TestWebsocket(t *testing.T) {
+	server, url, vehicleId := startGateway(t)
+	server.SetValue("Vehicle.Speed", "25")
+	conn, _, err := websocket.DefaultDialer.Dial("ws" + strings.TrimPrefix(url, "http") + "/vapi/ws", nil)
+	if err != nil {
+		t.Fatalf("Dial: %s", err)
+	}
+	defer conn.Close()
+	conn.WriteJSON(map[string]interface{}{"id": "1", "procedure": "Subscribe", "input": map[string]interface{}{"vehicleId": vehicleId, "path": "Vehicle.Speed",
+		"filter": `{"variant":"timebased","parameter":{"period":"100"}}`}})
+	var subscribeOut vapi.SubscribeOutput
+	var event vapi.SubscribeOutput
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for event.ServiceId == 0 {
+		var response struct {
+			Id string `json:"id"`
+			Output *vapi.SubscribeOutput `json:"output"`
+			Callback *vapi.SubscribeOutput `json:"callback"`
+		}
+		err = conn.ReadJSON(&response)
+		if err != nil {
+			t.Fatalf("ReadJSON: %s", err)
+		}
+		if response.Id != "1" {
+			t.Errorf("response id=%s", response.Id)
+		}
+		if response.Output != nil {
+			subscribeOut = *response.Output
+		}
+		if response.Callback != nil {
+			event = *response.Callback
+		}
+	}
+	if subscribeOut.Status != vapi.ONGOING || event.ServiceId != subscribeOut.ServiceId || event.Data[0].Dp[0].Value != "25" {
+		t.Errorf("Subscribe: output=%+v, callback=%+v", subscribeOut, event)
+	}
+	conn.WriteJSON(map[string]interface{}{"id": "2", "procedure": "Unsubscribe", "input": map[string]interface{}{"vehicleId": vehicleId, "serviceId": subscribeOut.ServiceId}})
+	for {
+		var response struct {
+			Id string `json:"id"`
+			Output *vapi.GeneralOutput `json:"output"`
+		}
+		err = conn.ReadJSON(&response)
+		if err != nil {
+			t.Fatalf("ReadJSON: %s", err)
+		}
+		if response.Id == "2" {
+			if response.Output.Status != vapi.SUCCESSFUL {
+				t.Errorf("Unsubscribe: %+v", response.Output)
+			}
+			break
+		}
+	}
+}
+
+func TestAllowedOrigins(t *testing.T) {
+	tests := []struct {
+		name string
+		allowOrigin string
+		origin string
+		allowed bool
+	}{
+		{"no origin", "", "", true},
+		{"other origin by default", "", "http://example.com", false},
+		{"listed origin", "http://example.com/, http://localhost:3000", "http://example.com", true},
+		{"unlisted origin", "http://localhost:3000", "http://example.com", false},
+		{"any origin", "*", "http://example.com", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			httpServer := httptest.NewServer(newGateway(VapiViss.NewBackend(), getAllowedOrigins(test.allowOrigin)).handler())
+			defer httpServer.Close()
+			request, _ := http.NewRequest("GET", httpServer.URL + "/vapi/openapi.json", nil)
+			if test.origin != "" {
+				request.Header.Set("Origin", test.origin)
+			}
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatalf("GET: %s", err)
+			}
+			response.Body.Close()
+			isAllowedHeader := test.origin != "" && response.Header.Get("Access-Control-Allow-Origin") == test.origin
+			if isAllowedHeader != (test.allowed && test.origin != "") {
+				t.Errorf("Access-Control-Allow-Origin = %q for origin %q", response.Header.Get("Access-Control-Allow-Origin"), test.origin)
+			}
+			for _, contentType := range []string{"application/json", "text/plain"} {  // text/plain is sent by a browser without a preflight
+				request, _ = http.NewRequest("POST", httpServer.URL + "/vapi/GetVehicle", strings.NewReader(`{"vehicleGuid":"unknownVin"}`))
+				request.Header.Set("Content-Type", contentType)
+				if test.origin != "" {
+					request.Header.Set("Origin", test.origin)
+				}
+				response, err = http.DefaultClient.Do(request)
+				if err != nil {
+					t.Fatalf("POST: %s", err)
+				}
+				response.Body.Close()
+				if isCalled := response.StatusCode == http.StatusOK; isCalled != (test.allowed && contentType == "application/json") {
+					t.Errorf("POST from origin %q with %s: statusCode=%d", test.origin, contentType, response.StatusCode)
+				}
+			}
+			header := http.Header{}
+			if test.origin != "" {
+				header.Set("Origin", test.origin)
+			}
+			conn, _, err := websocket.DefaultDialer.Dial("ws" + strings.TrimPrefix(httpServer.URL, "http") + "/vapi/ws", header)
+			if (err == nil) != test.allowed {
+				t.Errorf("websocket from origin %q: error=%v, want allowed=%t", test.origin, err, test.allowed)
+			}
+			if conn != nil {
+				conn.Close()
+			}
+		})
+	}
+	httpServer := httptest.NewServer(newGateway(VapiViss.NewBackend(), nil).handler())
+	defer httpServer.Close()
+	header := http.Header{"Origin": {httpServer.URL}}  // the same origin is always allowed
+	conn, _, err := websocket.DefaultDialer.Dial("ws" + strings.TrimPrefix(httpServer.URL, "http") + "/vapi/ws", header)
+	if err != nil {
+		t.Errorf("websocket from the same origin: %s", err)
+	} else {
+		conn.Close()
+	}
+}
+
+func TestDescriptions(t *testing.T) {
+	vapiType := reflect.TypeOf((*vapi.Vapi)(nil)).Elem()
+	for i := 0; i < vapiType.NumMethod(); i++ {  // every procedure is exposed
+		if getProcedure(vapiType.Method(i).Name) == nil {
+			t.Errorf("procedure %s is not exposed", vapiType.Method(i).Name)
+		}
+	}
+	openApi := getOpenApiDescription()
+	if len(openApi["paths"].(map[string]interface{})) != vapiType.NumMethod() + 1 {
+		t.Errorf("openapi paths: %d", len(openApi["paths"].(map[string]interface{})))
+	}
+	data, err := json.Marshal(getAsyncApiDescription())
+	if err != nil || !strings.Contains(string(data), `"SubscribeCallback"`) || strings.Contains(string(data), `"GetCallback"`) {
+		t.Errorf("asyncapi: error=%v", err)
+	}
+}
@@ -0,0 +1,187 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ****************** API descriptions ***************
+/* The OpenAPI and AsyncAPI descriptions are generated from the procedure table,
+*  the schemas of the inputs and outputs are derived from the Go types of procedureInput and the vapi output types. */
+const apiVersion = "1.0.0"
+
+var optionalInputs = []string{"clientCredentials", "stCredentials", "filter", "procedureInput"}
+
+func getRequiredInputs(proc *procedure) []string {
+	var required []string
+	for _, name := range proc.inputs {
+		isOptional := false
+		for _, optional := range optionalInputs {
+			if name == optional {
+				isOptional = true
+			}
+		}
+		if !isOptional {
+			required = append(required, name)
+		}
+	}
+	return required
+}
+
+func getOpenApiDescription() map[string]interface{} {
+	paths := make(map[string]interface{})
+	schemas := make(map[string]interface{})
+	for i := 0; i < len(procedures); i++ {
+		proc := &procedures[i]
+		outputName := reflect.TypeOf(proc.output).Name()
+		schemas[outputName] = getSchema(reflect.TypeOf(proc.output))
+		description := "Calls the VAPI procedure " + proc.name + "."
+		if proc.isFinal != nil {
+			description += " The callbacks of the started service are streamed by GET /vapi/services/{serviceId}/events."
+		}
+		paths["/vapi/" + proc.name] = map[string]interface{}{
+			"post": map[string]interface{}{
+				"operationId": proc.name,
+				"tags": []string{proc.group},
+				"description": description,
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content": map[string]interface{}{"application/json": map[string]interface{}{"schema": getInputSchema(proc)}},
+				},
+				"responses": map[string]interface{}{
+					"200": getResponse("The procedure output.", "#/components/schemas/" + outputName),
+					"400": getResponse("The input is invalid.", "#/components/schemas/GeneralOutput"),
+				},
+			},
+		}
+	}
+	paths["/vapi/services/{serviceId}/events"] = map[string]interface{}{
+		"get": map[string]interface{}{
+			"operationId": "ServiceEvents",
+			"description": "Streams the callbacks of an ongoing service as server-sent events with the event name callback, and the callback output as data. " +
+				"The stream ends after the final callback, or when the service is cancelled.",
+			"parameters": []interface{}{map[string]interface{}{"name": "serviceId", "in": "path", "required": true, "schema": map[string]interface{}{"type": "integer", "format": "uint32"}}},
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{"description": "The callbacks.", "content": map[string]interface{}{"text/event-stream": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}}},
+				"404": getResponse("The service is unknown, or it has ended and its callbacks were streamed.", "#/components/schemas/GeneralOutput"),
+			},
+		},
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{"title": "VAPI gateway", "version": apiVersion, "description": "The VAPI procedures as an HTTP API."},
+		"paths": paths,
+		"components": map[string]interface{}{"schemas": schemas},
+	}
+}
+
+func getAsyncApiDescription() map[string]interface{} {
+	var requests, responses []interface{}
+	for i := 0; i < len(procedures); i++ {
+		proc := &procedures[i]
+		outputSchema := getSchema(reflect.TypeOf(proc.output))
+		requests = append(requests, map[string]interface{}{
+			"name": proc.name,
+			"payload": getObjectSchema(map[string]interface{}{
+				"id": map[string]interface{}{"type": "string", "description": "Set by the client, and returned in the output and the callbacks."},
+				"procedure": map[string]interface{}{"type": "string", "enum": []string{proc.name}},
+				"input": getInputSchema(proc),
+			}, []string{"procedure", "input"}),
+		})
+		responses = append(responses, map[string]interface{}{
+			"name": proc.name + "Output",
+			"payload": getObjectSchema(map[string]interface{}{"id": map[string]interface{}{"type": "string"}, "output": outputSchema}, []string{"output"}),
+		})
+		if proc.isFinal != nil {
+			responses = append(responses, map[string]interface{}{
+				"name": proc.name + "Callback",
+				"payload": getObjectSchema(map[string]interface{}{"id": map[string]interface{}{"type": "string"}, "callback": outputSchema}, []string{"callback"}),
+			})
+		}
+	}
+	return map[string]interface{}{
+		"asyncapi": "2.6.0",
+		"info": map[string]interface{}{"title": "VAPI gateway websocket", "version": apiVersion,
+			"description": "The VAPI procedures over a websocket. The services that are ongoing when the websocket is closed are cancelled."},
+		"channels": map[string]interface{}{
+			"/vapi/ws": map[string]interface{}{
+				"publish": map[string]interface{}{"operationId": "call", "message": map[string]interface{}{"oneOf": requests}},
+				"subscribe": map[string]interface{}{"operationId": "receive", "message": map[string]interface{}{"oneOf": responses}},
+			},
+		},
+	}
+}
+
+func getResponse(description string, ref string) map[string]interface{} {
+	return map[string]interface{}{"description": description, "content": map[string]interface{}{"application/json": map[string]interface{}{"schema": map[string]interface{}{"$ref": ref}}}}
+}
+
+func getInputSchema(proc *procedure) map[string]interface{} {
+	inputType := reflect.TypeOf(procedureInput{})
+	properties := make(map[string]interface{})
+	for _, name := range proc.inputs {
+		for i := 0; i < inputType.NumField(); i++ {
+			if inputType.Field(i).Tag.Get("json") == name {
+				properties[name] = getSchema(inputType.Field(i).Type)
+			}
+		}
+	}
+	return getObjectSchema(properties, getRequiredInputs(proc))
+}
+
+func getObjectSchema(properties map[string]interface{}, required []string) map[string]interface{} {
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// getSchema returns the JSON schema of the JSON encoding of a Go type.
+func getSchema(goType reflect.Type) map[string]interface{} {
+	if goType == reflect.TypeOf(jsonText("")) {
+		return map[string]interface{}{"description": "A JSON value, or its text as a JSON string."}
+	}
+	switch goType.Kind() {
+		case reflect.Pointer:
+			schema := getSchema(goType.Elem())
+			schema["nullable"] = true
+			return schema
+		case reflect.Struct:
+			properties := make(map[string]interface{})
+			for i := 0; i < goType.NumField(); i++ {
+				field := goType.Field(i)
+				name := strings.Split(field.Tag.Get("json"), ",")[0]
+				if name == "" {
+					name = field.Name
+				}
+				properties[name] = getSchema(field.Type)
+			}
+			return map[string]interface{}{"type": "object", "properties": properties}
+		case reflect.Slice, reflect.Array:
+			return map[string]interface{}{"type": "array", "items": getSchema(goType.Elem())}
+		case reflect.Map:
+			return map[string]interface{}{"type": "object", "additionalProperties": getSchema(goType.Elem())}
+		case reflect.String:
+			return map[string]interface{}{"type": "string"}
+		case reflect.Bool:
+			return map[string]interface{}{"type": "boolean"}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return map[string]interface{}{"type": "integer", "format": goType.Kind().String()}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return map[string]interface{}{"type": "integer", "format": goType.Kind().String(), "minimum": 0}
+		case reflect.Float32:
+			return map[string]interface{}{"type": "number", "format": "float"}
+		case reflect.Float64:
+			return map[string]interface{}{"type": "number", "format": "double"}
+	}
+	return map[string]interface{}{}
+}
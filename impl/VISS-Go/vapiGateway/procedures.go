@@ -0,0 +1,172 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"VISS-Go/vapi"
+)
+
+/* procedureInput holds the inputs of all procedures, a request sets the members of the inputs of the called procedure.
+*  The members filter and procedureInput are JSON, and may be given as a JSON string or as a JSON value. */
+type procedureInput struct {
+	VehicleGuid string `json:"vehicleGuid"`
+	VehicleId vapi.VehicleHandle `json:"vehicleId"`
+	Protocol string `json:"protocol"`
+	ClientCredentials string `json:"clientCredentials"`
+	LtCredentials string `json:"ltCredentials"`
+	Purpose string `json:"purpose"`
+	StCredentials string `json:"stCredentials"`
+	ServiceId uint32 `json:"serviceId"`
+	ServiceName string `json:"serviceName"`
+	ProcedureInput jsonText `json:"procedureInput"`
+	Path string `json:"path"`
+	Value string `json:"value"`
+	Filter jsonText `json:"filter"`
+	SeatId vapi.MatrixId `json:"seatId"`
+	MovementType string `json:"movementType"`
+	Position vapi.Percentage `json:"position"`
+	Configuration []vapi.SeatConfig `json:"configuration"`
+	MassageType string `json:"massageType"`
+	Intensity vapi.Percentage `json:"intensity"`
+	Duration uint32 `json:"duration"`
+	Steps []vapi.MassageStep `json:"steps"`
+	Level vapi.Percentage `json:"level"`
+}
+
+// jsonText is the text of a JSON value, a JSON string is unquoted.
+type jsonText string
+
+func (text *jsonText) UnmarshalJSON(data []byte) error {
+	var unquoted string
+	if json.Unmarshal(data, &unquoted) == nil {
+		*text = jsonText(unquoted)
+		return nil
+	}
+	*text = jsonText(data)
+	return nil
+}
+
+type procedure struct {
+	name string
+	group string  // the vapi interface that declares the procedure
+	inputs []string  // the members of procedureInput that are inputs of the procedure, see getRequiredInputs
+	isFinal func(status vapi.ProcedureStatus) bool  // nil if the procedure has no callback, else true for the last callback of the service
+	call func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{}
+	output interface{}  // the zero output, for the API description
+}
+
+func untilDone(status vapi.ProcedureStatus) bool {
+	return status != vapi.ONGOING
+}
+
+func untilFailed(status vapi.ProcedureStatus) bool {  // subscription events are SUCCESSFUL until the subscription terminates
+	return status == vapi.FAILED
+}
+
+var procedures []procedure
+
+func init() {
+	procedures = []procedure{
+		{"GetVehicle", "Common", []string{"vehicleGuid"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.GetVehicle(in.VehicleGuid)
+		}, vapi.GetVehicleOutput{}},
+		{"ReleaseVehicle", "Common", []string{"vehicleId"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.ReleaseVehicle(in.VehicleId)
+		}, vapi.GeneralOutput{}},
+		{"Connect", "Common", []string{"vehicleId", "protocol", "clientCredentials"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.Connect(in.VehicleId, in.Protocol, in.ClientCredentials)
+		}, vapi.ConnectOutput{}},
+		{"Disconnect", "Common", []string{"vehicleId", "protocol"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.Disconnect(in.VehicleId, in.Protocol)
+		}, vapi.GeneralOutput{}},
+		{"SelectProtocol", "Common", []string{"vehicleId", "protocol"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.SelectProtocol(in.VehicleId, in.Protocol)
+		}, vapi.GeneralOutput{}},
+		{"CancelService", "Common", []string{"vehicleId", "serviceId"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.CancelService(in.VehicleId, in.ServiceId)
+		}, vapi.GeneralOutput{}},
+		{"ServiceInquiry", "Common", []string{"vehicleId"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.ServiceInquiry(in.VehicleId)
+		}, vapi.ServiceInquiryOutput{}},
+		{"GetStCredentials", "Common", []string{"vehicleId", "ltCredentials", "purpose"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.GetStCredentials(in.VehicleId, in.LtCredentials, in.Purpose)
+		}, vapi.GetStCredentialsOutput{}},
+		{"Invoke", "Common", []string{"vehicleId", "serviceName", "procedureInput", "stCredentials"}, untilDone, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.Invoke(in.VehicleId, in.ServiceName, string(in.ProcedureInput), in.StCredentials, func(out vapi.InvokeOutput) { callback(out) })
+		}, vapi.InvokeOutput{}},
+		{"GetMetadata", "Common", []string{"vehicleId", "path", "stCredentials"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.GetMetadata(in.VehicleId, in.Path, in.StCredentials)
+		}, vapi.GetMetadataOutput{}},
+		{"Get", "Data", []string{"vehicleId", "path", "filter", "stCredentials"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.Get(in.VehicleId, in.Path, string(in.Filter), in.StCredentials)
+		}, vapi.GetOutput{}},
+		{"Set", "Data", []string{"vehicleId", "path", "value", "stCredentials"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.Set(in.VehicleId, in.Path, in.Value, in.StCredentials)
+		}, vapi.GeneralOutput{}},
+		{"Subscribe", "Data", []string{"vehicleId", "path", "filter", "stCredentials"}, untilFailed, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.Subscribe(in.VehicleId, in.Path, string(in.Filter), in.StCredentials, func(out vapi.SubscribeOutput) { callback(out) })
+		}, vapi.SubscribeOutput{}},
+		{"Unsubscribe", "Data", []string{"vehicleId", "serviceId"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.Unsubscribe(in.VehicleId, in.ServiceId)
+		}, vapi.GeneralOutput{}},
+		{"MoveSeat", "Seating", []string{"vehicleId", "seatId", "movementType", "position", "stCredentials"}, untilDone, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.MoveSeat(in.VehicleId, in.SeatId, in.MovementType, in.Position, in.StCredentials, func(out vapi.MoveSeatOutput) { callback(out) })
+		}, vapi.MoveSeatOutput{}},
+		{"ConfigureSeat", "Seating", []string{"vehicleId", "seatId", "configuration", "stCredentials"}, untilDone, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.ConfigureSeat(in.VehicleId, in.SeatId, in.Configuration, in.StCredentials, func(out vapi.ConfigureSeatOutput) { callback(out) })
+		}, vapi.ConfigureSeatOutput{}},
+		{"ActivateMassage", "Seating", []string{"vehicleId", "seatId", "massageType", "intensity", "duration", "stCredentials"}, untilDone, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.ActivateMassage(in.VehicleId, in.SeatId, in.MassageType, in.Intensity, in.Duration, in.StCredentials, func(out vapi.MassageOutput) { callback(out) })
+		}, vapi.MassageOutput{}},
+		{"ActivateMassageProgram", "Seating", []string{"vehicleId", "seatId", "steps", "stCredentials"}, untilDone, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.ActivateMassageProgram(in.VehicleId, in.SeatId, in.Steps, in.StCredentials, func(out vapi.MassageProgramOutput) { callback(out) })
+		}, vapi.MassageProgramOutput{}},
+		{"ActivateSeatHeating", "Seating", []string{"vehicleId", "seatId", "level", "duration", "stCredentials"}, untilDone, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.ActivateSeatHeating(in.VehicleId, in.SeatId, in.Level, in.Duration, in.StCredentials, func(out vapi.SeatClimateOutput) { callback(out) })
+		}, vapi.SeatClimateOutput{}},
+		{"ActivateSeatVentilation", "Seating", []string{"vehicleId", "seatId", "level", "duration", "stCredentials"}, untilDone, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.ActivateSeatVentilation(in.VehicleId, in.SeatId, in.Level, in.Duration, in.StCredentials, func(out vapi.SeatClimateOutput) { callback(out) })
+		}, vapi.SeatClimateOutput{}},
+		{"GetPropertiesSeating", "Seating", []string{"vehicleId"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.GetPropertiesSeating(in.VehicleId)
+		}, vapi.GetPropertiesSeatingOutput{}},
+		{"HvacService1", "Hvac", []string{"vehicleId"}, nil, func(api vapi.Vapi, in procedureInput, callback func(interface{})) interface{} {
+			return api.HvacService1(in.VehicleId)
+		}, vapi.GeneralOutput{}},
+	}
+}
+
+func getProcedure(name string) *procedure {
+	for i := 0; i < len(procedures); i++ {
+		if procedures[i].name == name {
+			return &procedures[i]
+		}
+	}
+	return nil
+}
+
+// getStatus returns the Status, and the ServiceId if the output has one, of a procedure output.
+func getStatus(output interface{}) (vapi.ProcedureStatus, uint32) {
+	value := reflect.ValueOf(output)
+	if value.Kind() != reflect.Struct {
+		return vapi.FAILED, 0
+	}
+	var status vapi.ProcedureStatus = vapi.FAILED
+	if field := value.FieldByName("Status"); field.IsValid() {
+		status = vapi.ProcedureStatus(field.Int())
+	}
+	var serviceId uint32
+	if field := value.FieldByName("ServiceId"); field.IsValid() {
+		serviceId = uint32(field.Uint())
+	}
+	return status, serviceId
+}
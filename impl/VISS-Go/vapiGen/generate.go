@@ -0,0 +1,534 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// ****************** Generation ***************
+/* The generated files of a service group, the Go files follow the layout of the handwritten Seating service group.
+*  vapi/<Group>.go: the datatypes, the input and output structs, and the interface of the group.
+*  VapiViss/<Group>Procedures.go: the procedures, which validate the inputs and call the implementations, and the property procedures.
+*  VapiViss/<Group>.go: the implementation stubs, which are only generated if the file does not exist.
+*  <Group>.html: the service group section of the VAPI specification. */
+
+const licenseHeader = `/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+`
+
+var templateFunctions = template.FuncMap{
+	"goType": goType,
+	"param": param,
+	"impl": param,
+	"constName": constName,
+	"htmlId": htmlId,
+	"quote": strconv.Quote,
+	"number": func(value *float64) string { return strconv.FormatFloat(*value, 'g', -1, 64) },
+	"rangeCheck": getRangeCheck,
+	"license": func() string { return licenseHeader },
+}
+
+var vapiTemplate = template.Must(template.New("vapi").Funcs(templateFunctions).Parse(`{{license}}
+// Code generated by vapiGen from {{.Source}}. DO NOT EDIT.
+
+package vapi
+
+// ****************** {{.Name}} service group ***************
+{{- with .Description}}
+// {{.}}
+{{- end}}
+{{- with .Constants}}
+
+// constants for the allowed values of the inputs
+const (
+{{- range .}}
+	{{constName .}} = {{quote .}}
+{{- end}}
+)
+{{- end}}
+{{- range .Structs}}
+
+{{with .Description}}// {{.}}
+{{end -}}
+type {{.Name}} struct {
+{{- range .Members}}
+	{{.Name}} {{goType .Datatype}}{{with .Description}}  // {{.}}{{end}}
+{{- end}}
+}
+{{- end}}
+{{- range .Procedures}}
+{{- if .Inputs}}
+
+type {{.Name}}Input struct {
+{{- range .Inputs}}
+	{{.Name}} {{goType .Datatype}}{{with .Description}}  // {{.}}{{end}}
+{{- end}}
+}
+{{- end}}
+{{- if .HasOutputType}}
+
+type {{.Name}}Output struct {
+	Status ProcedureStatus
+	Error *ErrorData
+{{- range .Outputs}}
+	{{.Name}} {{goType .Datatype}}{{with .Description}}  // {{.}}{{end}}
+{{- end}}
+{{- if .Session}}
+	ServiceId uint32
+{{- end}}
+}
+{{- end}}
+{{- end}}
+
+// {{.Name}} is a service group, it is added to the Service interface in Service.go when its procedures are implemented by the backends.
+type {{.Name}} interface {
+{{- range .Procedures}}
+	{{.Signature}}
+{{- end}}
+}
+`))
+
+var proceduresTemplate = template.Must(template.New("procedures").Funcs(templateFunctions).Parse(`{{license}}
+// Code generated by vapiGen from {{.Source}}. DO NOT EDIT.
+
+package VapiViss
+{{- if .Imports}}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{- end}}
+{{- if .Aliases}}
+
+// The {{.Name}} types are defined by the vapi package
+{{- range .Aliases}}
+type {{.}} = vapi.{{.}}
+{{- end}}
+{{- end}}
+{{- with .Constants}}
+
+const (
+{{- range .}}
+	{{constName .}} = vapi.{{constName .}}
+{{- end}}
+)
+{{- end}}
+
+// ****************** {{.Name}} service group ***************
+{{- range .Procedures}}
+
+func {{.Signature}} {
+	var out {{.OutputType}}
+	vehConn := getVehicleConnection(vehicleId)
+	if vehConn == nil {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "Vehicle is not connected")
+		return out
+	}
+{{- if .Property}}
+	if len(vehConn.selectedProtocol) == 0 {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "vehicle not connected")
+		return out
+	}
+{{- range .Outputs}}
+	json.Unmarshal([]byte({{quote .Default}}), &out.{{.Name}})
+{{- end}}
+	out.Status = SUCCESSFUL
+	return out
+}
+{{- else}}
+{{- range $input := .Inputs}}
+{{- with rangeCheck $input}}
+	if {{.}} {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "{{param $input.Name}} out of range")
+		return out
+	}
+{{- end}}
+{{- if $input.Allowed}}
+	if {{range $i, $value := $input.Allowed}}{{if $i}} && {{end}}{{param $input.Name}} != {{constName $value}}{{end}} {
+		out.Status = FAILED
+		out.Error = getErrorObject(400, "invalid_data", "{{param $input.Name}} not supported")
+		return out
+	}
+{{- end}}
+{{- end}}
+	return {{impl .Name}}(vehicleId, vehConn, {{if .Inputs}}{{.Name}}Input{ {{- range $i, $input := .Inputs}}{{if $i}}, {{end}}{{.Name}}: {{param .Name}}{{end -}} }, {{end}}stCredentials{{if .Session}}, callback{{end}})
+}
+{{- end}}
+{{- end}}
+{{- range .Procedures}}
+
+func (Backend) {{.Signature}} {
+	return {{.Name}}({{.Arguments}})
+}
+{{- end}}
+`))
+
+var stubsTemplate = template.Must(template.New("stubs").Funcs(templateFunctions).Parse(`{{license}}
+package VapiViss
+
+/* The implementations of the {{.Name}} procedures, they are called by the procedures in {{.Name}}Procedures.go after the inputs are validated.
+*  This file was created by vapiGen from {{.Source}}, and is not overwritten when the procedures are generated again. */
+{{- range .Procedures}}
+{{- if not .Property}}
+
+func {{impl .Name}}(vehicleId VehicleHandle, vehConn *VehicleConnection, {{if .Inputs}}in {{.Name}}Input, {{end}}stCredentials string{{if .Session}}, callback func({{.OutputType}}){{end}}) {{.OutputType}} {
+	var out {{.OutputType}}
+	out.Status = FAILED
+	out.Error = getErrorObject(501, "not_implemented", "{{.Name}} is not implemented")
+	return out
+}
+{{- end}}
+{{- end}}
+`))
+
+var htmlTemplate = template.Must(template.New("html").Funcs(templateFunctions).Parse(`<!-- Generated by vapiGen from {{.Source}}, the section is inserted in the service-procedures section of VAPIv1.0.html -->
+    <section id="{{htmlId .Name}}-service-group">
+      <h2>{{.Name}} Service Group</h2>
+      <p>
+      {{html .Description}}
+      </p>
+{{- range .Structs}}
+
+    <section id="{{htmlId .Name}}">
+      <h2>{{.Name}}</h2>
+        <p>{{html .Description}}</p>
+        <pre><code>
+        {
+{{- range $i, $member := .Members}}{{if $i}},{{end}}
+          {{.Name}}: {{.Datatype}}
+{{- end}}
+        }
+        </code></pre>
+        Struct member descriptions:<br>
+{{- range .Members}}
+        {{.Name}}: {{html .Description}}<br>
+{{- end}}
+    </section>
+{{- end}}
+{{- range .Procedures}}
+
+    <section id="{{htmlId .Name}}-procedure">
+      <h2>{{.Name}}</h2>
+        <p>{{html .Description}}</p>
+{{- if .Session}}
+
+        <p>
+        A client can terminate the service execution by invokation of <a href="#cancel-service-procedure">CancelService</a>.
+        </p>
+{{- end}}
+
+        <section id="{{htmlId .Name}}-signature">
+          <h2>Signature</h2>
+          <pre><code>{{.SpecSignature}}</code></pre>
+        </section>
+
+        <section id="{{htmlId .Name}}-parameters">
+          <h2>Parameters</h2>
+          <ul class="param-list">
+            <li>
+              <strong>vehicleId</strong> (<span class="type">uint32</span>)
+              <br>
+              A reference to the vehicle that was obtained in a previous <a href="#getvehicle-procedure">GetVehicle</a>.
+            </li>
+{{- range .Inputs}}
+            <li>
+              <strong>{{param .Name}}</strong> (<span class="type">{{.Datatype}}</span>)
+              <br>
+              {{html .Description}}{{with .Unit}} The unit is {{html .}}.{{end}}{{if .Min}} The minimum is {{number .Min}}.{{end}}{{if .Max}} The maximum is {{number .Max}}.{{end}}{{with .Allowed}} The allowed values are{{range $i, $value := .}}{{if $i}},{{end}} "{{html $value}}"{{end}}.{{end}}
+            </li>
+{{- end}}
+{{- if not .Property}}
+            <li>
+              <strong>stCredentials</strong> (<span class="type">string</span>)
+              <br>
+              The short term credentials that was obtained by a call to <a href="#get-st-credentials-procedure">GetStCredentials</a>.
+            </li>
+{{- end}}
+{{- if .Session}}
+            <li>
+              <strong>callback</strong> (<span class="type">*fcn({{.OutputType}})</span>)
+              <br>
+              A pointer to a callback function that is called if an event message gets issued by the service.
+              If set to nil callbacks will not be issued.
+            </li>
+{{- end}}
+          </ul>
+        </section>
+
+        <section id="{{htmlId .Name}}-returns">
+          <h2>Returns</h2>
+            <span class="type"><code>{{.OutputType}}</code></span>
+            <br>
+            A struct containing the output data from the {{.Name}} procedure.
+{{- if .Session}}
+            This shall be returned both synchronously on the procedure  call and asynchronously on event trigger conditions.
+{{- end}}
+            <pre><code>
+            {
+              Status: ProcedureStatus,
+              Error: *ErrorData{{range .Outputs}},
+              {{.Name}}: {{.Datatype}}{{end}}{{if .Session}},
+              ServiceId: uint32{{end}}
+            }
+            </code></pre>
+            Struct member descriptions:<br>
+            <a href="#procedure-status">Status: </a>The status of the latest service call to it.<br>
+            <a href="#error-data">Error: </a>Error information for the latest service call, if error occurred.<br>
+{{- range .Outputs}}
+            {{.Name}}: {{html .Description}}<br>
+{{- end}}
+{{- if .Session}}
+            ServiceId: A reference to the service session that the client may use to call <a href="#cancel-service-procedure">CancelService</a>.
+            It may be set to zero in which case it is invalid.
+{{- end}}
+        </section>
+
+        <section id="{{htmlId .Name}}-error">
+          <h2>Error</h2>
+          <ul class="error-list">
+            <li>
+            If Status is set to FAILED then Error must be available and populated with error data, else the pointer to Error shall be set to nil.
+            </li>
+            <li>
+            Error data shall conform to the error data definitions in [[VISS]] for signals.
+            </li>
+{{- with .OutputNames}}
+            <li>
+             The {{.}} invalid if Status is set to FAILED.
+            </li>
+{{- end}}
+          </ul>
+        </section>
+    </section>
+{{- end}}
+    </section>
+`))
+
+// ****************** Template data ***************
+type groupData struct {
+	*serviceGroup
+	Source string
+	Procedures []procedureData
+}
+
+type procedureData struct {
+	*procedureDef
+}
+
+func newGroupData(group *serviceGroup, source string) groupData {
+	data := groupData{serviceGroup: group, Source: source}
+	for _, proc := range group.Procedures {
+		data.Procedures = append(data.Procedures, procedureData{proc})
+	}
+	return data
+}
+
+// Constants returns the allowed values of the string inputs, the generated constant is the value in upper case.
+func (data groupData) Constants() []string {
+	var constants []string
+	for _, proc := range data.serviceGroup.Procedures {
+		for _, input := range proc.Inputs {
+			for _, value := range input.Allowed {
+				if !contains(constants, value) {
+					constants = append(constants, value)
+				}
+			}
+		}
+	}
+	return constants
+}
+
+func (data groupData) Aliases() []string {
+	var aliases []string
+	for _, structType := range data.Structs {
+		aliases = append(aliases, structType.Name)
+	}
+	for _, proc := range data.Procedures {
+		if len(proc.Inputs) > 0 {
+			aliases = append(aliases, proc.Name + "Input")
+		}
+		if proc.HasOutputType() {
+			aliases = append(aliases, proc.Name + "Output")
+		}
+	}
+	return aliases
+}
+
+func (data groupData) Imports() []string {
+	var imports []string
+	for _, proc := range data.Procedures {
+		if proc.Property && !contains(imports, "encoding/json") {
+			imports = append(imports, "encoding/json")
+		}
+	}
+	if len(data.Aliases()) > 0 || len(data.Constants()) > 0 {
+		imports = append(imports, "VISS-Go/vapi")
+	}
+	return imports
+}
+
+// HasOutputType is true if the procedure has its own output type, else it returns a GeneralOutput.
+func (proc procedureData) HasOutputType() bool {
+	return proc.Session || len(proc.Outputs) > 0
+}
+
+func (proc procedureData) OutputType() string {
+	if proc.HasOutputType() {
+		return proc.Name + "Output"
+	}
+	return "GeneralOutput"
+}
+
+func (proc procedureData) Signature() string {
+	parameters := []string{"vehicleId VehicleHandle"}
+	for _, input := range proc.Inputs {
+		parameters = append(parameters, param(input.Name) + " " + goType(input.Datatype))
+	}
+	if !proc.Property {
+		parameters = append(parameters, "stCredentials string")
+	}
+	if proc.Session {
+		parameters = append(parameters, "callback func(" + proc.OutputType() + ")")
+	}
+	return proc.Name + "(" + strings.Join(parameters, ", ") + ") " + proc.OutputType()
+}
+
+func (proc procedureData) Arguments() string {
+	arguments := []string{"vehicleId"}
+	for _, input := range proc.Inputs {
+		arguments = append(arguments, param(input.Name))
+	}
+	if !proc.Property {
+		arguments = append(arguments, "stCredentials")
+	}
+	if proc.Session {
+		arguments = append(arguments, "callback")
+	}
+	return strings.Join(arguments, ", ")
+}
+
+// SpecSignature is the signature in the format of the VAPI specification.
+func (proc procedureData) SpecSignature() string {
+	parameters := []string{"vehicleId: uint32"}
+	for _, input := range proc.Inputs {
+		parameters = append(parameters, param(input.Name) + ": " + input.Datatype)
+	}
+	if !proc.Property {
+		parameters = append(parameters, "stCredentials: string")
+	}
+	if proc.Session {
+		parameters = append(parameters, "callback: *fcn(" + proc.OutputType() + ")")
+	}
+	return proc.Name + "(" + strings.Join(parameters, ", ") + "): " + proc.OutputType()
+}
+
+// OutputNames is the text that lists the outputs in the error section.
+func (proc procedureData) OutputNames() string {
+	var names []string
+	for _, output := range proc.Outputs {
+		names = append(names, output.Name)
+	}
+	if proc.Session {
+		names = append(names, "ServiceId")
+	}
+	switch len(names) {
+		case 0:
+			return ""
+		case 1:
+			return names[0] + " parameter is"
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1] + " parameters are"
+}
+
+// ****************** Template functions ***************
+func goType(datatype string) string {
+	if elementType, ok := strings.CutSuffix(datatype, "[]"); ok {
+		return "[]" + goType(elementType)
+	}
+	if himDatatypes[datatype] != "" {
+		return himDatatypes[datatype]
+	}
+	return datatype
+}
+
+// param returns the name of a Go parameter, or of an implementation function, from a node name.
+func param(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+func constName(value string) string {
+	name := strings.ToUpper(nonIdentifier.ReplaceAllString(value, "_"))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+var wordBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+func htmlId(name string) string {
+	return strings.ToLower(wordBoundary.ReplaceAllString(name, "$1-$2"))
+}
+
+// getRangeCheck returns the condition that an input is out of range, a Percentage is in the range 0 to 100 unless min or max is defined.
+func getRangeCheck(input *member) string {
+	var conditions []string
+	minimum, maximum := input.Min, input.Max
+	if input.Datatype == "Percentage" {
+		zero, hundred := 0.0, 100.0
+		if minimum == nil {
+			minimum = &zero
+		}
+		if maximum == nil {
+			maximum = &hundred
+		}
+	}
+	if minimum != nil {
+		conditions = append(conditions, param(input.Name) + " < " + strconv.FormatFloat(*minimum, 'g', -1, 64))
+	}
+	if maximum != nil {
+		conditions = append(conditions, param(input.Name) + " > " + strconv.FormatFloat(*maximum, 'g', -1, 64))
+	}
+	return strings.Join(conditions, " || ")
+}
+
+func contains(list []string, value string) bool {
+	for _, element := range list {
+		if element == value {
+			return true
+		}
+	}
+	return false
+}
+
+func execute(tmpl *template.Template, data groupData) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := tmpl.Execute(&buffer, data)
+	return buffer.Bytes(), err
+}
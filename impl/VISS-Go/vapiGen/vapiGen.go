@@ -0,0 +1,91 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+/* vapiGen generates the Go code and the specification section of a service group from its HIM service definition, see vspec.go.
+*  $ go run ./vapiGen [-o dir] [-spec dir] <Group>.vspec
+*  writes vapi/<Group>.go, VapiViss/<Group>Procedures.go, and VapiViss/<Group>.go if it does not exist, below the -o directory,
+*  and <Group>/<Group>.html below the -spec directory. */
+
+type generatedFile struct {
+	path string
+	tmpl *template.Template
+	isGo bool
+	keep bool  // an existing file is not overwritten
+}
+
+func main() {
+	outDir := flag.String("o", ".", "the impl/VISS-Go directory that the Go files are generated in")
+	specDir := flag.String("spec", "../../spec/Service", "the directory that the specification section is generated in, none if empty")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: vapiGen [-o dir] [-spec dir] <Group>.vspec\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	err := generate(flag.Arg(0), *outDir, *specDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "vapiGen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func generate(definition string, outDir string, specDir string) error {
+	group, err := readServiceGroup(definition)
+	if err != nil {
+		return err
+	}
+	files := []generatedFile{
+		{filepath.Join(outDir, "vapi", group.Name + ".go"), vapiTemplate, true, false},
+		{filepath.Join(outDir, "VapiViss", group.Name + "Procedures.go"), proceduresTemplate, true, false},
+		{filepath.Join(outDir, "VapiViss", group.Name + ".go"), stubsTemplate, true, true},
+	}
+	if specDir != "" {
+		files = append(files, generatedFile{filepath.Join(specDir, group.Name, group.Name + ".html"), htmlTemplate, false, false})
+	}
+	data := newGroupData(group, filepath.Base(definition))
+	for _, file := range files {
+		if _, err := os.Stat(file.path); file.keep && err == nil {
+			fmt.Printf("%s exists, it is not generated\n", file.path)
+			continue
+		}
+		content, err := execute(file.tmpl, data)
+		if err != nil {
+			return err
+		}
+		if file.isGo {
+			_, err = parser.ParseFile(token.NewFileSet(), file.path, content, parser.AllErrors)
+			if err != nil {
+				return fmt.Errorf("the generated code is invalid: %s", err)
+			}
+		}
+		err = os.MkdirAll(filepath.Dir(file.path), 0755)
+		if err == nil {
+			err = os.WriteFile(file.path, content, 0644)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s generated\n", file.path)
+	}
+	return nil
+}
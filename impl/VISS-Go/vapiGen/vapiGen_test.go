@@ -0,0 +1,93 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerate generates the example service group, and builds the vapi and VapiViss packages with the generated files added by an overlay.
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	err := generate("testdata/Windows.vspec", dir, filepath.Join(dir, "spec"))
+	if err != nil {
+		t.Fatalf("generate: %s", err)
+	}
+	moduleDir, _ := filepath.Abs("..")
+	overlay := map[string]map[string]string{"Replace": {}}
+	for _, file := range []string{"vapi/Windows.go", "VapiViss/WindowsProcedures.go", "VapiViss/Windows.go"} {
+		overlay["Replace"][filepath.Join(moduleDir, file)] = filepath.Join(dir, file)
+	}
+	overlayData, _ := json.Marshal(overlay)
+	os.WriteFile(filepath.Join(dir, "overlay.json"), overlayData, 0644)
+	build := exec.Command("go", "vet", "-overlay", filepath.Join(dir, "overlay.json"), "./vapi", "./VapiViss")
+	build.Dir = moduleDir
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("the generated code does not build: %s\n%s", err, output)
+	}
+
+	procedures, _ := os.ReadFile(filepath.Join(dir, "VapiViss/WindowsProcedures.go"))
+	for _, expected := range []string{
+		"func MoveWindow(vehicleId VehicleHandle, windowId MatrixId, position Percentage, stCredentials string, callback func(MoveWindowOutput)) MoveWindowOutput {",
+		"if position < 0 || position > 100 {",
+		"if mode != LOCKED && mode != UNLOCKED {",
+		"if row < 1 || row > 3 {",
+		"return setChildLock(vehicleId, vehConn, SetChildLockInput{Mode: mode, Row: row}, stCredentials)",
+		"func (Backend) GetPropertiesWindows(vehicleId VehicleHandle) GetPropertiesWindowsOutput {",
+	} {
+		if !strings.Contains(string(procedures), expected) {
+			t.Errorf("WindowsProcedures.go has no %q", expected)
+		}
+	}
+	html, _ := os.ReadFile(filepath.Join(dir, "spec/Windows/Windows.html"))
+	for _, expected := range []string{
+		`<section id="windows-service-group">`,
+		"<pre><code>MoveWindow(vehicleId: uint32, windowId: MatrixId, position: Percentage, stCredentials: string, callback: *fcn(MoveWindowOutput)): MoveWindowOutput</code></pre>",
+		"The Position and ServiceId parameters are invalid if Status is set to FAILED.",
+		`The allowed values are "locked", "unlocked".`,
+	} {
+		if !strings.Contains(string(html), expected) {
+			t.Errorf("Windows.html has no %q", expected)
+		}
+	}
+
+	stubs := filepath.Join(dir, "VapiViss/Windows.go")
+	os.WriteFile(stubs, []byte("package VapiViss\n"), 0644)
+	err = generate("testdata/Windows.vspec", dir, "")
+	if content, _ := os.ReadFile(stubs); err != nil || string(content) != "package VapiViss\n" {
+		t.Errorf("the implementations were overwritten, error=%v", err)
+	}
+}
+
+func TestDefinitionErrors(t *testing.T) {
+	service := "Windows:\n  type: service\n"
+	tests := []struct {
+		definition string
+		expected string
+	}{
+		{"Windows.Move:\n  type: procedure\n", "1: the first node must be the service node"},
+		{service + "Doors.Open:\n  type: procedure\n", "3: Doors.Open: the node is not in the service group Windows"},
+		{service + "Windows.Move:\n  type: procedure\nWindows.Move.Input.Position:\n  type: property\n", "5: Windows.Move.Input.Position: the datatype is missing"},
+		{service + "Windows.Move:\n  type: procedure\nWindows.Move.Input.Position:\n  type: property\n  datatype: Angle\n", "Move.Input.Position: the datatype Angle is not defined"},
+		{service + "Windows.Move:\n  type: procedure\nWindows.Move.Output.ServiceId:\n  type: property\n  datatype: uint32\n", "Move.Output.ServiceId: the output is implicit"},
+		{service + "Windows.Properties:\n  type: procedure\n  property: true\nWindows.Properties.Output.Rows:\n  type: property\n  datatype: string[]\n", "6: Windows.Properties.Output.Rows: an output of a property procedure must have a default"},
+		{service + "Windows.Open.Input.Position:\n  type: property\n  datatype: Percentage\n", "3: Windows.Open.Input.Position: the node does not belong to a struct or a procedure of the group"},
+	}
+	for _, test := range tests {
+		_, err := parseServiceGroup([]byte(test.definition))
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("definition:\n%s\nerror=%v, expected %q", test.definition, err, test.expected)
+		}
+	}
+}
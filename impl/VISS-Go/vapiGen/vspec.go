@@ -0,0 +1,287 @@
+/**
+* (C) 2025 Ford Motor Company
+*
+* All files and artifacts in the repository at https://github.com/ulfbj/Vehicle-Service-API
+* are licensed under the provisions of the license provided by the LICENSE file in this repository.
+*
+**/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ****************** Service definitions ***************
+/* A service group is defined in the HIM service profile, a vspec file where each node is keyed by its path, in the order
+*    <Group>                            type: service
+*    <Group>.<Struct>                   type: struct, a datatype of the group
+*    <Group>.<Struct>.<Member>          type: property
+*    <Group>.<Procedure>                type: procedure, with session: true if the service has a temporal duration,
+*                                       or property: true for a property procedure that returns vehicle configuration data
+*    <Group>.<Procedure>.Input          type: iostruct, optional
+*    <Group>.<Procedure>.Input.<Name>   type: property, an input parameter
+*    <Group>.<Procedure>.Output.<Name>  type: property, an output parameter
+*  A property has a datatype, which is a HIM datatype, a struct of the group, one of the VAPI datatypes MatrixId and Percentage,
+*  or an array of them by the suffix [], and may have a description, unit, min, max, and allowed values.
+*  The outputs of a property procedure have a default value, which is returned by the generated procedure.
+*  The parameters vehicleId and stCredentials, and callback for sessions, are implicit, as are the outputs Status, Error, and ServiceId for sessions. */
+type vspecNode struct {
+	Type string `yaml:"type"`
+	Datatype string `yaml:"datatype"`
+	Description string `yaml:"description"`
+	Unit string `yaml:"unit"`
+	Min *float64 `yaml:"min"`
+	Max *float64 `yaml:"max"`
+	Allowed []string `yaml:"allowed"`
+	Default interface{} `yaml:"default"`
+	Session bool `yaml:"session"`
+	Property bool `yaml:"property"`
+}
+
+type serviceGroup struct {
+	Name string
+	Description string
+	Structs []*structType
+	Procedures []*procedureDef
+}
+
+type structType struct {
+	Name string
+	Description string
+	Members []*member
+}
+
+type member struct {
+	Name string
+	Datatype string
+	Description string
+	Unit string
+	Min *float64
+	Max *float64
+	Allowed []string
+	Default string  // JSON
+}
+
+type procedureDef struct {
+	Name string
+	Description string
+	Session bool
+	Property bool
+	Inputs []*member
+	Outputs []*member
+}
+
+var himDatatypes = map[string]string{
+	"uint8": "uint8", "uint16": "uint16", "uint32": "uint32", "uint64": "uint64",
+	"int8": "int8", "int16": "int16", "int32": "int32", "int64": "int64",
+	"float": "float32", "double": "float64", "boolean": "bool", "string": "string",
+}
+
+var vapiDatatypes = []string{"MatrixId", "Percentage"}
+
+func readServiceGroup(fileName string) (*serviceGroup, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	group, err := parseServiceGroup(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%s", fileName, err)
+	}
+	return group, nil
+}
+
+func parseServiceGroup(data []byte) (*serviceGroup, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("1: the definition is not a map of nodes")
+	}
+	var group *serviceGroup
+	root := document.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		path := root.Content[i].Value
+		var node vspecNode
+		err = root.Content[i+1].Decode(&node)
+		if err != nil {
+			return nil, fmt.Errorf("%d: %s", root.Content[i].Line, err)
+		}
+		if group == nil {
+			if node.Type != "service" || strings.Contains(path, ".") {
+				return nil, fmt.Errorf("%d: the first node must be the service node of the group", root.Content[i].Line)
+			}
+			group = &serviceGroup{Name: path, Description: node.Description}
+			continue
+		}
+		err = group.addNode(strings.Split(path, "."), node)
+		if err != nil {
+			return nil, fmt.Errorf("%d: %s: %s", root.Content[i].Line, path, err)
+		}
+	}
+	if group == nil {
+		return nil, fmt.Errorf("1: the definition has no service node")
+	}
+	return group, group.check()
+}
+
+func (group *serviceGroup) addNode(names []string, node vspecNode) error {
+	if names[0] != group.Name {
+		return fmt.Errorf("the node is not in the service group %s", group.Name)
+	}
+	for _, name := range names {
+		if !isIdentifier(name) {
+			return fmt.Errorf("%s is not a valid name", name)
+		}
+	}
+	switch len(names) {
+		case 2:
+			if group.getStruct(names[1]) != nil || group.getProcedure(names[1]) != nil {
+				return fmt.Errorf("the node is defined twice")
+			}
+			switch node.Type {
+				case "struct":
+					group.Structs = append(group.Structs, &structType{Name: names[1], Description: node.Description})
+					return nil
+				case "procedure":
+					if node.Session && node.Property {
+						return fmt.Errorf("a property procedure can not have a session")
+					}
+					group.Procedures = append(group.Procedures, &procedureDef{Name: names[1], Description: node.Description, Session: node.Session, Property: node.Property})
+					return nil
+			}
+			return fmt.Errorf("the type must be struct or procedure")
+		case 3:
+			if node.Type == "iostruct" && (names[2] == "Input" || names[2] == "Output") && group.getProcedure(names[1]) != nil {
+				return nil
+			}
+			if structType := group.getStruct(names[1]); structType != nil && node.Type == "property" {
+				newMember, err := getMember(names[2], node)
+				if err != nil {
+					return err
+				}
+				structType.Members = append(structType.Members, newMember)
+				return nil
+			}
+		case 4:
+			proc := group.getProcedure(names[1])
+			if proc != nil && node.Type == "property" {
+				newMember, err := getMember(names[3], node)
+				if err != nil {
+					return err
+				}
+				switch names[2] {
+					case "Input":
+						if proc.Property {
+							return fmt.Errorf("a property procedure has no inputs")
+						}
+						proc.Inputs = append(proc.Inputs, newMember)
+						return nil
+					case "Output":
+						if proc.Property && newMember.Default == "" {
+							return fmt.Errorf("an output of a property procedure must have a default")
+						}
+						proc.Outputs = append(proc.Outputs, newMember)
+						return nil
+				}
+			}
+	}
+	return fmt.Errorf("the node does not belong to a struct or a procedure of the group")
+}
+
+func getMember(name string, node vspecNode) (*member, error) {
+	if node.Datatype == "" {
+		return nil, fmt.Errorf("the datatype is missing")
+	}
+	newMember := &member{Name: name, Datatype: node.Datatype, Description: node.Description, Unit: node.Unit, Min: node.Min, Max: node.Max, Allowed: node.Allowed}
+	if node.Default != nil {
+		defaultValue, err := json.Marshal(node.Default)
+		if err != nil {
+			return nil, fmt.Errorf("the default is not a JSON value: %s", err)
+		}
+		newMember.Default = string(defaultValue)
+	}
+	return newMember, nil
+}
+
+// check verifies that the datatypes are defined, and that the names do not collide with the implicit parameters.
+func (group *serviceGroup) check() error {
+	for _, structType := range group.Structs {
+		if len(structType.Members) == 0 {
+			return fmt.Errorf("the struct %s has no members", structType.Name)
+		}
+		for _, structMember := range structType.Members {
+			if err := group.checkDatatype(structMember.Datatype); err != nil {
+				return fmt.Errorf("%s.%s: %s", structType.Name, structMember.Name, err)
+			}
+		}
+	}
+	for _, proc := range group.Procedures {
+		for _, input := range proc.Inputs {
+			if err := group.checkDatatype(input.Datatype); err != nil {
+				return fmt.Errorf("%s.Input.%s: %s", proc.Name, input.Name, err)
+			}
+			if input.Name == "VehicleId" || input.Name == "StCredentials" || input.Name == "Callback" {
+				return fmt.Errorf("%s.Input.%s: the input is implicit", proc.Name, input.Name)
+			}
+		}
+		for _, output := range proc.Outputs {
+			if err := group.checkDatatype(output.Datatype); err != nil {
+				return fmt.Errorf("%s.Output.%s: %s", proc.Name, output.Name, err)
+			}
+			if output.Name == "Status" || output.Name == "Error" || output.Name == "ServiceId" {
+				return fmt.Errorf("%s.Output.%s: the output is implicit", proc.Name, output.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (group *serviceGroup) checkDatatype(datatype string) error {
+	datatype = strings.TrimSuffix(datatype, "[]")
+	if himDatatypes[datatype] != "" || group.getStruct(datatype) != nil {
+		return nil
+	}
+	for _, vapiDatatype := range vapiDatatypes {
+		if datatype == vapiDatatype {
+			return nil
+		}
+	}
+	return fmt.Errorf("the datatype %s is not defined", datatype)
+}
+
+func (group *serviceGroup) getStruct(name string) *structType {
+	for _, structType := range group.Structs {
+		if structType.Name == name {
+			return structType
+		}
+	}
+	return nil
+}
+
+func (group *serviceGroup) getProcedure(name string) *procedureDef {
+	for _, proc := range group.Procedures {
+		if proc.Name == name {
+			return proc
+		}
+	}
+	return nil
+}
+
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != "" && unicode.IsUpper([]rune(name)[0])
+}